@@ -1,6 +1,8 @@
 package main
 
 import (
+	"os"
+
 	"github.com/shashaneRanasinghe/simpleAPI/internal/config"
 	"github.com/shashaneRanasinghe/simpleAPI/pkg/server"
 	"github.com/tryfix/log"
@@ -8,8 +10,20 @@ import (
 
 func main() {
 
-	config.LoadConfigs()
-	closeChannel := server.Serve()
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err := migrate(os.Args[2:])
+		if err != nil {
+			log.Fatal("Error running migrations ", err)
+		}
+		return
+	}
+
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatal("Error loading configs ", err)
+	}
+
+	closeChannel := server.Serve(cfg)
 	<-closeChannel
 
 	log.Info("Service Stopped")
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/config"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/migrations"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/database"
+)
+
+const migrateUsage = "usage: migrate up | migrate down N | migrate status, followed by the config flags"
+
+// migrate runs the migrate subcommand. args are the arguments after
+// "migrate", the ones after the command are the usual config flags
+func migrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	command, args := args[0], args[1:]
+
+	// the command is checked before the database is opened, so a misspelt
+	// one gets the usage rather than a connection error
+	steps := 0
+	switch command {
+	case "up", "status":
+	case "down":
+		if len(args) == 0 {
+			return errors.New(migrateUsage)
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return fmt.Errorf("migrate down needs a positive number of migrations, got %q", args[0])
+		}
+		steps, args = n, args[1:]
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	cfg, err := config.LoadDatabase(args)
+	if err != nil {
+		return err
+	}
+	if cfg.Database.Driver == config.DriverMemory {
+		return errors.New("the memory driver has no schema to migrate")
+	}
+
+	db := database.NewDatabase()
+	db.InitDatabase(cfg.Database)
+	conn := db.GetConnection()
+	defer conn.Close()
+
+	migrator, err := migrations.New(conn, cfg.Database.Driver)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Applied %d migrations\n", applied)
+	case "down":
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Reverted %d migrations\n", reverted)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+		return w.Flush()
+	}
+	return nil
+}
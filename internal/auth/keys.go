@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// The signing algorithms the keys can be used with
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+)
+
+// Key is a verification key of the key set
+type Key struct {
+	ID        string
+	Algorithm string
+	// Key is a []byte secret for HS256 and an *rsa.PublicKey for RS256
+	Key interface{}
+}
+
+// KeySet holds the keys tokens are verified with
+type KeySet struct {
+	keys []Key
+}
+
+func NewKeySet(keys ...Key) *KeySet {
+	return &KeySet{keys: keys}
+}
+
+// find returns the key a token signed with alg and kid is verified with.
+// A token without a kid is verified with the only key of its algorithm
+func (k *KeySet) find(alg string, kid string) (Key, error) {
+	var found []Key
+	for _, key := range k.keys {
+		if key.Algorithm == alg && (kid == "" || key.ID == kid) {
+			found = append(found, key)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return Key{}, fmt.Errorf("no %s key with id %q", alg, kid)
+	case 1:
+		return found[0], nil
+	default:
+		return Key{}, fmt.Errorf("the token must name one of the %s keys", alg)
+	}
+}
+
+// jwk is a JSON Web Key, only the fields of oct and RSA keys are read
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	K   string `json:"k"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// LoadKeySet reads a JWKS document from a local file or, when source is an
+// http(s) URL, downloads it once
+func LoadKeySet(source string) (*KeySet, error) {
+	var b []byte
+	var err error
+
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		b, err = download(source)
+	} else {
+		b, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key set %s : %w", source, err)
+	}
+
+	keys, err := ParseKeySet(b)
+	if err != nil {
+		return nil, fmt.Errorf("key set %s : %w", source, err)
+	}
+	return keys, nil
+}
+
+// ParseKeySet parses a JWKS document. HS256 keys are oct keys and RS256 keys
+// are RSA keys, keys meant for encryption are skipped
+func ParseKeySet(document []byte) (*KeySet, error) {
+	var jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+	err := json.Unmarshal(document, &jwks)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []Key
+	for i, k := range jwks.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.parse()
+		if err != nil {
+			return nil, fmt.Errorf("key %d : %w", i, err)
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("no signing keys found")
+	}
+	return NewKeySet(keys...), nil
+}
+
+func (k jwk) parse() (Key, error) {
+	switch k.Kty {
+	case "oct":
+		if k.Alg != "" && k.Alg != HS256 {
+			return Key{}, fmt.Errorf("algorithm %s is not supported for oct keys", k.Alg)
+		}
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil || len(secret) == 0 {
+			return Key{}, errors.New("invalid oct key")
+		}
+		return Key{ID: k.Kid, Algorithm: HS256, Key: secret}, nil
+	case "RSA":
+		if k.Alg != "" && k.Alg != RS256 {
+			return Key{}, fmt.Errorf("algorithm %s is not supported for RSA keys", k.Alg)
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil || len(n) == 0 {
+			return Key{}, errors.New("invalid RSA modulus")
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return Key{}, errors.New("invalid RSA exponent")
+		}
+		publicKey := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+		return Key{ID: k.Kid, Algorithm: RS256, Key: publicKey}, nil
+	default:
+		return Key{}, fmt.Errorf("key type %q is not supported", k.Kty)
+	}
+}
+
+func download(url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
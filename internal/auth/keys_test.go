@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseKeySet(t *testing.T) {
+	rsaKey := newRSAKey(t)
+	document := `{"keys":[
+		{"kty":"oct","kid":"hmac","alg":"HS256","k":"` + base64.RawURLEncoding.EncodeToString(secret) + `"},
+		{"kty":"RSA","kid":"rsa","use":"sig","n":"` +
+		base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()) + `","e":"` +
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()) + `"},
+		{"kty":"RSA","kid":"encryption","use":"enc","n":"AQAB","e":"AQAB"}
+	]}`
+
+	keys, err := ParseKeySet([]byte(document))
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	hmac, err := keys.find(HS256, "hmac")
+	if err != nil || string(hmac.Key.([]byte)) != string(secret) {
+		t.Errorf("Expected the HS256 key, but got %v, %v", hmac, err)
+	}
+	rsaPublic, err := keys.find(RS256, "rsa")
+	if err != nil || !rsaPublic.Key.(*rsa.PublicKey).Equal(&rsaKey.PublicKey) {
+		t.Errorf("Expected the RS256 key, but got %v, %v", rsaPublic, err)
+	}
+	if _, err := keys.find(RS256, "encryption"); err == nil {
+		t.Error("Expected encryption keys to be skipped")
+	}
+}
+
+func TestParseKeySet_Invalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		document string
+	}{
+		{name: "Not JSON", document: "keys"},
+		{name: "No Keys", document: `{"keys":[]}`},
+		{name: "Unsupported Type", document: `{"keys":[{"kty":"EC","crv":"P-256"}]}`},
+		{name: "Unsupported Algorithm", document: `{"keys":[{"kty":"oct","alg":"HS512","k":"c2VjcmV0"}]}`},
+		{name: "Empty Secret", document: `{"keys":[{"kty":"oct","k":""}]}`},
+		{name: "Bad Modulus", document: `{"keys":[{"kty":"RSA","n":"!","e":"AQAB"}]}`},
+	}
+
+	for _, test := range testCases {
+		_, err := ParseKeySet([]byte(test.document))
+		if err == nil {
+			t.Errorf("Test %s : Expected an error", test.name)
+		}
+	}
+}
+
+func TestLoadKeySet(t *testing.T) {
+	document := `{"keys":[{"kty":"oct","kid":"hmac","k":"c2VjcmV0"}]}`
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	err := os.WriteFile(path, []byte(document), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(document))
+	}))
+	defer server.Close()
+
+	for _, source := range []string{path, server.URL} {
+		keys, err := LoadKeySet(source)
+		if err != nil {
+			t.Errorf("Test %s : Unexpected error %v", source, err)
+			continue
+		}
+		if _, err := keys.find(HS256, "hmac"); err != nil {
+			t.Errorf("Test %s : Expected the HS256 key, but got %v", source, err)
+		}
+	}
+
+	_, err = LoadKeySet(filepath.Join(t.TempDir(), "missing.json"))
+	if err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Action is what a route does to a resource. Restore undeletes a soft
+// deleted record and also covers reading the deleted records, no scope
+// grants it
+type Action string
+
+const (
+	ActionList    Action = "list"
+	ActionRead    Action = "read"
+	ActionCreate  Action = "create"
+	ActionUpdate  Action = "update"
+	ActionDelete  Action = "delete"
+	ActionSearch  Action = "search"
+	ActionRestore Action = "restore"
+)
+
+// wildcard matches every role, resource or action
+const wildcard = "*"
+
+// Rule allows the callers with Role to perform Actions on Resource. An Own
+// rule only allows the caller's own record, the one whose id is the subject
+// of the token, so it never matches a route without an id in its path
+type Rule struct {
+	Role     string   `yaml:"role"`
+	Resource string   `yaml:"resource"`
+	Actions  []Action `yaml:"actions"`
+	Own      bool     `yaml:"own"`
+}
+
+// Policy is a list of allow rules, a request no rule matches is denied
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Request describes the action a caller wants to perform. ResourceID is the
+// id in the route path, empty when the route has none
+type Request struct {
+	Subject    string
+	Roles      []string
+	Scopes     []string
+	Resource   string
+	Action     Action
+	ResourceID string
+}
+
+// Decision is the outcome of evaluating a request. An allowed request is
+// allowed by either the matching Rule or the granting Scope
+type Decision struct {
+	Allowed bool
+	Rule    *Rule
+	Scope   string
+}
+
+// DefaultPolicy is used when no policy file is configured. Admins may do
+// everything, lecturers may read students, lecturers and enrolments and
+// record grades, everyone may read the courses and departments and students
+// may read their own record, grades and transcript and manage their own
+// enrolments
+func DefaultPolicy() *Policy {
+	return &Policy{Rules: []Rule{
+		{Role: "admin", Resource: wildcard, Actions: []Action{wildcard}},
+		{Role: "lecturer", Resource: "student", Actions: []Action{ActionList, ActionRead, ActionSearch}},
+		{Role: "lecturer", Resource: "lecturer", Actions: []Action{ActionList, ActionRead, ActionSearch}},
+		{Role: "lecturer", Resource: "course", Actions: []Action{ActionList, ActionRead, ActionSearch}},
+		{Role: "lecturer", Resource: "enrolment", Actions: []Action{ActionList}},
+		{Role: "lecturer", Resource: "grade", Actions: []Action{ActionList, ActionRead, ActionCreate, ActionUpdate}},
+		{Role: "lecturer", Resource: "department", Actions: []Action{ActionList, ActionRead, ActionSearch}},
+		{Role: "student", Resource: "student", Actions: []Action{ActionRead}, Own: true},
+		{Role: "student", Resource: "course", Actions: []Action{ActionList, ActionRead, ActionSearch}},
+		{Role: "student", Resource: "enrolment", Actions: []Action{ActionList, ActionCreate, ActionDelete},
+			Own: true},
+		{Role: "student", Resource: "grade", Actions: []Action{ActionList, ActionRead}, Own: true},
+		{Role: "student", Resource: "department", Actions: []Action{ActionList, ActionRead, ActionSearch}},
+	}}
+}
+
+// LoadPolicy reads a YAML policy file
+func LoadPolicy(path string) (*Policy, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("policy file : %w", err)
+	}
+
+	var policy Policy
+	err = yaml.Unmarshal(b, &policy)
+	if err != nil {
+		return nil, fmt.Errorf("policy file %s : %w", path, err)
+	}
+
+	err = policy.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("policy file %s : %w", path, err)
+	}
+	return &policy, nil
+}
+
+// Validate returns every problem found in the rules
+func (p *Policy) Validate() error {
+	var errs []error
+	for i, rule := range p.Rules {
+		if rule.Role == "" || rule.Resource == "" {
+			errs = append(errs, fmt.Errorf("rule %d : role and resource are required", i))
+		}
+		if len(rule.Actions) == 0 {
+			errs = append(errs, fmt.Errorf("rule %d : at least one action is required", i))
+		}
+		for _, action := range rule.Actions {
+			switch action {
+			case ActionList, ActionRead, ActionCreate, ActionUpdate, ActionDelete, ActionSearch, ActionRestore,
+				wildcard:
+			default:
+				errs = append(errs, fmt.Errorf("rule %d : unknown action %q", i, action))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Evaluate returns whether a rule or one of the scopes allows the request
+func (p *Policy) Evaluate(req Request) Decision {
+	if scope, ok := scopeAllows(req.Scopes, req.Resource, req.Action); ok {
+		return Decision{Allowed: true, Scope: scope}
+	}
+
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.matches(req) {
+			return Decision{Allowed: true, Rule: rule}
+		}
+	}
+	return Decision{Allowed: false}
+}
+
+func (r Rule) matches(req Request) bool {
+	if r.Resource != wildcard && r.Resource != req.Resource {
+		return false
+	}
+	if !r.hasRole(req.Roles) || !r.hasAction(req.Action) {
+		return false
+	}
+	if r.Own {
+		return req.ResourceID != "" && req.Subject != "" && req.ResourceID == req.Subject
+	}
+	return true
+}
+
+func (r Rule) hasRole(roles []string) bool {
+	for _, role := range roles {
+		if r.Role == wildcard || r.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) hasAction(action Action) bool {
+	for _, a := range r.Actions {
+		if a == wildcard || a == action {
+			return true
+		}
+	}
+	return false
+}
+
+func (d Decision) String() string {
+	switch {
+	case d.Rule != nil:
+		return fmt.Sprintf("rule %+v", *d.Rule)
+	case d.Scope != "":
+		return "scope " + d.Scope
+	default:
+		return "no rule"
+	}
+}
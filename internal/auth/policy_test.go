@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPolicy_Evaluate_DefaultPolicy(t *testing.T) {
+	policy := DefaultPolicy()
+
+	testCases := []struct {
+		name     string
+		request  Request
+		expected bool
+	}{
+		{name: "Admin Deletes Student", expected: true,
+			request: Request{Subject: "a1", Roles: []string{"admin"}, Resource: "student", Action: ActionDelete,
+				ResourceID: "7"}},
+		{name: "Admin Creates Staff", expected: true,
+			request: Request{Subject: "a1", Roles: []string{"admin"}, Resource: "staff", Action: ActionCreate}},
+		{name: "Lecturer Lists Students", expected: true,
+			request: Request{Subject: "l1", Roles: []string{"lecturer"}, Resource: "student", Action: ActionList}},
+		{name: "Lecturer Reads Student", expected: true,
+			request: Request{Subject: "l1", Roles: []string{"lecturer"}, Resource: "student", Action: ActionRead,
+				ResourceID: "7"}},
+		{name: "Lecturer Deletes Student", expected: false,
+			request: Request{Subject: "l1", Roles: []string{"lecturer"}, Resource: "student", Action: ActionDelete,
+				ResourceID: "7"}},
+		{name: "Admin Restores Student", expected: true,
+			request: Request{Subject: "a1", Roles: []string{"admin"}, Resource: "student", Action: ActionRestore,
+				ResourceID: "7"}},
+		{name: "Lecturer Restores Student", expected: false,
+			request: Request{Subject: "l1", Roles: []string{"lecturer"}, Resource: "student", Action: ActionRestore,
+				ResourceID: "7"}},
+		{name: "Student Reads Own Record", expected: true,
+			request: Request{Subject: "7", Roles: []string{"student"}, Resource: "student", Action: ActionRead,
+				ResourceID: "7"}},
+		{name: "Student Reads Another Record", expected: false,
+			request: Request{Subject: "7", Roles: []string{"student"}, Resource: "student", Action: ActionRead,
+				ResourceID: "8"}},
+		{name: "Student Lists Students", expected: false,
+			request: Request{Subject: "7", Roles: []string{"student"}, Resource: "student", Action: ActionList}},
+		{name: "Student Reads Lecturer", expected: false,
+			request: Request{Subject: "7", Roles: []string{"student"}, Resource: "lecturer", Action: ActionRead,
+				ResourceID: "7"}},
+		{name: "No Roles", expected: false,
+			request: Request{Subject: "7", Resource: "student", Action: ActionRead, ResourceID: "7"}},
+		{name: "Several Roles", expected: true,
+			request: Request{Subject: "l1", Roles: []string{"student", "lecturer"}, Resource: "lecturer",
+				Action: ActionSearch}},
+	}
+
+	for _, test := range testCases {
+		decision := policy.Evaluate(test.request)
+		if decision.Allowed != test.expected {
+			t.Errorf("Test %s : Expected allowed %v, but got %v", test.name, test.expected, decision.Allowed)
+		}
+		if decision.Allowed && decision.Rule == nil {
+			t.Errorf("Test %s : Expected the matching rule", test.name)
+		}
+	}
+}
+
+func writePolicyFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	err := os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPolicy(t *testing.T) {
+	path := writePolicyFile(t, `
+rules:
+  - role: registrar
+    resource: student
+    actions: [create, update]
+`)
+
+	policy, err := LoadPolicy(path)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	allowed := policy.Evaluate(Request{Roles: []string{"registrar"}, Resource: "student", Action: ActionCreate})
+	denied := policy.Evaluate(Request{Roles: []string{"registrar"}, Resource: "student", Action: ActionDelete})
+	if !allowed.Allowed || denied.Allowed {
+		t.Errorf("Expected create to be allowed and delete denied, but got %v and %v", allowed, denied)
+	}
+}
+
+func TestLoadPolicy_Invalid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+	}{
+		{name: "Not YAML", content: "rules: ["},
+		{name: "Missing Role", content: "rules:\n  - resource: student\n    actions: [read]\n"},
+		{name: "Missing Actions", content: "rules:\n  - role: admin\n    resource: student\n"},
+		{name: "Unknown Action", content: "rules:\n  - role: admin\n    resource: student\n    actions: [drop]\n"},
+	}
+
+	for _, test := range testCases {
+		_, err := LoadPolicy(writePolicyFile(t, test.content))
+		if err == nil {
+			t.Errorf("Test %s : Expected an error", test.name)
+		}
+	}
+
+	_, err := LoadPolicy(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The access levels of a scope, read allows the actions that do not change
+// anything and write the ones that do
+const (
+	AccessRead  = "read"
+	AccessWrite = "write"
+)
+
+// ScopeResources are the resources a scope can grant access to
+var ScopeResources = []string{"student", "lecturer", "staff", "course", "enrolment", "grade",
+	"department"}
+
+var scopeActions = map[string][]Action{
+	AccessRead:  {ActionList, ActionRead, ActionSearch},
+	AccessWrite: {ActionCreate, ActionUpdate, ActionDelete},
+}
+
+// ValidateScope checks a scope has the form resource:access, eg. student:read
+func ValidateScope(scope string) error {
+	resource, access, ok := strings.Cut(scope, ":")
+	if !ok {
+		return fmt.Errorf("scope %q must have the form resource:access", scope)
+	}
+	if !contains(ScopeResources, resource) {
+		return fmt.Errorf("scope %q names an unknown resource, it must be one of %s", scope,
+			strings.Join(ScopeResources, ", "))
+	}
+	if _, ok := scopeActions[access]; !ok {
+		return fmt.Errorf("scope %q must grant %s or %s access", scope, AccessRead, AccessWrite)
+	}
+	return nil
+}
+
+// scopeAllows returns the scope granting the action on resource, if any
+func scopeAllows(scopes []string, resource string, action Action) (string, bool) {
+	for _, scope := range scopes {
+		r, access, _ := strings.Cut(scope, ":")
+		if r != resource {
+			continue
+		}
+		for _, a := range scopeActions[access] {
+			if a == action {
+				return scope, true
+			}
+		}
+	}
+	return "", false
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
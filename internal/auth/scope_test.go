@@ -0,0 +1,60 @@
+package auth
+
+import "testing"
+
+func TestValidateScope(t *testing.T) {
+	testCases := []struct {
+		scope string
+		valid bool
+	}{
+		{scope: "student:read", valid: true},
+		{scope: "staff:write", valid: true},
+		{scope: "student", valid: false},
+		{scope: "course:read", valid: true},
+		{scope: "unknown:read", valid: false},
+		{scope: "student:admin", valid: false},
+		{scope: "apikey:write", valid: false},
+	}
+
+	for _, test := range testCases {
+		err := ValidateScope(test.scope)
+		if (err == nil) != test.valid {
+			t.Errorf("Test %s : Expected valid %v, but got %v", test.scope, test.valid, err)
+		}
+	}
+}
+
+func TestPolicy_Evaluate_Scopes(t *testing.T) {
+	policy := DefaultPolicy()
+
+	testCases := []struct {
+		name     string
+		request  Request
+		expected string
+	}{
+		{name: "Read Scope Lists", expected: "student:read",
+			request: Request{Scopes: []string{"student:read"}, Resource: "student", Action: ActionList}},
+		{name: "Read Scope Searches", expected: "student:read",
+			request: Request{Scopes: []string{"lecturer:write", "student:read"}, Resource: "student",
+				Action: ActionSearch}},
+		{name: "Read Scope Deletes", expected: "",
+			request: Request{Scopes: []string{"student:read"}, Resource: "student", Action: ActionDelete}},
+		{name: "Write Scope Deletes", expected: "staff:write",
+			request: Request{Scopes: []string{"staff:write"}, Resource: "staff", Action: ActionDelete}},
+		{name: "Write Scope Reads", expected: "",
+			request: Request{Scopes: []string{"staff:write"}, Resource: "staff", Action: ActionRead}},
+		{name: "Write Scope Restores", expected: "",
+			request: Request{Scopes: []string{"student:write"}, Resource: "student", Action: ActionRestore}},
+		{name: "Other Resource", expected: "",
+			request: Request{Scopes: []string{"student:read"}, Resource: "lecturer", Action: ActionRead}},
+		{name: "API Key Management", expected: "",
+			request: Request{Scopes: []string{"student:write"}, Resource: "apikey", Action: ActionCreate}},
+	}
+
+	for _, test := range testCases {
+		decision := policy.Evaluate(test.request)
+		if decision.Allowed != (test.expected != "") || decision.Scope != test.expected {
+			t.Errorf("Test %s : Expected scope %q, but got %v", test.name, test.expected, decision)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// The roles the application itself relies on, the other roles are only
+// known to the policy
+const (
+	RoleAdmin    = "admin"
+	RoleLecturer = "lecturer"
+)
+
+// Claims are the verified claims of a token. Callers using an API key have
+// no roles, they are authorized by the Scopes of their key instead
+type Claims struct {
+	jwt.RegisteredClaims
+	Roles  []string `json:"roles,omitempty"`
+	Scopes []string `json:"-"`
+}
+
+// HasRole tells whether the token grants the role
+func (c *Claims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Verifier verifies bearer tokens against a key set
+type Verifier struct {
+	keys    *KeySet
+	options []jwt.ParserOption
+}
+
+// NewVerifier returns a verifier for tokens signed with one of keys. The
+// issuer and audience are checked when they are not empty
+func NewVerifier(keys *KeySet, issuer string, audience string) *Verifier {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{HS256, RS256}),
+		jwt.WithExpirationRequired(),
+	}
+	if issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		options = append(options, jwt.WithAudience(audience))
+	}
+	return &Verifier{
+		keys:    keys,
+		options: options,
+	}
+}
+
+// Verify checks the signature and the claims of the token and returns them
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	var claims Claims
+
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		// the key is picked by the algorithm of the token as well, so a
+		// public RSA key is never used as an HMAC secret
+		key, err := v.keys.find(t.Method.Alg(), kid)
+		if err != nil {
+			return nil, err
+		}
+		return key.Key, nil
+	}, v.options...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token : %w", err)
+	}
+	return &claims, nil
+}
+
+type claimsKey struct{}
+
+// WithClaims returns a copy of ctx holding the verified claims
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFrom returns the verified claims of the request, if any
+func ClaimsFrom(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok
+}
+
+// SubjectFrom returns the verified subject of the request, if any
+func SubjectFrom(ctx context.Context) string {
+	claims, ok := ClaimsFrom(ctx)
+	if !ok {
+		return ""
+	}
+	return claims.Subject
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var secret = []byte("a-secret-of-at-least-thirty-two-bytes")
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Error generating the RSA key %v", err)
+	}
+	return key
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims Claims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("Error signing the token %v", err)
+	}
+	return signed
+}
+
+func validClaims() Claims {
+	return Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-1",
+			Issuer:    "simpleAPI",
+			Audience:  jwt.ClaimStrings{"students"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Roles: []string{"admin"},
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	rsaKey := newRSAKey(t)
+	otherKey := newRSAKey(t)
+	keys := NewKeySet(
+		Key{ID: "hmac", Algorithm: HS256, Key: secret},
+		Key{ID: "rsa", Algorithm: RS256, Key: &rsaKey.PublicKey},
+	)
+	verifier := NewVerifier(keys, "simpleAPI", "students")
+
+	expired := validClaims()
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+	noExpiry := validClaims()
+	noExpiry.ExpiresAt = nil
+	otherIssuer := validClaims()
+	otherIssuer.Issuer = "someone"
+	otherAudience := validClaims()
+	otherAudience.Audience = jwt.ClaimStrings{"lecturers"}
+
+	testCases := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{name: "HS256", token: sign(t, jwt.SigningMethodHS256, "hmac", secret, validClaims()), valid: true},
+		{name: "RS256", token: sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, validClaims()), valid: true},
+		{name: "Without Kid", token: sign(t, jwt.SigningMethodRS256, "", rsaKey, validClaims()), valid: true},
+		{name: "Unknown Kid", token: sign(t, jwt.SigningMethodHS256, "other", secret, validClaims())},
+		{name: "Wrong Secret", token: sign(t, jwt.SigningMethodHS256, "hmac", []byte("wrong"), validClaims())},
+		{name: "Wrong RSA Key", token: sign(t, jwt.SigningMethodRS256, "rsa", otherKey, validClaims())},
+		{name: "Algorithm Mismatch", token: sign(t, jwt.SigningMethodHS256, "rsa", secret, validClaims())},
+		{name: "Unsupported Algorithm", token: sign(t, jwt.SigningMethodHS512, "hmac", secret, validClaims())},
+		{name: "None Algorithm", token: sign(t, jwt.SigningMethodNone, "hmac", jwt.UnsafeAllowNoneSignatureType,
+			validClaims())},
+		{name: "Expired", token: sign(t, jwt.SigningMethodHS256, "hmac", secret, expired)},
+		{name: "No Expiry", token: sign(t, jwt.SigningMethodHS256, "hmac", secret, noExpiry)},
+		{name: "Other Issuer", token: sign(t, jwt.SigningMethodHS256, "hmac", secret, otherIssuer)},
+		{name: "Other Audience", token: sign(t, jwt.SigningMethodHS256, "hmac", secret, otherAudience)},
+		{name: "Malformed", token: "not.a.token"},
+	}
+
+	for _, test := range testCases {
+		claims, err := verifier.Verify(test.token)
+		if test.valid && (err != nil || claims.Subject != "user-1" || len(claims.Roles) != 1) {
+			t.Errorf("Test %s : Expected the token to be valid, but got %v, %v", test.name, claims, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("Test %s : Expected the token to be rejected", test.name)
+		}
+	}
+}
+
+func TestClaimsContext(t *testing.T) {
+	if SubjectFrom(context.Background()) != "" {
+		t.Error("Expected no subject without claims")
+	}
+
+	claims := validClaims()
+	ctx := WithClaims(context.Background(), &claims)
+	if actual := SubjectFrom(ctx); actual != "user-1" {
+		t.Errorf("Expected subject %s, but got %s", "user-1", actual)
+	}
+}
@@ -1,22 +1,404 @@
 package config
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/joho/godotenv"
-	"github.com/tryfix/log"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/numbering"
+	"gopkg.in/yaml.v3"
 )
 
-func LoadConfigs() {
-	err := godotenv.Load(".env")
+// Config is the configuration of the service. It is built from, in
+// increasing order of precedence, the defaults, the optional config file,
+// the environment (including an optional .env file) and the command line flags
+type Config struct {
+	Server    ServerConfig    `yaml:"server"`
+	Database  DBConnection    `yaml:"database"`
+	Auth      AuthConfig      `yaml:"auth"`
+	Numbers   NumbersConfig   `yaml:"numbers"`
+	Retention RetentionConfig `yaml:"retention"`
+}
+
+// ServerConfig configures the HTTP server. LegacyRoutes serves the routes
+// of before /api/v1, eg. /student/getStudent/{id}, next to the versioned
+// ones, marked as deprecated since LegacyDeprecated and going away on
+// LegacySunset
+type ServerConfig struct {
+	Port             string        `yaml:"port"`
+	ReadTimeout      time.Duration `yaml:"readTimeout"`
+	WriteTimeout     time.Duration `yaml:"writeTimeout"`
+	ShutdownTimeout  time.Duration `yaml:"shutdownTimeout"`
+	LegacyRoutes     bool          `yaml:"legacyRoutes"`
+	LegacyDeprecated time.Time     `yaml:"legacyDeprecated"`
+	LegacySunset     time.Time     `yaml:"legacySunset"`
+}
+
+// The storage drivers, mysql and sqlite store the data in a SQL database and
+// memory keeps it in memory until the service stops
+const (
+	DriverMySQL  = "mysql"
+	DriverSQLite = "sqlite"
+	DriverMemory = "memory"
+)
+
+type DBConnection struct {
+	Driver      string        `yaml:"driver"`
+	Path        string        `yaml:"path"`
+	DBHost      string        `yaml:"host"`
+	DBPort      string        `yaml:"port"`
+	Username    string        `yaml:"username"`
+	Password    string        `yaml:"password"`
+	DBName      string        `yaml:"name"`
+	DBNetwork   string        `yaml:"network"`
+	Timeout     time.Duration `yaml:"timeout"`
+	AutoMigrate bool          `yaml:"autoMigrate"`
+}
+
+// AuthConfig configures the bearer token authentication. Keys is the path or
+// URL of the JWKS document holding the verification keys, the issuer and
+// audience are checked when set. AllowPaths are served without a token.
+// Policy is the path of the authorization policy file, the default policy
+// is used when it is empty
+type AuthConfig struct {
+	Enabled    bool     `yaml:"enabled"`
+	Keys       string   `yaml:"keys"`
+	Policy     string   `yaml:"policy"`
+	Issuer     string   `yaml:"issuer"`
+	Audience   string   `yaml:"audience"`
+	AllowPaths []string `yaml:"allowPaths"`
+}
+
+// NumbersConfig holds the patterns of the generated student registration
+// numbers and staff numbers, see the numbering package
+type NumbersConfig struct {
+	Student string `yaml:"student"`
+	Staff   string `yaml:"staff"`
+}
+
+// RetentionConfig configures the purge of the soft deleted students and
+// lecturers. The records deleted more than Deleted ago are removed for good
+// every PurgeInterval, a zero interval disables the purge
+type RetentionConfig struct {
+	Deleted       time.Duration `yaml:"deleted"`
+	PurgeInterval time.Duration `yaml:"purgeInterval"`
+}
+
+// Address returns the address the server listens on, eg. :8001
+func (s ServerConfig) Address() string {
+	return ":" + strings.TrimPrefix(s.Port, ":")
+}
+
+// Default returns the configuration used when nothing else is set
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Port:             "8001",
+			ReadTimeout:      30 * time.Second,
+			WriteTimeout:     30 * time.Second,
+			ShutdownTimeout:  5 * time.Second,
+			LegacyRoutes:     true,
+			LegacyDeprecated: time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC),
+			LegacySunset:     time.Date(2027, time.April, 18, 0, 0, 0, 0, time.UTC),
+		},
+		Database: DBConnection{
+			Driver:    DriverMySQL,
+			Path:      "simpleapi.db",
+			DBHost:    "localhost",
+			DBPort:    "3306",
+			DBName:    "simpleapidb",
+			DBNetwork: "tcp",
+			Timeout:   10 * time.Second,
+		},
+		Auth: AuthConfig{
+			Enabled:    true,
+			AllowPaths: []string{"/health", "/metrics"},
+		},
+		Numbers: NumbersConfig{
+			Student: "STU-{year}-{seq:6}",
+			Staff:   "STF-{year}-{seq:6}",
+		},
+		Retention: RetentionConfig{
+			Deleted:       30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+	}
+}
+
+// setting is a value that can be set from the environment and the command line
+type setting struct {
+	env   string
+	flag  string
+	usage string
+	set   func(cfg *Config, value string) error
+}
+
+var settings = []setting{
+	{env: "PORT", flag: "port", usage: "port the server listens on",
+		set: func(cfg *Config, v string) error { cfg.Server.Port = v; return nil }},
+	{env: "READ_TIMEOUT", flag: "read-timeout", usage: "server read timeout, eg. 30s",
+		set: func(cfg *Config, v string) error { return setDuration(&cfg.Server.ReadTimeout, v) }},
+	{env: "WRITE_TIMEOUT", flag: "write-timeout", usage: "server write timeout, eg. 30s",
+		set: func(cfg *Config, v string) error { return setDuration(&cfg.Server.WriteTimeout, v) }},
+	{env: "SHUTDOWN_TIMEOUT", flag: "shutdown-timeout", usage: "graceful shutdown period, eg. 5s",
+		set: func(cfg *Config, v string) error { return setDuration(&cfg.Server.ShutdownTimeout, v) }},
+	{env: "LEGACY_ROUTES", flag: "legacy-routes", usage: "serve the deprecated routes of before /api/v1, true or false",
+		set: func(cfg *Config, v string) error { return setBool(&cfg.Server.LegacyRoutes, v) }},
+	{env: "LEGACY_DEPRECATED", flag: "legacy-deprecated", usage: "date the routes of before /api/v1 were deprecated, eg. 2026-10-18",
+		set: func(cfg *Config, v string) error { return setDate(&cfg.Server.LegacyDeprecated, v) }},
+	{env: "LEGACY_SUNSET", flag: "legacy-sunset", usage: "date the deprecated routes go away, eg. 2027-04-18",
+		set: func(cfg *Config, v string) error { return setDate(&cfg.Server.LegacySunset, v) }},
+	{env: "DB_DRIVER", flag: "db-driver", usage: "storage driver, mysql, sqlite or memory",
+		set: func(cfg *Config, v string) error { cfg.Database.Driver = v; return nil }},
+	{env: "DB_PATH", flag: "db-path", usage: "database file of the sqlite driver",
+		set: func(cfg *Config, v string) error { cfg.Database.Path = v; return nil }},
+	{env: "DB_HOST", flag: "db-host", usage: "database host",
+		set: func(cfg *Config, v string) error { cfg.Database.DBHost = v; return nil }},
+	{env: "DB_PORT", flag: "db-port", usage: "database port",
+		set: func(cfg *Config, v string) error { cfg.Database.DBPort = v; return nil }},
+	{env: "DB_USERNAME", flag: "db-username", usage: "database user",
+		set: func(cfg *Config, v string) error { cfg.Database.Username = v; return nil }},
+	{env: "DB_PASSWORD", flag: "db-password", usage: "database password",
+		set: func(cfg *Config, v string) error { cfg.Database.Password = v; return nil }},
+	{env: "DB_NAME", flag: "db-name", usage: "database name",
+		set: func(cfg *Config, v string) error { cfg.Database.DBName = v; return nil }},
+	{env: "DB_NETWORK", flag: "db-network", usage: "database network, eg. tcp",
+		set: func(cfg *Config, v string) error { cfg.Database.DBNetwork = v; return nil }},
+	{env: "DB_TIMEOUT", flag: "db-timeout", usage: "per request database timeout, 0 disables it",
+		set: func(cfg *Config, v string) error { return setDuration(&cfg.Database.Timeout, v) }},
+	{env: "AUTO_MIGRATE", flag: "auto-migrate", usage: "apply pending schema migrations on start, true or false",
+		set: func(cfg *Config, v string) error { return setBool(&cfg.Database.AutoMigrate, v) }},
+	{env: "AUTH_ENABLED", flag: "auth-enabled", usage: "require a bearer token, true or false",
+		set: func(cfg *Config, v string) error { return setBool(&cfg.Auth.Enabled, v) }},
+	{env: "AUTH_KEYS", flag: "auth-keys", usage: "path or URL of the JWKS document the tokens are verified with",
+		set: func(cfg *Config, v string) error { cfg.Auth.Keys = v; return nil }},
+	{env: "AUTH_POLICY", flag: "auth-policy", usage: "path of the YAML authorization policy file",
+		set: func(cfg *Config, v string) error { cfg.Auth.Policy = v; return nil }},
+	{env: "AUTH_ISSUER", flag: "auth-issuer", usage: "required token issuer",
+		set: func(cfg *Config, v string) error { cfg.Auth.Issuer = v; return nil }},
+	{env: "AUTH_AUDIENCE", flag: "auth-audience", usage: "required token audience",
+		set: func(cfg *Config, v string) error { cfg.Auth.Audience = v; return nil }},
+	{env: "AUTH_ALLOW_PATHS", flag: "auth-allow-paths", usage: "comma separated paths served without a token",
+		set: func(cfg *Config, v string) error { cfg.Auth.AllowPaths = splitList(v); return nil }},
+	{env: "STUDENT_NUMBER_PATTERN", flag: "student-number-pattern",
+		usage: "pattern of the student registration numbers, eg. STU-{year}-{seq:6}",
+		set:   func(cfg *Config, v string) error { cfg.Numbers.Student = v; return nil }},
+	{env: "STAFF_NUMBER_PATTERN", flag: "staff-number-pattern",
+		usage: "pattern of the staff numbers, eg. STF-{year}-{seq:6}",
+		set:   func(cfg *Config, v string) error { cfg.Numbers.Staff = v; return nil }},
+	{env: "DELETED_RETENTION", flag: "deleted-retention",
+		usage: "how long the deleted students and lecturers are kept before the purge, eg. 720h",
+		set:   func(cfg *Config, v string) error { return setDuration(&cfg.Retention.Deleted, v) }},
+	{env: "PURGE_INTERVAL", flag: "purge-interval", usage: "how often the deleted records are purged, 0 disables it",
+		set: func(cfg *Config, v string) error { return setDuration(&cfg.Retention.PurgeInterval, v) }},
+}
+
+// Load builds the configuration from the defaults, the config file, the
+// environment and args, which are the command line flags without the
+// program name. All the problems found are returned together
+func Load(args []string) (*Config, error) {
+	return load(args, Config.Validate)
+}
+
+// LoadDatabase builds the configuration like Load but only validates the
+// database settings, for the commands that do not start the server
+func LoadDatabase(args []string) (*Config, error) {
+	return load(args, func(c Config) []error {
+		return c.Database.Validate()
+	})
+}
+
+func load(args []string, validate func(c Config) []error) (*Config, error) {
+	fs := flag.NewFlagSet("simpleAPI", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path of a YAML config file")
+	for _, s := range settings {
+		fs.String(s.flag, "", s.usage+" (env "+s.env+")")
+	}
+	err := fs.Parse(args)
 	if err != nil {
-		log.Fatal("Error loading configs", err)
+		return nil, err
+	}
+
+	// the .env file is optional, the variables may be set by other means
+	_ = godotenv.Load(".env")
+
+	cfg := Default()
+	var errs []error
+
+	if *configFile != "" {
+		err := loadFile(&cfg, *configFile)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, s := range settings {
+		value, ok := os.LookupEnv(s.env)
+		if !ok || value == "" {
+			continue
+		}
+		err := s.set(&cfg, value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s : %w", s.env, err))
+		}
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		for _, s := range settings {
+			if s.flag != f.Name {
+				continue
+			}
+			err := s.set(&cfg, f.Value.String())
+			if err != nil {
+				errs = append(errs, fmt.Errorf("-%s : %w", s.flag, err))
+			}
+		}
+	})
+
+	errs = append(errs, validate(cfg)...)
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
 	}
+	return &cfg, nil
 }
 
-type DBConnection struct {
-	DBHost    string
-	DBPort    string
-	Username  string
-	Password  string
-	DBName    string
-	DBNetwork string
+func loadFile(cfg *Config, path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config file : %w", err)
+	}
+
+	err = yaml.Unmarshal(b, cfg)
+	if err != nil {
+		return fmt.Errorf("config file %s : %w", path, err)
+	}
+	return nil
+}
+
+// Validate returns every problem found in the configuration
+func (c Config) Validate() []error {
+	var errs []error
+
+	if !validPort(c.Server.Port) {
+		errs = append(errs, fmt.Errorf("server port %q is not a valid port", c.Server.Port))
+	}
+	if c.Server.ReadTimeout <= 0 {
+		errs = append(errs, errors.New("server read timeout must be positive"))
+	}
+	if c.Server.WriteTimeout <= 0 {
+		errs = append(errs, errors.New("server write timeout must be positive"))
+	}
+	if c.Server.ShutdownTimeout < 0 {
+		errs = append(errs, errors.New("server shutdown timeout can not be negative"))
+	}
+
+	errs = append(errs, c.Database.Validate()...)
+
+	if c.Auth.Enabled && c.Auth.Keys == "" {
+		errs = append(errs, errors.New("auth keys are required when auth is enabled"))
+	}
+
+	_, err := numbering.Parse(c.Numbers.Student)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("student number pattern : %w", err))
+	}
+	_, err = numbering.Parse(c.Numbers.Staff)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("staff number pattern : %w", err))
+	}
+
+	if c.Retention.Deleted <= 0 {
+		errs = append(errs, errors.New("deleted retention must be positive"))
+	}
+	if c.Retention.PurgeInterval < 0 {
+		errs = append(errs, errors.New("purge interval can not be negative"))
+	}
+
+	return errs
+}
+
+// Validate returns every problem found in the database settings
+func (c DBConnection) Validate() []error {
+	var errs []error
+
+	switch c.Driver {
+	case DriverMySQL:
+		if c.DBHost == "" {
+			errs = append(errs, errors.New("database host is required"))
+		}
+		if !validPort(c.DBPort) {
+			errs = append(errs, fmt.Errorf("database port %q is not a valid port", c.DBPort))
+		}
+		if c.Username == "" {
+			errs = append(errs, errors.New("database username is required"))
+		}
+		if c.DBName == "" {
+			errs = append(errs, errors.New("database name is required"))
+		}
+		if c.DBNetwork == "" {
+			errs = append(errs, errors.New("database network is required"))
+		}
+	case DriverSQLite:
+		if c.Path == "" {
+			errs = append(errs, errors.New("database path is required by the sqlite driver"))
+		}
+	case DriverMemory:
+	default:
+		errs = append(errs, fmt.Errorf("database driver %q is not one of %s, %s or %s",
+			c.Driver, DriverMySQL, DriverSQLite, DriverMemory))
+	}
+	if c.Timeout < 0 {
+		errs = append(errs, errors.New("database timeout can not be negative"))
+	}
+
+	return errs
+}
+
+func setDuration(d *time.Duration, value string) error {
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// setDate sets a date, eg. 2027-04-18, which is midnight UTC
+func setDate(d *time.Time, value string) error {
+	parsed, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func setBool(b *bool, value string) error {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	*b = parsed
+	return nil
+}
+
+// splitList splits a comma separated list, dropping the empty items
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func validPort(port string) bool {
+	p, err := strconv.Atoi(strings.TrimPrefix(port, ":"))
+	return err == nil && p > 0 && p < 65536
 }
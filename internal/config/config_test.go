@@ -0,0 +1,218 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte(content), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeConfigFile(t, `
+server:
+  port: "9000"
+  readTimeout: 10s
+database:
+  host: filehost
+  username: fileuser
+  name: filedb
+  timeout: 3s
+auth:
+  keys: jwks.json
+`)
+
+	t.Setenv("DB_HOST", "envhost")
+	t.Setenv("DB_TIMEOUT", "4s")
+	t.Setenv("AUTO_MIGRATE", "true")
+	t.Setenv("AUTH_ALLOW_PATHS", "/health, ,/metrics,/docs")
+
+	cfg, err := Load([]string{"-config", path, "-db-timeout", "5s"})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		actual   interface{}
+		expected interface{}
+	}{
+		{name: "Default", actual: cfg.Server.WriteTimeout, expected: 30 * time.Second},
+		{name: "File Over Default", actual: cfg.Server.ReadTimeout, expected: 10 * time.Second},
+		{name: "File Port", actual: cfg.Server.Address(), expected: ":9000"},
+		{name: "File Only", actual: cfg.Database.Username, expected: "fileuser"},
+		{name: "Env Over File", actual: cfg.Database.DBHost, expected: "envhost"},
+		{name: "Flag Over Env", actual: cfg.Database.Timeout, expected: 5 * time.Second},
+		{name: "Env Bool", actual: cfg.Database.AutoMigrate, expected: true},
+		{name: "Env List", actual: strings.Join(cfg.Auth.AllowPaths, " "), expected: "/health /metrics /docs"},
+	}
+
+	for _, test := range testCases {
+		if test.actual != test.expected {
+			t.Errorf("Test %s : Expected %v, but got %v", test.name, test.expected, test.actual)
+		}
+	}
+}
+
+func TestLoad_AggregatesErrors(t *testing.T) {
+	t.Setenv("PORT", "eighty")
+	t.Setenv("DB_TIMEOUT", "soon")
+	t.Setenv("DB_USERNAME", "")
+
+	_, err := Load([]string{"-db-port", "0"})
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	for _, expected := range []string{"DB_TIMEOUT", `server port "eighty"`, `database port "0"`,
+		"database username is required", "auth keys are required"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected the error to mention %s, but got %v", expected, err)
+		}
+	}
+}
+
+func TestLoad_MissingConfigFile(t *testing.T) {
+	t.Setenv("DB_USERNAME", "root")
+
+	_, err := Load([]string{"-config", filepath.Join(t.TempDir(), "missing.yaml")})
+	if err == nil || !strings.Contains(err.Error(), "config file") {
+		t.Errorf("Expected a config file error, but got %v", err)
+	}
+}
+
+func TestLoad_Drivers(t *testing.T) {
+	t.Setenv("DB_USERNAME", "")
+	t.Setenv("AUTH_ENABLED", "false")
+
+	testCases := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{name: "Memory Needs No Database", args: []string{"-db-driver", "memory"}},
+		{name: "SQLite Needs No Server", args: []string{"-db-driver", "sqlite"}},
+		{name: "SQLite Needs A Path", args: []string{"-db-driver", "sqlite", "-db-path", ""},
+			expected: "database path is required"},
+		{name: "Unknown Driver", args: []string{"-db-driver", "postgres"},
+			expected: `database driver "postgres"`},
+	}
+
+	for _, test := range testCases {
+		_, err := Load(test.args)
+		if test.expected == "" && err != nil {
+			t.Errorf("Test %s : Unexpected error %v", test.name, err)
+		}
+		if test.expected != "" && (err == nil || !strings.Contains(err.Error(), test.expected)) {
+			t.Errorf("Test %s : Expected an error mentioning %s, but got %v", test.name, test.expected, err)
+		}
+	}
+}
+
+func TestLoad_NumberPatterns(t *testing.T) {
+	t.Setenv("AUTH_ENABLED", "false")
+	t.Setenv("STUDENT_NUMBER_PATTERN", "S{seq:8}")
+
+	cfg, err := Load([]string{"-db-driver", "memory"})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if cfg.Numbers.Student != "S{seq:8}" || cfg.Numbers.Staff != "STF-{year}-{seq:6}" {
+		t.Errorf("Expected the student pattern from the environment and the default staff pattern, "+
+			"but got %+v", cfg.Numbers)
+	}
+
+	_, err = Load([]string{"-db-driver", "memory", "-staff-number-pattern", "STF-{year}"})
+	if err == nil || !strings.Contains(err.Error(), "staff number pattern") {
+		t.Errorf("Expected a staff number pattern error, but got %v", err)
+	}
+}
+
+func TestLoad_Retention(t *testing.T) {
+	t.Setenv("AUTH_ENABLED", "false")
+	t.Setenv("PURGE_INTERVAL", "0")
+
+	cfg, err := Load([]string{"-db-driver", "memory", "-deleted-retention", "48h"})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if cfg.Retention.Deleted != 48*time.Hour || cfg.Retention.PurgeInterval != 0 {
+		t.Errorf("Expected the retention from the flag and the purge disabled, but got %+v", cfg.Retention)
+	}
+
+	_, err = Load([]string{"-db-driver", "memory", "-deleted-retention", "0s"})
+	if err == nil || !strings.Contains(err.Error(), "deleted retention must be positive") {
+		t.Errorf("Expected a retention error, but got %v", err)
+	}
+}
+
+func TestServerConfig_Address(t *testing.T) {
+	for _, port := range []string{"8080", ":8080"} {
+		if actual := (ServerConfig{Port: port}).Address(); actual != ":8080" {
+			t.Errorf("Expected %s, but got %s", ":8080", actual)
+		}
+	}
+}
+
+func TestLoadDatabase_IgnoresServerAndAuth(t *testing.T) {
+	t.Setenv("PORT", "eighty")
+	t.Setenv("AUTH_ENABLED", "true")
+	t.Setenv("AUTH_KEYS", "")
+
+	_, err := LoadDatabase([]string{"-db-driver", "sqlite"})
+	if err != nil {
+		t.Errorf("Expected only the database settings to be validated, but got %v", err)
+	}
+
+	_, err = LoadDatabase([]string{"-db-driver", "sqlite", "-db-path", ""})
+	if err == nil || !strings.Contains(err.Error(), "database path is required") {
+		t.Errorf("Expected a database path error, but got %v", err)
+	}
+}
+
+func TestLoad_LegacyRoutes(t *testing.T) {
+	t.Setenv("AUTH_ENABLED", "false")
+	path := writeConfigFile(t,
+		"server:\n  legacyRoutes: false\n  legacyDeprecated: 2026-12-31\n  legacySunset: 2027-06-30\n")
+
+	cfg, err := Load([]string{"-db-driver", "memory", "-config", path})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if cfg.Server.LegacyRoutes ||
+		!cfg.Server.LegacyDeprecated.Equal(time.Date(2026, time.December, 31, 0, 0, 0, 0, time.UTC)) ||
+		!cfg.Server.LegacySunset.Equal(time.Date(2027, time.June, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the legacy routes off, deprecated on 2026-12-31 until 2027-06-30, but got %+v", cfg.Server)
+	}
+
+	t.Setenv("LEGACY_DEPRECATED", "2026-11-01")
+	t.Setenv("LEGACY_SUNSET", "2027-07-01")
+	cfg, err = Load([]string{"-db-driver", "memory", "-legacy-routes", "true"})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !cfg.Server.LegacyRoutes ||
+		!cfg.Server.LegacyDeprecated.Equal(time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)) ||
+		!cfg.Server.LegacySunset.Equal(time.Date(2027, time.July, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the legacy routes on, deprecated on 2026-11-01 until 2027-07-01, but got %+v", cfg.Server)
+	}
+
+	_, err = Load([]string{"-db-driver", "memory", "-legacy-deprecated", "today"})
+	if err == nil || !strings.Contains(err.Error(), "-legacy-deprecated") {
+		t.Errorf("Expected a legacy deprecated error, but got %v", err)
+	}
+
+	_, err = Load([]string{"-db-driver", "memory", "-legacy-sunset", "next year"})
+	if err == nil || !strings.Contains(err.Error(), "-legacy-sunset") {
+		t.Errorf("Expected a legacy sunset error, but got %v", err)
+	}
+}
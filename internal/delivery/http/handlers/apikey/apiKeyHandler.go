@@ -0,0 +1,162 @@
+package apikey
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/crud"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/middleware"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/response"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	ak "github.com/shashaneRanasinghe/simpleAPI/internal/usecases/apikey"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+type APIKeyHandler struct {
+	apiKey ak.APIKeyUsecase
+}
+
+func NewAPIKeyHandler(apiKeyRepo repository.APIKeyRepository) *APIKeyHandler {
+	return newAPIKeyHandler(ak.NewAPIKey(apiKeyRepo))
+}
+
+func newAPIKeyHandler(apiKey ak.APIKeyUsecase) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKey: apiKey,
+	}
+}
+
+// APIKeyRoutes registers the API key management routes, policy decides who
+// may use them. The scopes of an API key never grant access to them
+func (handler *APIKeyHandler) APIKeyRoutes(r *mux.Router, policy *auth.Policy) {
+	authorize := middleware.Authorize(policy, "apikey")
+
+	r.Handle("/", authorize(auth.ActionList, handler.getAll)).Methods("GET")
+	r.Handle("/", authorize(auth.ActionCreate, handler.create)).Methods("POST")
+	r.Handle("/{id}/rotate", authorize(auth.ActionUpdate, handler.rotate)).Methods("POST")
+	r.Handle("/{id}", authorize(auth.ActionDelete, handler.revoke)).Methods("DELETE")
+}
+
+// V1Routes registers the API key routes of /api/v1/api-keys like
+// APIKeyRoutes, the collection is served without the trailing slash
+func (handler *APIKeyHandler) V1Routes(r *mux.Router, policy *auth.Policy) {
+	authorize := middleware.Authorize(policy, "apikey")
+
+	r.Handle("", authorize(auth.ActionList, handler.getAll)).Methods("GET")
+	r.Handle("", authorize(auth.ActionCreate, handler.create)).Methods("POST")
+	r.Handle("/{id}/rotate", authorize(auth.ActionUpdate, handler.rotate)).Methods("POST")
+	r.Handle("/{id}", authorize(auth.ActionDelete, handler.revoke)).Methods("DELETE")
+}
+
+func (handler *APIKeyHandler) getAll(w http.ResponseWriter, r *http.Request) {
+	var respModel models.APIKeyListResponse
+
+	list, err := handler.apiKey.GetAll(r.Context())
+	if err != nil {
+		log.Error(consts.GetAPIKeyError, err)
+
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.GetAPIKeyError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	respModel.Status = consts.Success
+	respModel.Data = list
+	respModel.Message = consts.GetAPIKeys
+	response.Write(w, http.StatusOK, respModel)
+}
+
+func (handler *APIKeyHandler) create(w http.ResponseWriter, r *http.Request) {
+	var respModel models.NewAPIKeyResponse
+	var reqBody models.APIKeyRequest
+
+	err := crud.ReadBody(r, &reqBody)
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.APIKeyCreateError)
+		respModel.Errors = apperrors.FieldsOf(err)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	created, err := handler.apiKey.Create(r.Context(), &reqBody)
+	if err != nil {
+		log.Error(consts.APIKeyCreateError, err)
+
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.APIKeyCreateError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	respModel.Status = consts.Success
+	respModel.Data = *created
+	respModel.Message = consts.APIKeyCreated
+	response.Write(w, http.StatusOK, respModel)
+}
+
+func (handler *APIKeyHandler) rotate(w http.ResponseWriter, r *http.Request) {
+	var respModel models.NewAPIKeyResponse
+
+	id, err := crud.PathID(r)
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.IDError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	rotated, err := handler.apiKey.Rotate(r.Context(), id)
+	if err != nil {
+		log.Error(consts.APIKeyCreateError, err)
+
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.APIKeyCreateError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	respModel.Status = consts.Success
+	respModel.Data = *rotated
+	respModel.Message = consts.APIKeyRotated
+	response.Write(w, http.StatusOK, respModel)
+}
+
+func (handler *APIKeyHandler) revoke(w http.ResponseWriter, r *http.Request) {
+	var respModel models.APIKeyResponse
+
+	id, err := crud.PathID(r)
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.IDError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	revoked, err := handler.apiKey.Revoke(r.Context(), id)
+	if err != nil {
+		log.Error(consts.GetAPIKeyError, err)
+
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.GetAPIKeyError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	respModel.Status = consts.Success
+	respModel.Data = *revoked
+	respModel.Message = consts.APIKeyRevoked
+	response.Write(w, http.StatusOK, respModel)
+}
@@ -0,0 +1,126 @@
+package apikey
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/mocks"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+)
+
+var (
+	createdAt = time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	revokedAt = createdAt.Add(time.Hour)
+
+	key1 = models.APIKey{
+		ID:        1,
+		Name:      "batch",
+		Prefix:    "0123456789abcdef",
+		Hash:      "secret-hash",
+		Scopes:    []string{"student:read"},
+		CreatedAt: createdAt,
+	}
+	newKey1 = models.NewAPIKey{
+		APIKey: key1,
+		Key:    "sak_0123456789abcdef.secret",
+	}
+	revokedKey1 = models.APIKey{
+		ID:        1,
+		Name:      "batch",
+		Prefix:    "0123456789abcdef",
+		Scopes:    []string{"student:read"},
+		CreatedAt: createdAt,
+		RevokedAt: &revokedAt,
+	}
+)
+
+func TestAPIKeyRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	apiKey := mocks.NewMockAPIKeyUsecase(ctrl)
+	apiKey.EXPECT().GetAll(gomock.Any()).Return([]models.APIKey{key1}, nil)
+	apiKey.EXPECT().Create(gomock.Any(), &models.APIKeyRequest{Name: "batch",
+		Scopes: []string{"student:read"}}).Return(&newKey1, nil)
+	apiKey.EXPECT().Rotate(gomock.Any(), 1).Return(&newKey1, nil)
+	apiKey.EXPECT().Rotate(gomock.Any(), 2).Return(nil, apperrors.Conflict(consts.APIKeyRevokedError, nil))
+	apiKey.EXPECT().Revoke(gomock.Any(), 1).Return(&revokedKey1, nil)
+
+	r := mux.NewRouter()
+	newAPIKeyHandler(apiKey).APIKeyRoutes(r, nil)
+
+	testCases := []struct {
+		name           string
+		url            string
+		method         string
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Get All API Keys",
+			url:            "/",
+			method:         "GET",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":[{"id":1,"name":"batch","prefix":"0123456789abcdef","scopes":["student:read"],"createdAt":"2023-05-01T10:00:00Z"}],"message":"API Keys Queried Successfully"}`,
+		},
+		{
+			name:           "Create API Key",
+			url:            "/",
+			method:         "POST",
+			requestBody:    `{"name":"batch","scopes":["student:read"]}`,
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":1,"name":"batch","prefix":"0123456789abcdef","scopes":["student:read"],"createdAt":"2023-05-01T10:00:00Z","key":"sak_0123456789abcdef.secret"},"message":"API Key Created Successfully, It Is Shown Only Once"}`,
+		},
+		{
+			name:           "Create API Key With Unknown Scope",
+			url:            "/",
+			method:         "POST",
+			requestBody:    `{"name":"batch","scopes":["student:admin"]}`,
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"id":0,"name":"","prefix":"","scopes":null,"createdAt":"0001-01-01T00:00:00Z","key":""},"message":"Invalid Request Body","code":"VALIDATION_ERROR","errors":[{"field":"scopes[0]","message":"scope \"student:admin\" must grant read or write access"}]}`,
+		},
+		{
+			name:           "Rotate API Key",
+			url:            "/1/rotate",
+			method:         "POST",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":1,"name":"batch","prefix":"0123456789abcdef","scopes":["student:read"],"createdAt":"2023-05-01T10:00:00Z","key":"sak_0123456789abcdef.secret"},"message":"API Key Rotated Successfully, It Is Shown Only Once"}`,
+		},
+		{
+			name:           "Rotate Revoked API Key",
+			url:            "/2/rotate",
+			method:         "POST",
+			expectedStatus: 409,
+			expectedBody:   `{"status":"Error","data":{"id":0,"name":"","prefix":"","scopes":null,"createdAt":"0001-01-01T00:00:00Z","key":""},"message":"The API Key Is Revoked","code":"CONFLICT"}`,
+		},
+		{
+			name:           "Revoke API Key",
+			url:            "/1",
+			method:         "DELETE",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":1,"name":"batch","prefix":"0123456789abcdef","scopes":["student:read"],"createdAt":"2023-05-01T10:00:00Z","revokedAt":"2023-05-01T11:00:00Z"},"message":"API Key Revoked Successfully"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest(test.method, test.url, strings.NewReader(test.requestBody))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
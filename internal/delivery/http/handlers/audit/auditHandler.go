@@ -0,0 +1,107 @@
+package audit
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/middleware"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/response"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	au "github.com/shashaneRanasinghe/simpleAPI/internal/usecases/audit"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+type AuditHandler struct {
+	audit au.AuditUsecase
+}
+
+func NewAuditHandler(auditRepo repository.AuditRepository) *AuditHandler {
+	return newAuditHandler(au.NewAudit(auditRepo))
+}
+
+func newAuditHandler(audit au.AuditUsecase) *AuditHandler {
+	return &AuditHandler{
+		audit: audit,
+	}
+}
+
+// AuditRoutes registers the audit log route, policy decides who may read it
+func (handler *AuditHandler) AuditRoutes(r *mux.Router, policy *auth.Policy) {
+	authorize := middleware.Authorize(policy, "audit")
+
+	r.Handle("/", authorize(auth.ActionList, handler.list)).Methods("GET")
+}
+
+// V1Routes registers the audit log route of /api/v1/audit like AuditRoutes,
+// without the trailing slash
+func (handler *AuditHandler) V1Routes(r *mux.Router, policy *auth.Policy) {
+	authorize := middleware.Authorize(policy, "audit")
+
+	r.Handle("", authorize(auth.ActionList, handler.list)).Methods("GET")
+}
+
+// list serves the events selected by the entity and id query parameters, a
+// page at a time, eg. /audit?entity=student&id=7&page=0&pageSize=20
+func (handler *AuditHandler) list(w http.ResponseWriter, r *http.Request) {
+	var respModel models.AuditResponse
+
+	query := r.URL.Query()
+	var values [3]int
+	for i, name := range []string{"id", "page", "pageSize"} {
+		value, err := queryInt(r, name)
+		if err != nil {
+			respModel.Status = consts.Error
+			respModel.Code = apperrors.CodeOf(err)
+			respModel.Message = apperrors.MessageOf(err, consts.AuditQueryError)
+			response.Write(w, response.Status(err), respModel)
+			return
+		}
+		values[i] = value
+	}
+
+	pagination := models.Pagination{Page: values[1], PageSize: values[2]}
+	if fields := pagination.Validate(); len(fields) != 0 {
+		err := apperrors.InvalidFields(consts.InvalidSearchQuery, fields)
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.AuditQueryError)
+		respModel.Errors = fields
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	events, err := handler.audit.List(r.Context(), query.Get("entity"), values[0], pagination)
+	if err != nil {
+		log.Error(consts.GetAuditError, err)
+
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.GetAuditError)
+		respModel.Errors = apperrors.FieldsOf(err)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	respModel.Status = consts.Success
+	respModel.Data = *events
+	respModel.Message = consts.GetAuditEvents
+	response.Write(w, http.StatusOK, respModel)
+}
+
+// queryInt returns the named numeric query parameter, 0 when it is missing
+func queryInt(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, apperrors.Validation(consts.AuditQueryError, err)
+	}
+	return n, nil
+}
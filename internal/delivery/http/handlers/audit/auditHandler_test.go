@@ -0,0 +1,88 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/mocks"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+)
+
+var event1 = models.AuditEvent{
+	ID:        3,
+	Actor:     "admin-1",
+	Entity:    "student",
+	EntityID:  7,
+	Action:    models.AuditUpdate,
+	Timestamp: time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC),
+	Changes: map[string]models.AuditChange{
+		"year": {Before: json.RawMessage("2"), After: json.RawMessage("3")},
+	},
+}
+
+func TestAuditRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	audit := mocks.NewMockAuditUsecase(ctrl)
+	audit.EXPECT().List(gomock.Any(), "student", 7, models.Pagination{Page: 0, PageSize: 10}).
+		Return(&models.SearchData[models.AuditEvent]{TotalElements: 1, Data: []models.AuditEvent{event1}}, nil)
+	audit.EXPECT().List(gomock.Any(), "", 7, models.Pagination{}).
+		Return(nil, apperrors.Validation(consts.AuditEntityError, nil))
+
+	r := mux.NewRouter()
+	newAuditHandler(audit).AuditRoutes(r, nil)
+
+	testCases := []struct {
+		name           string
+		url            string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Events Of A Student",
+			url:            "/?entity=student&id=7&pageSize=10",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"totalElements":1,"data":[{"id":3,"actor":"admin-1","entity":"student","entityId":7,"action":"update","timestamp":"2023-05-01T10:00:00Z","changes":{"year":{"before":2,"after":3}}}]},"message":"Audit Events Queried Successfully"}`,
+		},
+		{
+			name:           "Id Without Entity",
+			url:            "/?id=7",
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"totalElements":0,"data":null},"message":"An id Must Be Given With Its entity","code":"VALIDATION_ERROR"}`,
+		},
+		{
+			name:           "Invalid Page",
+			url:            "/?entity=student&page=first",
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"totalElements":0,"data":null},"message":"id, page And pageSize Must Be Numbers","code":"VALIDATION_ERROR"}`,
+		},
+		{
+			name:           "Page Size Above The Maximum",
+			url:            "/?entity=student&pageSize=101",
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"totalElements":0,"data":null},"message":"Invalid Search Query","code":"VALIDATION_ERROR","errors":[{"field":"pagination.pageSize","message":"must be between 0 and 100"}]}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest("GET", test.url, nil)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
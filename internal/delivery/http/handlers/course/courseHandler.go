@@ -0,0 +1,35 @@
+package course
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/crud"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/middleware"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	cou "github.com/shashaneRanasinghe/simpleAPI/internal/usecases/course"
+)
+
+type CourseHandler struct {
+	course *crud.Handler[models.Course]
+}
+
+func NewCourseHandler(courseRepo repository.CourseRepository, lecturerRepo repository.LecturerRepository) *CourseHandler {
+	return newCourseHandler(cou.NewCourse(courseRepo, lecturerRepo))
+}
+
+func newCourseHandler(course cou.CourseUsecase) *CourseHandler {
+	return &CourseHandler{
+		course: crud.NewHandler[models.Course](course, models.CourseResource),
+	}
+}
+
+// CourseRoutes registers the course routes, policy decides who may use them
+func (handler *CourseHandler) CourseRoutes(r *mux.Router, policy *auth.Policy) {
+	handler.course.Routes(r, middleware.Authorize(policy, "course"))
+}
+
+// V1Routes registers the course routes of /api/v1/courses like CourseRoutes
+func (handler *CourseHandler) V1Routes(r *mux.Router, policy *auth.Policy) {
+	handler.course.V1Routes(r, middleware.Authorize(policy, "course"))
+}
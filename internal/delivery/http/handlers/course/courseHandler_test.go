@@ -0,0 +1,133 @@
+package course
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/mocks"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+)
+
+var (
+	course0 = models.Course{
+		ID:         0,
+		Code:       "AE101",
+		Title:      "Aerodynamics",
+		Credits:    3,
+		Semester:   "2023-S1",
+		LecturerID: 1,
+	}
+	course1 = models.Course{
+		ID:         1,
+		Code:       "AE101",
+		Title:      "Aerodynamics",
+		Credits:    3,
+		Semester:   "2023-S1",
+		LecturerID: 1,
+	}
+	course2 = models.Course{
+		ID:         2,
+		Code:       "CH201",
+		Title:      "Chassis Design",
+		Credits:    4,
+		Semester:   "2023-S2",
+		LecturerID: 2,
+	}
+	courseList = []models.Course{course1, course2}
+)
+
+func TestCourseRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	course := mocks.NewMockCourseUsecase(ctrl)
+	course.EXPECT().GetAll(gomock.Any(), nil).Return(courseList, nil)
+	course.EXPECT().Get(gomock.Any(), 1).Return(&course1, nil)
+	course.EXPECT().Create(gomock.Any(), &course0).Return(&course1, nil)
+	missingLecturer := course0
+	missingLecturer.LecturerID = 9
+	course.EXPECT().Create(gomock.Any(), &missingLecturer).
+		Return(&models.Course{}, apperrors.Conflict(consts.ForeignKeyError, nil))
+	course.EXPECT().Search(gomock.Any(), "aero", nil, models.Pagination{Page: 0, PageSize: 2},
+		models.SortBy{Column: "code", Direction: "ASC"}).
+		Return(&models.CourseSearchData{TotalElements: 1, Data: []models.Course{course1}}, nil)
+
+	r := mux.NewRouter()
+	newCourseHandler(course).CourseRoutes(r, nil)
+
+	testCases := []struct {
+		name           string
+		url            string
+		method         string
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Get All Courses",
+			url:            "/",
+			method:         "GET",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":[{"id":1,"code":"AE101","title":"Aerodynamics","credits":3,"semester":"2023-S1","lecturerId":1,"capacity":0,"minYear":0},{"id":2,"code":"CH201","title":"Chassis Design","credits":4,"semester":"2023-S2","lecturerId":2,"capacity":0,"minYear":0}],"message":"Course Queried Successfully"}`,
+		},
+		{
+			name:           "Get Specific Course",
+			url:            "/getCourse/1",
+			method:         "GET",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":1,"code":"AE101","title":"Aerodynamics","credits":3,"semester":"2023-S1","lecturerId":1,"capacity":0,"minYear":0},"message":"Course Queried Successfully"}`,
+		},
+		{
+			name:           "Create Course",
+			url:            "/",
+			method:         "POST",
+			requestBody:    `{"code":"AE101","title":"Aerodynamics","credits":3,"semester":"2023-S1","lecturerId":1,"capacity":0,"minYear":0}`,
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":1,"code":"AE101","title":"Aerodynamics","credits":3,"semester":"2023-S1","lecturerId":1,"capacity":0,"minYear":0},"message":"Course Created Successfully"}`,
+		},
+		{
+			name:           "Create Course Without Lecturer",
+			url:            "/",
+			method:         "POST",
+			requestBody:    `{"code":"AE101","title":"Aerodynamics","credits":3,"semester":"2023-S1"}`,
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"id":0,"code":"","title":"","credits":0,"semester":"","lecturerId":0,"capacity":0,"minYear":0},"message":"Invalid Request Body","code":"VALIDATION_ERROR","errors":[{"field":"lecturerId","message":"must be at least 1"}]}`,
+		},
+		{
+			name:           "Create Course With Missing Lecturer",
+			url:            "/",
+			method:         "POST",
+			requestBody:    `{"code":"AE101","title":"Aerodynamics","credits":3,"semester":"2023-S1","lecturerId":9,"capacity":0,"minYear":0}`,
+			expectedStatus: 409,
+			expectedBody:   `{"status":"Error","data":{"id":0,"code":"","title":"","credits":0,"semester":"","lecturerId":0,"capacity":0,"minYear":0},"message":"The Record Is Referenced By Or References A Missing Record","code":"CONFLICT"}`,
+		},
+		{
+			name:           "Search Courses",
+			url:            "/search",
+			method:         "GET",
+			requestBody:    `{"searchString":"aero","sortBy": {"column":"code","direction":"ASC"},"pagination": {"page":0,"pageSize":2}}`,
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"totalElements":1,"data":[{"id":1,"code":"AE101","title":"Aerodynamics","credits":3,"semester":"2023-S1","lecturerId":1,"capacity":0,"minYear":0}]},"message":"Course Queried Successfully"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest(test.method, test.url, strings.NewReader(test.requestBody))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,571 @@
+package crud
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/middleware"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/response"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/usecases/crud"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/patch"
+	"github.com/tryfix/log"
+)
+
+// Handler serves the CRUD routes of an entity. The handler of a soft
+// deleted entity has a restorer, which serves the restore route, and that of
+// an entity whose usecase is a crud.Patcher a patcher, which serves the
+// patch route
+type Handler[T any] struct {
+	usecase  crud.Usecase[T]
+	restorer crud.SoftDeleteUsecase[T]
+	patcher  crud.Patcher[T]
+	resource models.Resource
+}
+
+func NewHandler[T any](usecase crud.Usecase[T], resource models.Resource) *Handler[T] {
+	patcher, _ := usecase.(crud.Patcher[T])
+	return &Handler[T]{
+		usecase:  usecase,
+		patcher:  patcher,
+		resource: resource,
+	}
+}
+
+// NewSoftDeleteHandler is like NewHandler for an entity that is soft
+// deleted, the deleted records can be read with includeDeleted=true and
+// restored
+func NewSoftDeleteHandler[T any](usecase crud.SoftDeleteUsecase[T], resource models.Resource) *Handler[T] {
+	handler := NewHandler[T](usecase, resource)
+	handler.restorer = usecase
+	return handler
+}
+
+// Routes registers the CRUD routes on the given router, each route is
+// authorized for the action it performs. The records of a models.Versioned
+// entity are served with their ETag, the update and delete routes honour
+// If-Match and the read of a single record If-None-Match. The single entity route is named
+// after the resource, eg. /getStudent/{id}. The routes of a soft deleted
+// entity also restore the records, eg. /{id}/restore, and those of a patched
+// entity patch them, eg. PATCH /{id}, which is authorized as an update
+func (handler *Handler[T]) Routes(r *mux.Router, authorize middleware.Authorizer) {
+	r.Handle("/", authorize(auth.ActionList, handler.withDeleted(authorize, handler.getAll))).Methods("GET")
+	r.Handle("/get"+handler.resource.Name+"/{id}",
+		authorize(auth.ActionRead, handler.withDeleted(authorize, handler.get))).Methods("GET")
+	r.Handle("/", authorize(auth.ActionCreate, handler.create)).Methods("POST")
+	r.Handle("/", authorize(auth.ActionUpdate, handler.update)).Methods("PUT")
+	r.Handle("/{id}", authorize(auth.ActionDelete, handler.delete)).Methods("DELETE")
+	r.Handle("/search", authorize(auth.ActionSearch, handler.withDeleted(authorize, handler.search))).Methods("GET")
+	if handler.restorer != nil {
+		r.Handle("/{id}/restore", authorize(auth.ActionRestore, handler.restore)).Methods("POST")
+	}
+	if handler.patcher != nil {
+		r.Handle("/{id}", authorize(auth.ActionUpdate, handler.patch)).Methods("PATCH")
+	}
+}
+
+// V1Routes registers the routes of the entity under /api/v1, eg.
+// /api/v1/students, where the records are addressed by their path,
+// GET, PUT, PATCH and DELETE /{id}, and searched with the query parameters
+// of GET /search. The routes are authorized, versioned and soft deleted like
+// those of Routes
+func (handler *Handler[T]) V1Routes(r *mux.Router, authorize middleware.Authorizer) {
+	r.Handle("", authorize(auth.ActionList, handler.withDeleted(authorize, handler.getAll))).Methods("GET")
+	r.Handle("", authorize(auth.ActionCreate, handler.create)).Methods("POST")
+	// registered before /{id}, which would take search for an id
+	r.Handle("/search", authorize(auth.ActionSearch, handler.withDeleted(authorize, handler.searchQuery))).
+		Methods("GET")
+	r.Handle("/{id}", authorize(auth.ActionRead, handler.withDeleted(authorize, handler.get))).Methods("GET")
+	r.Handle("/{id}", authorize(auth.ActionUpdate, handler.replace)).Methods("PUT")
+	r.Handle("/{id}", authorize(auth.ActionDelete, handler.delete)).Methods("DELETE")
+	if handler.restorer != nil {
+		r.Handle("/{id}/restore", authorize(auth.ActionRestore, handler.restore)).Methods("POST")
+	}
+	if handler.patcher != nil {
+		r.Handle("/{id}", authorize(auth.ActionUpdate, handler.patch)).Methods("PATCH")
+	}
+}
+
+// withDeleted serves the read next with the soft deleted records included
+// when the request asks for them with includeDeleted=true, which is
+// authorized as a restore on top of the action of the route
+func (handler *Handler[T]) withDeleted(authorize middleware.Authorizer, next http.HandlerFunc) http.HandlerFunc {
+	if handler.restorer == nil {
+		return next
+	}
+
+	included := authorize(auth.ActionRestore, func(w http.ResponseWriter, r *http.Request) {
+		next(w, r.WithContext(repository.WithDeleted(r.Context())))
+	})
+	return func(w http.ResponseWriter, r *http.Request) {
+		include, err := includeDeleted(r)
+		if err != nil {
+			var respModel models.Response[interface{}]
+			respModel.Status = consts.Error
+			respModel.Code = apperrors.CodeOf(err)
+			respModel.Message = apperrors.MessageOf(err, consts.IncludeDeletedError)
+			response.Write(w, response.Status(err), respModel)
+			return
+		}
+
+		if !include {
+			next(w, r)
+			return
+		}
+		included.ServeHTTP(w, r)
+	}
+}
+
+// includeDeleted returns the includeDeleted query parameter, false when it
+// is missing
+func includeDeleted(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get("includeDeleted")
+	if value == "" {
+		return false, nil
+	}
+	include, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, apperrors.Validation(consts.IncludeDeletedError, err)
+	}
+	return include, nil
+}
+
+func (handler *Handler[T]) getAll(w http.ResponseWriter, r *http.Request) {
+	var respModel models.ListResponse[T]
+
+	expr, err := FilterQuery(r)
+	var list []T
+	if err == nil {
+		list, err = handler.usecase.GetAll(r.Context(), expr)
+	}
+	if err != nil {
+		log.Error(handler.resource.GetError, err)
+
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, handler.resource.GetError)
+		respModel.Errors = apperrors.FieldsOf(err)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	respModel.Status = consts.Success
+	respModel.Data = list
+	respModel.Message = handler.resource.Queried
+	response.Write(w, http.StatusOK, respModel)
+}
+
+func (handler *Handler[T]) get(w http.ResponseWriter, r *http.Request) {
+	var respModel models.Response[T]
+
+	id, err := PathID(r)
+	if err != nil {
+		handler.writeError(w, err, handler.resource.GetError)
+		return
+	}
+
+	entity, err := handler.usecase.Get(r.Context(), id)
+	if err != nil {
+		log.Error(handler.resource.GetError, err)
+		handler.writeError(w, err, handler.resource.GetError)
+		return
+	}
+
+	SetETag(w, *entity)
+	if NotModified(r, *entity) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	respModel.Status = consts.Success
+	respModel.Data = *entity
+	respModel.Message = handler.resource.Queried
+	response.Write(w, http.StatusOK, respModel)
+}
+
+func (handler *Handler[T]) create(w http.ResponseWriter, r *http.Request) {
+	var respModel models.Response[T]
+	var newEntity T
+
+	err := ReadBody(r, &newEntity)
+	if err != nil {
+		handler.writeError(w, err, handler.resource.CreateError)
+		return
+	}
+
+	created, err := handler.usecase.Create(r.Context(), &newEntity)
+	if err != nil {
+		log.Error(handler.resource.CreateError, err)
+		handler.writeError(w, err, handler.resource.CreateError)
+		return
+	}
+
+	SetETag(w, *created)
+	respModel.Status = consts.Success
+	respModel.Data = *created
+	respModel.Message = handler.resource.Created
+	response.Write(w, http.StatusOK, respModel)
+}
+
+func (handler *Handler[T]) update(w http.ResponseWriter, r *http.Request) {
+	var updatedEntity T
+
+	err := ReadBody(r, &updatedEntity)
+	if err != nil {
+		handler.writeError(w, err, handler.resource.UpdateError)
+		return
+	}
+	handler.save(w, r, &updatedEntity)
+}
+
+// replace updates the record with the id of the path, the id of the body
+// can be left out and must be the same when it is not
+func (handler *Handler[T]) replace(w http.ResponseWriter, r *http.Request) {
+	var updatedEntity T
+
+	id, err := PathID(r)
+	if err != nil {
+		handler.writeError(w, err, handler.resource.UpdateError)
+		return
+	}
+
+	err = readBodyWithID(r, id, &updatedEntity)
+	if err != nil {
+		handler.writeError(w, err, handler.resource.UpdateError)
+		return
+	}
+	handler.save(w, r, &updatedEntity)
+}
+
+// save updates the record and responds with the updated record
+func (handler *Handler[T]) save(w http.ResponseWriter, r *http.Request, updatedEntity *T) {
+	var respModel models.Response[T]
+
+	updated, err := handler.usecase.Update(IfMatch(r), updatedEntity)
+	if err != nil {
+		log.Error(handler.resource.UpdateError, err)
+		err = IfMatchFailed(r, err)
+		handler.writeError(w, err, handler.resource.UpdateError)
+		return
+	}
+
+	SetETag(w, *updated)
+	respModel.Status = consts.Success
+	respModel.Data = *updated
+	respModel.Message = handler.resource.Updated
+	response.Write(w, http.StatusOK, respModel)
+}
+
+// writeError responds with the error of a request for a single record,
+// message is used when the error has no message of its own
+func (handler *Handler[T]) writeError(w http.ResponseWriter, err error, message string) {
+	var respModel models.Response[T]
+	respModel.Status = consts.Error
+	respModel.Code = apperrors.CodeOf(err)
+	respModel.Message = apperrors.MessageOf(err, message)
+	respModel.Errors = apperrors.FieldsOf(err)
+	response.Write(w, response.Status(err), respModel)
+}
+
+func (handler *Handler[T]) delete(w http.ResponseWriter, r *http.Request) {
+	var respModel models.Response[T]
+
+	id, err := PathID(r)
+	if err != nil {
+		handler.writeError(w, err, consts.IDError)
+		return
+	}
+
+	deleted, err := handler.usecase.Delete(IfMatch(r), id)
+	if err != nil {
+		log.Error(handler.resource.DeleteError, err)
+		err = IfMatchFailed(r, err)
+		handler.writeError(w, err, handler.resource.DeleteError)
+		return
+	}
+
+	respModel.Status = consts.Success
+	respModel.Data = *deleted
+	respModel.Message = handler.resource.Deleted
+	response.Write(w, http.StatusOK, respModel)
+}
+
+func (handler *Handler[T]) restore(w http.ResponseWriter, r *http.Request) {
+	var respModel models.Response[T]
+
+	id, err := PathID(r)
+	if err != nil {
+		handler.writeError(w, err, consts.IDError)
+		return
+	}
+
+	restored, err := handler.restorer.Restore(r.Context(), id)
+	if err != nil {
+		log.Error(handler.resource.RestoreError, err)
+		handler.writeError(w, err, handler.resource.RestoreError)
+		return
+	}
+
+	SetETag(w, *restored)
+	respModel.Status = consts.Success
+	respModel.Data = *restored
+	respModel.Message = handler.resource.Restored
+	response.Write(w, http.StatusOK, respModel)
+}
+
+// patch applies the merge patch or the JSON patch of the request body, told
+// apart by the Content-Type, to the record and returns the patched record.
+// The patched record is validated like the body of an update
+func (handler *Handler[T]) patch(w http.ResponseWriter, r *http.Request) {
+	var respModel models.Response[T]
+
+	id, err := PathID(r)
+	if err != nil {
+		handler.writeError(w, err, consts.IDError)
+		return
+	}
+
+	apply, err := readPatch(r)
+	if err != nil {
+		if apperrors.CodeOf(err) == apperrors.CodeMediaType {
+			w.Header().Set(consts.AcceptPatch, patch.Accepted)
+		}
+		handler.writeError(w, err, handler.resource.PatchError)
+		return
+	}
+
+	patched, err := handler.patcher.Patch(IfMatch(r), id, func(entity *T) error {
+		doc, err := json.Marshal(entity)
+		if err != nil {
+			log.Error(consts.JSONMarshalError, err)
+			return apperrors.Internal(err)
+		}
+		doc, err = apply(doc)
+		if err != nil {
+			return err
+		}
+
+		var result T
+		err = decode(doc, &result)
+		if err != nil {
+			return err
+		}
+		*entity = result
+		return nil
+	})
+	if err != nil {
+		log.Error(handler.resource.PatchError, err)
+		err = IfMatchFailed(r, err)
+		handler.writeError(w, err, handler.resource.PatchError)
+		return
+	}
+
+	SetETag(w, *patched)
+	respModel.Status = consts.Success
+	respModel.Data = *patched
+	respModel.Message = handler.resource.Updated
+	response.Write(w, http.StatusOK, respModel)
+}
+
+func (handler *Handler[T]) search(w http.ResponseWriter, r *http.Request) {
+	var reqBody models.SearchRequest
+
+	err := ReadBody(r, &reqBody)
+	if err == nil {
+		reqBody.Filter, err = FilterQuery(r)
+	}
+	if err != nil {
+		handler.writeSearchError(w, err)
+		return
+	}
+	handler.find(w, r, reqBody)
+}
+
+// searchQuery searches like search with the query parameters of the
+// request, see SearchQuery
+func (handler *Handler[T]) searchQuery(w http.ResponseWriter, r *http.Request) {
+	req, err := SearchQuery(r)
+	if err != nil {
+		handler.writeSearchError(w, err)
+		return
+	}
+	handler.find(w, r, req)
+}
+
+// find responds with the records matching the search request
+func (handler *Handler[T]) find(w http.ResponseWriter, r *http.Request, req models.SearchRequest) {
+	var respModel models.SearchResponse[T]
+
+	list, err := handler.usecase.Search(r.Context(), req.SearchString, req.Filter, req.Pagination, req.SortBy)
+	if err != nil {
+		log.Error(handler.resource.GetError, err)
+		handler.writeSearchError(w, err)
+		return
+	}
+
+	respModel.Status = consts.Success
+	respModel.Data = *list
+	respModel.Message = handler.resource.Queried
+	response.Write(w, http.StatusOK, respModel)
+}
+
+func (handler *Handler[T]) writeSearchError(w http.ResponseWriter, err error) {
+	var respModel models.SearchResponse[T]
+	respModel.Status = consts.Error
+	respModel.Code = apperrors.CodeOf(err)
+	respModel.Message = apperrors.MessageOf(err, handler.resource.GetError)
+	respModel.Errors = apperrors.FieldsOf(err)
+	response.Write(w, response.Status(err), respModel)
+}
+
+// PathID returns the id path variable, a non numeric id is a validation error
+func PathID(r *http.Request) (int, error) {
+	return PathInt(r, "id")
+}
+
+// PathInt returns the named numeric path variable, like PathID
+func PathInt(r *http.Request, name string) (int, error) {
+	id, err := strconv.Atoi(mux.Vars(r)[name])
+	if err != nil {
+		log.Error(consts.IDError, err)
+		return 0, apperrors.Validation(consts.IDError, err)
+	}
+	return id, nil
+}
+
+// MaxBodySize is the size in bytes of the largest request body the handlers
+// read
+const MaxBodySize = 1 << 20
+
+// ReadBody reads the JSON request body into v, an empty body leaves v unchanged.
+// Unknown fields are rejected, v is normalized when it is a models.Normalizer
+// and validated when it is a models.Validator. A body larger than MaxBodySize
+// is rejected
+func ReadBody(r *http.Request, v interface{}) error {
+	body, err := readAll(r)
+	if err != nil {
+		return err
+	}
+
+	if len(body) == 0 {
+		return check(v)
+	}
+	return decode(body, v)
+}
+
+// readAll reads the request body and closes it, a body larger than
+// MaxBodySize is rejected
+func readAll(r *http.Request) ([]byte, error) {
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			log.Error(consts.RequestBodyCloseError, err)
+		}
+	}(r.Body)
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxBodySize+1))
+	if err != nil {
+		log.Error(consts.RequestBodyReadError, err)
+		return nil, apperrors.Internal(err)
+	}
+	if len(body) > MaxBodySize {
+		return nil, apperrors.TooLarge(consts.RequestBodyTooLarge)
+	}
+	return body, nil
+}
+
+// readBodyWithID reads the JSON request body into v like ReadBody, with the
+// id of the path. The id of the body may be left out but must be id when
+// it is given
+func readBodyWithID(r *http.Request, id int, v interface{}) error {
+	body, err := readAll(r)
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if len(body) != 0 {
+		err = json.Unmarshal(body, &fields)
+		if err != nil {
+			log.Error(consts.JSONMarshalError, err)
+			return apperrors.Validation(consts.InvalidRequestBody, err)
+		}
+	}
+
+	if given, ok := fields["id"]; ok {
+		var bodyID int
+		if json.Unmarshal(given, &bodyID) != nil || bodyID != id {
+			return apperrors.InvalidFields(consts.InvalidRequestBody, []apperrors.FieldError{
+				{Field: "id", Message: "must be the id of the path"},
+			})
+		}
+	}
+	fields["id"] = json.RawMessage(strconv.Itoa(id))
+
+	body, err = json.Marshal(fields)
+	if err != nil {
+		log.Error(consts.JSONMarshalError, err)
+		return apperrors.Internal(err)
+	}
+	return decode(body, v)
+}
+
+// decode reads the JSON body into v, which is then checked like by ReadBody
+func decode(body []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(v)
+	if err != nil {
+		log.Error(consts.JSONMarshalError, err)
+		return decodeError(err)
+	}
+	return check(v)
+}
+
+// check normalizes v when it is a models.Normalizer and validates it when it
+// is a models.Validator
+func check(v interface{}) error {
+	if normalizer, ok := v.(models.Normalizer); ok {
+		normalizer.Normalize()
+	}
+
+	validator, ok := v.(models.Validator)
+	if !ok {
+		return nil
+	}
+	fields := validator.Validate()
+	if len(fields) != 0 {
+		return apperrors.InvalidFields(consts.InvalidRequestBody, fields)
+	}
+	return nil
+}
+
+// decodeError converts a JSON decoding error into a validation error,
+// naming the offending field when the decoder reports it
+func decodeError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return apperrors.InvalidFields(consts.InvalidRequestBody, []apperrors.FieldError{
+			{Field: typeErr.Field, Message: "must be of type " + typeErr.Type.String()},
+		})
+	}
+
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return apperrors.InvalidFields(consts.InvalidRequestBody, []apperrors.FieldError{
+			{Field: strings.Trim(field, `"`), Message: "is not allowed"},
+		})
+	}
+
+	return apperrors.Validation(consts.InvalidRequestBody, err)
+}
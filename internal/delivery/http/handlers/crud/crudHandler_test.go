@@ -0,0 +1,826 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/middleware"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	"github.com/shashaneRanasinghe/simpleAPI/mocks"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/filter"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/numbering"
+)
+
+var (
+	student1 = models.Student{
+		ID:        1,
+		FirstName: "Charles",
+		LastName:  "Leclerc",
+		Year:      3,
+		Status:    "active",
+	}
+	student7 = models.Student{
+		ID:        7,
+		FirstName: "Carlos",
+		LastName:  "Sainz",
+		Year:      1,
+		Status:    "active",
+	}
+	errStudent = &models.Student{}
+)
+
+func TestHandler_ErrorStatusCodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockUsecase[models.Student](ctrl)
+	usecase.EXPECT().Get(gomock.Any(), 7).Return(errStudent, apperrors.NotFound(consts.StudentNotFound))
+	usecase.EXPECT().Delete(gomock.Any(), 7).Return(errStudent, apperrors.NotFound(consts.StudentNotFound))
+	usecase.EXPECT().Create(gomock.Any(), &student1).Return(errStudent,
+		apperrors.Conflict(consts.DuplicateEntryError, errors.New("Error 1062")))
+	usecase.EXPECT().Update(gomock.Any(), &student1).Return(errStudent, apperrors.Internal(errors.New("connection refused")))
+	usecase.EXPECT().Update(gomock.Any(), &student7).Return(errStudent, apperrors.NotFound(consts.StudentNotFound))
+
+	r := mux.NewRouter()
+	NewHandler[models.Student](usecase, models.StudentResource).Routes(r, middleware.Authorize(nil, "student"))
+
+	testCases := []struct {
+		name           string
+		url            string
+		method         string
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Non Numeric ID",
+			url:            "/getStudent/abc",
+			method:         "GET",
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"Error Getting The ID","code":"VALIDATION_ERROR"}`,
+		},
+		{
+			name:           "Missing Student",
+			url:            "/getStudent/7",
+			method:         "GET",
+			expectedStatus: 404,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"student Not Found","code":"NOT_FOUND"}`,
+		},
+		{
+			name:           "Delete Missing Student",
+			url:            "/7",
+			method:         "DELETE",
+			expectedStatus: 404,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"student Not Found","code":"NOT_FOUND"}`,
+		},
+		{
+			name:           "Update Missing Student",
+			url:            "/",
+			method:         "PUT",
+			requestBody:    `{"id":7,"firstname":"Carlos","lastname":"Sainz","year":1,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}`,
+			expectedStatus: 404,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"student Not Found","code":"NOT_FOUND"}`,
+		},
+		{
+			name:           "Malformed JSON",
+			url:            "/",
+			method:         "POST",
+			requestBody:    `{"firstname":"Charles",`,
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"Invalid Request Body","code":"VALIDATION_ERROR"}`,
+		},
+		{
+			name:           "Duplicate Student",
+			url:            "/",
+			method:         "POST",
+			requestBody:    `{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}`,
+			expectedStatus: 409,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"A Record With The Same Values Already Exists","code":"CONFLICT"}`,
+		},
+		{
+			name:           "Internal Error Is Not Leaked",
+			url:            "/",
+			method:         "PUT",
+			requestBody:    `{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}`,
+			expectedStatus: 500,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"Error Updating Student","code":"INTERNAL_ERROR"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest(test.method, test.url, strings.NewReader(test.requestBody))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
+
+func TestHandler_RequestValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the usecase must not be reached by an invalid body
+	usecase := mocks.NewMockUsecase[models.Student](ctrl)
+
+	r := mux.NewRouter()
+	NewHandler[models.Student](usecase, models.StudentResource).Routes(r, middleware.Authorize(nil, "student"))
+
+	testCases := []struct {
+		name           string
+		url            string
+		method         string
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Empty Body",
+			url:            "/",
+			method:         "POST",
+			requestBody:    "",
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"Invalid Request Body","code":"VALIDATION_ERROR","errors":[{"field":"firstname","message":"is required"},{"field":"lastname","message":"is required"},{"field":"year","message":"must be between 1 and 6"}]}`,
+		},
+		{
+			name:           "Missing Lastname",
+			url:            "/",
+			method:         "PUT",
+			requestBody:    `{"id":1,"firstname":"Charles","year":3}`,
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"Invalid Request Body","code":"VALIDATION_ERROR","errors":[{"field":"lastname","message":"is required"}]}`,
+		},
+		{
+			name:           "Unknown Field",
+			url:            "/",
+			method:         "POST",
+			requestBody:    `{"firstname":"Charles","lastname":"Leclerc","year":3,"team":"Ferrari"}`,
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"Invalid Request Body","code":"VALIDATION_ERROR","errors":[{"field":"team","message":"is not allowed"}]}`,
+		},
+		{
+			name:           "Wrong Type",
+			url:            "/",
+			method:         "POST",
+			requestBody:    `{"firstname":"Charles","lastname":"Leclerc","year":"third"}`,
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"Invalid Request Body","code":"VALIDATION_ERROR","errors":[{"field":"year","message":"must be of type int"}]}`,
+		},
+		{
+			name:           "Negative Page",
+			url:            "/search",
+			method:         "GET",
+			requestBody:    `{"searchString":"charl","pagination":{"page":-1,"pageSize":2}}`,
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"totalElements":0,"data":null},"message":"Invalid Request Body","code":"VALIDATION_ERROR","errors":[{"field":"pagination.page","message":"must be at least 0"}]}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest(test.method, test.url, strings.NewReader(test.requestBody))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
+
+func TestHandler_SoftDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockSoftDeleteUsecase[models.Student](ctrl)
+	usecase.EXPECT().Restore(gomock.Any(), 7).Return(&student7, nil)
+	usecase.EXPECT().Restore(gomock.Any(), 8).Return(errStudent, apperrors.NotFound(consts.StudentNotFound))
+	usecase.EXPECT().GetAll(gomock.Any(), nil).Return([]models.Student{student1}, nil).Times(2)
+
+	r := mux.NewRouter()
+	NewSoftDeleteHandler[models.Student](usecase, models.StudentResource).Routes(r,
+		middleware.Authorize(auth.DefaultPolicy(), "student"))
+
+	testCases := []struct {
+		name           string
+		url            string
+		method         string
+		roles          []string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Restore",
+			url:            "/7/restore",
+			method:         "POST",
+			roles:          []string{"admin"},
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":7,"registrationNumber":null,"firstname":"Carlos","lastname":"Sainz","year":1,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Restored Successfully"}`,
+		},
+		{
+			name:           "Restore Missing",
+			url:            "/8/restore",
+			method:         "POST",
+			roles:          []string{"admin"},
+			expectedStatus: 404,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"student Not Found","code":"NOT_FOUND"}`,
+		},
+		{
+			name:           "Restore Denied",
+			url:            "/7/restore",
+			method:         "POST",
+			roles:          []string{"lecturer"},
+			expectedStatus: 403,
+			expectedBody:   `{"status":"Error","data":null,"message":"You Are Not Allowed To Perform This Action","code":"FORBIDDEN"}`,
+		},
+		{
+			name:           "Include Deleted",
+			url:            "/?includeDeleted=true",
+			method:         "GET",
+			roles:          []string{"admin"},
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":[{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}],"message":"Student Queried Successfully"}`,
+		},
+		{
+			name:           "Include Deleted Denied",
+			url:            "/?includeDeleted=true",
+			method:         "GET",
+			roles:          []string{"lecturer"},
+			expectedStatus: 403,
+			expectedBody:   `{"status":"Error","data":null,"message":"You Are Not Allowed To Perform This Action","code":"FORBIDDEN"}`,
+		},
+		{
+			name:           "Exclude Deleted",
+			url:            "/?includeDeleted=false",
+			method:         "GET",
+			roles:          []string{"lecturer"},
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":[{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}],"message":"Student Queried Successfully"}`,
+		},
+		{
+			name:           "Invalid Include Deleted",
+			url:            "/?includeDeleted=maybe",
+			method:         "GET",
+			roles:          []string{"admin"},
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":null,"message":"includeDeleted Must Be true Or false","code":"VALIDATION_ERROR"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest(test.method, test.url, nil)
+		req = req.WithContext(auth.WithClaims(req.Context(), &auth.Claims{Roles: test.roles}))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
+
+func TestHandler_ETags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stored := student1
+	stored.Version = 2
+	updated := student1
+	updated.Version = 3
+
+	usecase := mocks.NewMockUsecase[models.Student](ctrl)
+	usecase.EXPECT().Get(gomock.Any(), 1).Return(&stored, nil).Times(3)
+	usecase.EXPECT().Update(gomock.Any(), &student1).Return(errStudent,
+		apperrors.PreconditionFailed(consts.VersionMismatchError))
+	usecase.EXPECT().Update(gomock.Any(), &student1).Return(&updated, nil)
+	usecase.EXPECT().Delete(gomock.Any(), 9).Return(errStudent, apperrors.NotFound(consts.StudentNotFound)).Times(2)
+
+	r := mux.NewRouter()
+	NewHandler[models.Student](usecase, models.StudentResource).Routes(r, middleware.Authorize(nil, "student"))
+
+	testCases := []struct {
+		name           string
+		url            string
+		method         string
+		header         string
+		value          string
+		requestBody    string
+		expectedStatus int
+		expectedETag   string
+		expectedBody   string
+	}{
+		{
+			name:           "Get",
+			url:            "/getStudent/1",
+			method:         "GET",
+			expectedStatus: 200,
+			expectedETag:   `"2"`,
+			expectedBody:   `{"status":"Success","data":{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Queried Successfully"}`,
+		},
+		{
+			name:           "Not Modified",
+			url:            "/getStudent/1",
+			method:         "GET",
+			header:         consts.IfNoneMatch,
+			value:          `"2"`,
+			expectedStatus: 304,
+			expectedETag:   `"2"`,
+			expectedBody:   ``,
+		},
+		{
+			name:           "Modified",
+			url:            "/getStudent/1",
+			method:         "GET",
+			header:         consts.IfNoneMatch,
+			value:          `"1"`,
+			expectedStatus: 200,
+			expectedETag:   `"2"`,
+			expectedBody:   `{"status":"Success","data":{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Queried Successfully"}`,
+		},
+		{
+			name:           "Precondition Failed",
+			url:            "/",
+			method:         "PUT",
+			header:         consts.IfMatch,
+			value:          `"1"`,
+			requestBody:    `{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3,"status":"active"}`,
+			expectedStatus: 412,
+			expectedETag:   ``,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"The Record Was Changed Since It Was Read","code":"PRECONDITION_FAILED"}`,
+		},
+		{
+			name:           "Update",
+			url:            "/",
+			method:         "PUT",
+			header:         consts.IfMatch,
+			value:          `"2"`,
+			requestBody:    `{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3,"status":"active"}`,
+			expectedStatus: 200,
+			expectedETag:   `"3"`,
+			expectedBody:   `{"status":"Success","data":{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Updated Successfully"}`,
+		},
+		{
+			name:           "Any Version Of A Missing Record",
+			url:            "/9",
+			method:         "DELETE",
+			header:         consts.IfMatch,
+			value:          `*`,
+			expectedStatus: 412,
+			expectedETag:   ``,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"The Record Does Not Exist","code":"PRECONDITION_FAILED"}`,
+		},
+		{
+			name:           "Missing Record",
+			url:            "/9",
+			method:         "DELETE",
+			expectedStatus: 404,
+			expectedETag:   ``,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"student Not Found","code":"NOT_FOUND"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest(test.method, test.url, strings.NewReader(test.requestBody))
+		if test.header != "" {
+			req.Header.Set(test.header, test.value)
+		}
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if etag := w.Header().Get(consts.ETag); etag != test.expectedETag {
+			t.Errorf("Test %s : Expected ETag %s, but got %s", test.name, test.expectedETag, etag)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
+
+func TestHandler_Patch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	stored := student1
+	stored.Version = 2
+
+	// the usecase patches the stored student like the repositories do
+	usecase := mocks.NewMockStudentUsecase(ctrl)
+	usecase.EXPECT().Patch(gomock.Any(), 1, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id int, patch func(*models.Student) error) (*models.Student, error) {
+			patched := stored
+			err := patch(&patched)
+			if err != nil {
+				return errStudent, err
+			}
+			patched.ID = stored.ID
+			patched.Version = stored.Version + 1
+			return &patched, nil
+		}).AnyTimes()
+
+	r := mux.NewRouter()
+	NewHandler[models.Student](usecase, models.StudentResource).Routes(r, middleware.Authorize(nil, "student"))
+
+	testCases := []struct {
+		name                string
+		url                 string
+		contentType         string
+		requestBody         string
+		expectedStatus      int
+		expectedETag        string
+		expectedAcceptPatch string
+		expectedBody        string
+	}{
+		{
+			name:           "Merge Patch",
+			url:            "/1",
+			contentType:    "application/merge-patch+json",
+			requestBody:    `{"year":4,"email":"Charles@Example.com"}`,
+			expectedStatus: 200,
+			expectedETag:   `"3"`,
+			expectedBody:   `{"status":"Success","data":{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":4,"email":"charles@example.com","phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Updated Successfully"}`,
+		},
+		{
+			name:           "Plain JSON Is A Merge Patch",
+			url:            "/1",
+			contentType:    "application/json; charset=utf-8",
+			requestBody:    `{"lastname":"Sainz"}`,
+			expectedStatus: 200,
+			expectedETag:   `"3"`,
+			expectedBody:   `{"status":"Success","data":{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Sainz","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Updated Successfully"}`,
+		},
+		{
+			name:           "JSON Patch",
+			url:            "/1",
+			contentType:    "application/json-patch+json",
+			requestBody:    `[{"op":"test","path":"/year","value":3},{"op":"replace","path":"/year","value":5}]`,
+			expectedStatus: 200,
+			expectedETag:   `"3"`,
+			expectedBody:   `{"status":"Success","data":{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":5,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Updated Successfully"}`,
+		},
+		{
+			name:           "Patched Student Is Validated",
+			url:            "/1",
+			contentType:    "application/merge-patch+json",
+			requestBody:    `{"year":9,"firstname":null}`,
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"Invalid Request Body","code":"VALIDATION_ERROR","errors":[{"field":"firstname","message":"is required"},{"field":"year","message":"must be between 1 and 6"}]}`,
+		},
+		{
+			name:           "Unknown Field",
+			url:            "/1",
+			contentType:    "application/merge-patch+json",
+			requestBody:    `{"team":"Ferrari"}`,
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"Invalid Request Body","code":"VALIDATION_ERROR","errors":[{"field":"team","message":"is not allowed"}]}`,
+		},
+		{
+			name:           "Failed Test",
+			url:            "/1",
+			contentType:    "application/json-patch+json",
+			requestBody:    `[{"op":"test","path":"/year","value":2},{"op":"replace","path":"/year","value":5}]`,
+			expectedStatus: 409,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"The Patch Test Failed","code":"CONFLICT"}`,
+		},
+		{
+			name:           "Invalid Patch",
+			url:            "/1",
+			contentType:    "application/json-patch+json",
+			requestBody:    `{"year":4}`,
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"Invalid Patch","code":"VALIDATION_ERROR"}`,
+		},
+		{
+			name:                "Unsupported Content Type",
+			url:                 "/1",
+			contentType:         "text/plain",
+			requestBody:         `{"year":4}`,
+			expectedStatus:      415,
+			expectedAcceptPatch: "application/merge-patch+json, application/json-patch+json",
+			expectedBody:        `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"Content-Type Must Be application/merge-patch+json Or application/json-patch+json","code":"UNSUPPORTED_MEDIA_TYPE"}`,
+		},
+		{
+			name:                "Missing Content Type",
+			url:                 "/1",
+			requestBody:         `{"year":4}`,
+			expectedStatus:      415,
+			expectedAcceptPatch: "application/merge-patch+json, application/json-patch+json",
+			expectedBody:        `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"Content-Type Must Be application/merge-patch+json Or application/json-patch+json","code":"UNSUPPORTED_MEDIA_TYPE"}`,
+		},
+		{
+			name:           "Body Too Large",
+			url:            "/1",
+			contentType:    "application/merge-patch+json",
+			requestBody:    `{"lastname":"` + strings.Repeat("a", MaxBodySize) + `"}`,
+			expectedStatus: 413,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"The Request Body Is Too Large","code":"REQUEST_TOO_LARGE"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest("PATCH", test.url, strings.NewReader(test.requestBody))
+		req.Header.Set(consts.ContentType, test.contentType)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if etag := w.Header().Get(consts.ETag); etag != test.expectedETag {
+			t.Errorf("Test %s : Expected ETag %s, but got %s", test.name, test.expectedETag, etag)
+		}
+
+		if accept := w.Header().Get(consts.AcceptPatch); accept != test.expectedAcceptPatch {
+			t.Errorf("Test %s : Expected Accept-Patch %s, but got %s", test.name, test.expectedAcceptPatch, accept)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
+
+func TestHandler_V1Routes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	usecase := mocks.NewMockUsecase[models.Student](ctrl)
+	usecase.EXPECT().GetAll(gomock.Any(), nil).Return([]models.Student{student1}, nil)
+	usecase.EXPECT().Get(gomock.Any(), 1).Return(&student1, nil)
+	usecase.EXPECT().Update(gomock.Any(), &student7).Return(&student7, nil).Times(2)
+	usecase.EXPECT().Delete(gomock.Any(), 7).Return(&student7, nil)
+	usecase.EXPECT().Search(gomock.Any(), "lec", filter.Expr{{Field: "year", Op: filter.Eq, Values: []string{"3"}}},
+		models.Pagination{Page: 0, PageSize: 10}, models.SortBy{Column: "lastname", Direction: "desc"}).
+		Return(&models.SearchData[models.Student]{TotalElements: 1, Data: []models.Student{student1}}, nil)
+	usecase.EXPECT().Search(gomock.Any(), "ham", nil, models.Pagination{Page: 0, PageSize: models.DefaultPageSize},
+		models.SortBy{}).Return(&models.SearchData[models.Student]{}, nil)
+
+	r := mux.NewRouter()
+	NewHandler[models.Student](usecase, models.StudentResource).V1Routes(
+		r.PathPrefix("/api/v1/students").Subrouter(), middleware.Authorize(nil, "student"))
+
+	testCases := []struct {
+		name           string
+		url            string
+		method         string
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "List",
+			url:            "/api/v1/students",
+			method:         "GET",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":[{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}],"message":"Student Queried Successfully"}`,
+		},
+		{
+			name:           "Get",
+			url:            "/api/v1/students/1",
+			method:         "GET",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Queried Successfully"}`,
+		},
+		{
+			name:           "Update Without The ID In The Body",
+			url:            "/api/v1/students/7",
+			method:         "PUT",
+			requestBody:    `{"firstname":"Carlos","lastname":"Sainz","year":1,"status":"active"}`,
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":7,"registrationNumber":null,"firstname":"Carlos","lastname":"Sainz","year":1,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Updated Successfully"}`,
+		},
+		{
+			name:           "Update With The ID In The Body",
+			url:            "/api/v1/students/7",
+			method:         "PUT",
+			requestBody:    `{"id":7,"firstname":"Carlos","lastname":"Sainz","year":1,"status":"active"}`,
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":7,"registrationNumber":null,"firstname":"Carlos","lastname":"Sainz","year":1,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Updated Successfully"}`,
+		},
+		{
+			name:           "Update With Another ID In The Body",
+			url:            "/api/v1/students/7",
+			method:         "PUT",
+			requestBody:    `{"id":1,"firstname":"Carlos","lastname":"Sainz","year":1,"status":"active"}`,
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"Invalid Request Body","code":"VALIDATION_ERROR","errors":[{"field":"id","message":"must be the id of the path"}]}`,
+		},
+		{
+			name:           "Delete",
+			url:            "/api/v1/students/7",
+			method:         "DELETE",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":7,"registrationNumber":null,"firstname":"Carlos","lastname":"Sainz","year":1,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Deleted Successfully"}`,
+		},
+		{
+			name:           "Search",
+			url:            "/api/v1/students/search?q=lec&page=0&pageSize=10&sortBy=lastname&direction=desc&filter=year:eq:3",
+			method:         "GET",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"totalElements":1,"data":[{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}]},"message":"Student Queried Successfully"}`,
+		},
+		{
+			name:           "Search With The Default Page Size",
+			url:            "/api/v1/students/search?q=ham",
+			method:         "GET",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"totalElements":0,"data":null},"message":"Student Queried Successfully"}`,
+		},
+		{
+			name:           "Search With Unknown Parameters",
+			url:            "/api/v1/students/search?pageSize=ten&pagesize=10&year=3",
+			method:         "GET",
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"totalElements":0,"data":null},"message":"Invalid Search Query","code":"VALIDATION_ERROR","errors":[{"field":"pageSize","message":"must be a number"},{"field":"pagesize","message":"is not allowed"},{"field":"year","message":"is not allowed"}]}`,
+		},
+		{
+			name:           "Search Above The Maximum Page Size",
+			url:            "/api/v1/students/search?pageSize=101",
+			method:         "GET",
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"totalElements":0,"data":null},"message":"Invalid Search Query","code":"VALIDATION_ERROR","errors":[{"field":"pagination.pageSize","message":"must be between 0 and 100"}]}`,
+		},
+		{
+			name:           "Search A Negative Page",
+			url:            "/api/v1/students/search?page=-1",
+			method:         "GET",
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"totalElements":0,"data":null},"message":"Invalid Search Query","code":"VALIDATION_ERROR","errors":[{"field":"pagination.page","message":"must be at least 0"}]}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest(test.method, test.url, strings.NewReader(test.requestBody))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
+
+func TestHandler_Filter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the usecase reads a memory repository, which applies the filter
+	// expression
+	repo := repository.NewMemoryStudentRepository(numbering.MustParse("STU-{seq:3}"))
+	for _, st := range []models.Student{student1, student7} {
+		st := st
+		_, err := repo.Create(context.Background(), &st)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	usecase := mocks.NewMockUsecase[models.Student](ctrl)
+	usecase.EXPECT().GetAll(gomock.Any(), gomock.Any()).DoAndReturn(repo.GetAll).Times(2)
+	usecase.EXPECT().Search(gomock.Any(), "", gomock.Any(), models.Pagination{Page: 0, PageSize: 10}, models.SortBy{}).
+		DoAndReturn(repo.Search)
+
+	r := mux.NewRouter()
+	handler := NewHandler[models.Student](usecase, models.StudentResource)
+	handler.Routes(r, middleware.Authorize(nil, "student"))
+	handler.V1Routes(r.PathPrefix("/api/v1/students").Subrouter(), middleware.Authorize(nil, "student"))
+
+	testCases := []struct {
+		name           string
+		url            string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "List",
+			url:            "/?filter=year:eq:3,lastname:prefix:L",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":[{"id":1,"registrationNumber":"STU-001","firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}],"message":"Student Queried Successfully"}`,
+		},
+		{
+			name:           "Search",
+			url:            "/api/v1/students/search?pageSize=10&filter=firstname:in:carlos|lando",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"totalElements":1,"data":[{"id":2,"registrationNumber":"STU-002","firstname":"Carlos","lastname":"Sainz","year":1,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}]},"message":"Student Queried Successfully"}`,
+		},
+		{
+			name:           "Syntax Error",
+			url:            "/?filter=year:eq",
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":null,"message":"Invalid Filter : \"year:eq\" is not a field:operator:value condition","code":"VALIDATION_ERROR"}`,
+		},
+		{
+			name:           "Field That Can Not Be Filtered",
+			url:            "/api/v1/students?filter=position:eq:Registrar",
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":null,"message":"Invalid Search Request : field \"position\" can not be filtered","code":"VALIDATION_ERROR"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest("GET", test.url, nil)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
+
+func TestHandler_ChangedNumber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the usecase updates a memory repository, which keeps the numbers
+	repo := repository.NewMemoryStudentRepository(numbering.MustParse("STU-{seq:3}"))
+	st := student1
+	_, err := repo.Create(context.Background(), &st)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	usecase := mocks.NewMockUsecase[models.Student](ctrl)
+	usecase.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(repo.Update).Times(2)
+
+	r := mux.NewRouter()
+	NewHandler[models.Student](usecase, models.StudentResource).
+		V1Routes(r.PathPrefix("/api/v1/students").Subrouter(), middleware.Authorize(nil, "student"))
+
+	testCases := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Changed Number",
+			requestBody:    `{"registrationNumber":"STU-042","firstname":"Charles","lastname":"Leclerc","year":4,"status":"active"}`,
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"Invalid Request Body","code":"VALIDATION_ERROR","errors":[{"field":"registrationNumber","message":"can not be changed"}]}`,
+		},
+		{
+			name:           "Same Number",
+			requestBody:    `{"registrationNumber":"STU-001","firstname":"Charles","lastname":"Leclerc","year":4,"status":"active"}`,
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":1,"registrationNumber":"STU-001","firstname":"Charles","lastname":"Leclerc","year":4,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Updated Successfully"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest("PUT", "/api/v1/students/1", strings.NewReader(test.requestBody))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
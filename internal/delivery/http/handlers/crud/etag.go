@@ -0,0 +1,110 @@
+package crud
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+)
+
+// ETag returns the entity tag of the entity, empty when it is not versioned.
+// The entity tag of a record is its version, eg. "3", so it changes with
+// every change of the record. Only the models.Versioned entities have one
+func ETag(entity interface{}) string {
+	versioned, ok := entity.(models.Versioned)
+	if !ok {
+		return ""
+	}
+	return `"` + strconv.Itoa(versioned.CurrentVersion()) + `"`
+}
+
+// SetETag sets the ETag header of the response to the entity tag of the
+// entity, when it has one
+func SetETag(w http.ResponseWriter, entity interface{}) {
+	if tag := ETag(entity); tag != "" {
+		w.Header().Set(consts.ETag, tag)
+	}
+}
+
+// NotModified tells whether the If-None-Match header of the request matches
+// the entity tag of the entity, the client already has the entity then. The
+// tags are compared weakly, so W/"3" matches "3", and * matches any entity
+func NotModified(r *http.Request, entity interface{}) bool {
+	current := ETag(entity)
+	if current == "" {
+		return false
+	}
+	for _, tag := range entityTags(r, consts.IfNoneMatch) {
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == current {
+			return true
+		}
+	}
+	return false
+}
+
+// IfMatch returns the context of the request, limited to changing the
+// versions listed by its If-Match header. The tags are compared strongly, a
+// weak or invalid tag matches no version, and a missing header or * matches
+// any version
+func IfMatch(r *http.Request) context.Context {
+	versions, limited := matchedVersions(entityTags(r, consts.IfMatch))
+	if !limited {
+		return r.Context()
+	}
+	return repository.IfVersion(r.Context(), versions...)
+}
+
+// IfMatchFailed returns a precondition failure in place of the not found
+// error of a request whose If-Match is *, which requires a current record
+func IfMatchFailed(r *http.Request, err error) error {
+	if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+		return err
+	}
+	for _, tag := range entityTags(r, consts.IfMatch) {
+		if tag == "*" {
+			return apperrors.PreconditionFailed(consts.RecordMissingError)
+		}
+	}
+	return err
+}
+
+// matchedVersions returns the versions matched by the If-Match tags, false
+// when they match any version
+func matchedVersions(tags []string) ([]int, bool) {
+	if len(tags) == 0 {
+		return nil, false
+	}
+
+	var versions []int
+	for _, tag := range tags {
+		if tag == "*" {
+			return nil, false
+		}
+		if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+			continue
+		}
+		version, err := strconv.Atoi(tag[1 : len(tag)-1])
+		if err == nil {
+			versions = append(versions, version)
+		}
+	}
+	return versions, true
+}
+
+// entityTags returns the comma separated tags of the named header
+func entityTags(r *http.Request, header string) []string {
+	var tags []string
+	for _, value := range r.Header.Values(header) {
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
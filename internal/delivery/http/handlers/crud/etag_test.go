@@ -0,0 +1,65 @@
+package crud
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+)
+
+func TestMatchedVersions(t *testing.T) {
+	testCases := []struct {
+		name             string
+		tags             []string
+		expectedVersions []int
+		expectedLimited  bool
+	}{
+		{name: "No Header", tags: nil, expectedVersions: nil, expectedLimited: false},
+		{name: "Any Version", tags: []string{"*"}, expectedVersions: nil, expectedLimited: false},
+		{name: "One Version", tags: []string{`"3"`}, expectedVersions: []int{3}, expectedLimited: true},
+		{name: "Many Versions", tags: []string{`"3"`, `"5"`}, expectedVersions: []int{3, 5}, expectedLimited: true},
+		{name: "Weak Tag", tags: []string{`W/"3"`}, expectedVersions: nil, expectedLimited: true},
+		{name: "Invalid Tags", tags: []string{`3`, `"`, `"three"`}, expectedVersions: nil, expectedLimited: true},
+	}
+
+	for _, test := range testCases {
+		versions, limited := matchedVersions(test.tags)
+		if !reflect.DeepEqual(versions, test.expectedVersions) || limited != test.expectedLimited {
+			t.Errorf("Test %s : Expected %v, %v, but got %v, %v", test.name, test.expectedVersions,
+				test.expectedLimited, versions, limited)
+		}
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	student := models.Student{ID: 1, FirstName: "Charles", LastName: "Leclerc", Year: 3, Version: 3}
+
+	testCases := []struct {
+		name        string
+		ifNoneMatch string
+		entity      interface{}
+		expected    bool
+	}{
+		{name: "No Header", ifNoneMatch: "", entity: student, expected: false},
+		{name: "Same Version", ifNoneMatch: `"3"`, entity: student, expected: true},
+		{name: "Weak Tag", ifNoneMatch: `W/"3"`, entity: student, expected: true},
+		{name: "One Of Many", ifNoneMatch: `"1", "3"`, entity: student, expected: true},
+		{name: "Any Version", ifNoneMatch: "*", entity: student, expected: true},
+		{name: "Changed", ifNoneMatch: `"2"`, entity: student, expected: false},
+		{name: "Not Versioned", ifNoneMatch: "*", entity: models.Staff{ID: 1}, expected: false},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest("GET", "/getStudent/1", nil)
+		if test.ifNoneMatch != "" {
+			req.Header.Set(consts.IfNoneMatch, test.ifNoneMatch)
+		}
+
+		actual := NotModified(req, test.entity)
+		if actual != test.expected {
+			t.Errorf("Test %s : Expected %v, but got %v", test.name, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package crud
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/patch"
+)
+
+// readPatch reads the patch of the request body and returns the function
+// applying it to the JSON of a record. The body is a merge patch when the
+// Content-Type is application/merge-patch+json, or plain application/json,
+// and a JSON patch when it is application/json-patch+json. Any other
+// Content-Type is an unsupported media type
+func readPatch(r *http.Request) (func(doc []byte) ([]byte, error), error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get(consts.ContentType))
+	if err != nil {
+		return nil, apperrors.UnsupportedMediaType(consts.PatchTypeError, err)
+	}
+
+	var apply func(doc []byte, p []byte) ([]byte, error)
+	switch mediaType {
+	case patch.MergePatchType, consts.ApplicationJSON:
+		apply = patch.Merge
+	case patch.JSONPatchType:
+		apply = patch.Apply
+	default:
+		return nil, apperrors.UnsupportedMediaType(consts.PatchTypeError, nil)
+	}
+
+	body, err := readAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(doc []byte) ([]byte, error) {
+		patched, err := apply(doc, body)
+		if errors.Is(err, patch.ErrTestFailed) {
+			return nil, apperrors.Conflict(consts.PatchTestError, err)
+		}
+		if err != nil {
+			return nil, apperrors.Validation(consts.InvalidPatch, err)
+		}
+		return patched, nil
+	}, nil
+}
@@ -0,0 +1,97 @@
+package crud
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/filter"
+)
+
+// searchParams are the query parameters of a search, includeDeleted is read
+// by the route
+var searchParams = map[string]bool{
+	"q":              true,
+	"page":           true,
+	"pageSize":       true,
+	"sortBy":         true,
+	"direction":      true,
+	"includeDeleted": true,
+	"filter":         true,
+}
+
+// SearchQuery reads the search request from the query parameters, eg.
+// ?q=ham&page=0&pageSize=10&sortBy=lastname&direction=desc&filter=year:eq:3.
+// The page size is models.DefaultPageSize when it is not set and at most
+// models.MaxPageSize. Any other parameter is rejected by name, the filters
+// go in the filter expression. The request is validated like a search
+// request body, but its errors are reported as query errors
+func SearchQuery(r *http.Request) (models.SearchRequest, error) {
+	query := r.URL.Query()
+	req := models.SearchRequest{
+		SearchString: query.Get("q"),
+		SortBy: models.SortBy{
+			Column:    query.Get("sortBy"),
+			Direction: query.Get("direction"),
+		},
+		Pagination: models.Pagination{PageSize: models.DefaultPageSize},
+	}
+
+	var fields []apperrors.FieldError
+	number := func(name string) int {
+		n, err := strconv.Atoi(query.Get(name))
+		if err != nil {
+			fields = append(fields, apperrors.FieldError{Field: name, Message: "must be a number"})
+		}
+		return n
+	}
+
+	if query.Has("page") {
+		req.Pagination.Page = number("page")
+	}
+	if query.Has("pageSize") {
+		req.Pagination.PageSize = number("pageSize")
+	}
+
+	var unknown []string
+	for name := range query {
+		if !searchParams[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	// sorted so the errors are reported in a stable order
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		fields = append(fields, apperrors.FieldError{Field: name, Message: "is not allowed"})
+	}
+
+	if len(fields) != 0 {
+		return req, apperrors.InvalidFields(consts.InvalidSearchQuery, fields)
+	}
+
+	expr, err := FilterQuery(r)
+	if err != nil {
+		return req, err
+	}
+	req.Filter = expr
+
+	fields = req.Validate()
+	if len(fields) != 0 {
+		return req, apperrors.InvalidFields(consts.InvalidSearchQuery, fields)
+	}
+	return req, nil
+}
+
+// FilterQuery reads the filter expression of the filter query parameter,
+// eg. ?filter=year:eq:3,lastname:prefix:L, the repository decides which
+// fields can be filtered. It is empty when the parameter is not set
+func FilterQuery(r *http.Request) (filter.Expr, error) {
+	expr, err := filter.Parse(r.URL.Query().Get("filter"))
+	if err != nil {
+		return nil, apperrors.Validation(consts.InvalidFilterError+" : "+err.Error(), err)
+	}
+	return expr, nil
+}
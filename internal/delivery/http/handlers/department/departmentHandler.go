@@ -0,0 +1,115 @@
+package department
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/crud"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/middleware"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/response"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	dep "github.com/shashaneRanasinghe/simpleAPI/internal/usecases/department"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+type DepartmentHandler struct {
+	department *crud.Handler[models.Department]
+	usecase    dep.DepartmentUsecase
+}
+
+func NewDepartmentHandler(departmentRepo repository.DepartmentRepository) *DepartmentHandler {
+	return newDepartmentHandler(dep.NewDepartment(departmentRepo))
+}
+
+func newDepartmentHandler(department dep.DepartmentUsecase) *DepartmentHandler {
+	return &DepartmentHandler{
+		department: crud.NewHandler[models.Department](department, models.DepartmentResource),
+		usecase:    department,
+	}
+}
+
+// DepartmentRoutes registers the department routes, policy decides who may
+// use them. The lecturers and staff of a department are authorized as a list
+// of lecturers and a list of staff
+func (handler *DepartmentHandler) DepartmentRoutes(r *mux.Router, policy *auth.Policy) {
+	handler.department.Routes(r, middleware.Authorize(policy, "department"))
+	handler.subRoutes(r, policy)
+}
+
+// V1Routes registers the department routes of /api/v1/departments like
+// DepartmentRoutes
+func (handler *DepartmentHandler) V1Routes(r *mux.Router, policy *auth.Policy) {
+	handler.department.V1Routes(r, middleware.Authorize(policy, "department"))
+	handler.subRoutes(r, policy)
+}
+
+// subRoutes registers the routes that are the same in every version
+func (handler *DepartmentHandler) subRoutes(r *mux.Router, policy *auth.Policy) {
+	authorizeLecturer := middleware.Authorize(policy, "lecturer")
+	r.Handle("/{id}/lecturers", authorizeLecturer(auth.ActionList, handler.getLecturers)).Methods("GET")
+
+	authorizeStaff := middleware.Authorize(policy, "staff")
+	r.Handle("/{id}/staff", authorizeStaff(auth.ActionList, handler.getStaff)).Methods("GET")
+}
+
+func (handler *DepartmentHandler) getLecturers(w http.ResponseWriter, r *http.Request) {
+	var respModel models.LecturerListResponse
+
+	id, err := crud.PathID(r)
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.IDError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	list, err := handler.usecase.GetLecturers(r.Context(), id)
+	if err != nil {
+		log.Error(consts.GetLecturersError, err)
+
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.GetLecturersError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	respModel.Status = consts.Success
+	respModel.Data = list
+	respModel.Message = consts.GetLecturer
+	response.Write(w, http.StatusOK, respModel)
+}
+
+func (handler *DepartmentHandler) getStaff(w http.ResponseWriter, r *http.Request) {
+	var respModel models.StaffListResponse
+
+	id, err := crud.PathID(r)
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.IDError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	list, err := handler.usecase.GetStaff(r.Context(), id)
+	if err != nil {
+		log.Error(consts.GetStaffError, err)
+
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.GetStaffError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	respModel.Status = consts.Success
+	respModel.Data = list
+	respModel.Message = consts.GetStaff
+	response.Write(w, http.StatusOK, respModel)
+}
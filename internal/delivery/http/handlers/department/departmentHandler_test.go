@@ -0,0 +1,169 @@
+package department
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/mocks"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/filter"
+)
+
+var (
+	aeroID      = 1
+	headID      = 1
+	department0 = models.Department{
+		ID:   0,
+		Name: "Aerodynamics",
+	}
+	department1 = models.Department{
+		ID:     1,
+		Name:   "Aerodynamics",
+		HeadID: &headID,
+	}
+	lecturer1 = models.Lecturer{
+		ID:           1,
+		FirstName:    "Adrian",
+		LastName:     "Newey",
+		Year:         30,
+		DepartmentID: &aeroID,
+		Status:       "active",
+	}
+	staff1 = models.Staff{
+		ID:           1,
+		FirstName:    "Toto",
+		LastName:     "Wolff",
+		Position:     "Registrar",
+		DepartmentID: &aeroID,
+	}
+)
+
+func TestDepartmentRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	department := mocks.NewMockDepartmentUsecase(ctrl)
+	department.EXPECT().Get(gomock.Any(), 1).Return(&department1, nil)
+	department.EXPECT().Create(gomock.Any(), &department0).Return(&department0, nil)
+	department.EXPECT().Create(gomock.Any(), &models.Department{Name: "Aerodynamics", HeadID: &headID}).
+		Return(&models.Department{}, apperrors.InvalidFields(consts.InvalidRequestBody,
+			[]apperrors.FieldError{{Field: "headId", Message: consts.HeadOnCreateError}}))
+	department.EXPECT().Update(gomock.Any(), &models.Department{Name: "Aerodynamics", HeadID: &headID}).
+		Return(&models.Department{}, apperrors.NotFound(consts.DepartmentNotFound))
+	department.EXPECT().Update(gomock.Any(), &department1).
+		Return(&models.Department{}, apperrors.Unprocessable(consts.HeadNotInDepartmentError))
+	department.EXPECT().Search(gomock.Any(), "", filter.Expr{{Field: "headId", Op: filter.Eq, Values: []string{"1"}}},
+		models.Pagination{Page: 0, PageSize: 2}, models.SortBy{}).
+		Return(&models.DepartmentSearchData{TotalElements: 1, Data: []models.Department{department1}}, nil)
+	department.EXPECT().GetLecturers(gomock.Any(), 1).Return([]models.Lecturer{lecturer1}, nil)
+	department.EXPECT().GetLecturers(gomock.Any(), 2).Return(nil, apperrors.NotFound(consts.DepartmentNotFound))
+	department.EXPECT().GetStaff(gomock.Any(), 1).Return([]models.Staff{staff1}, nil)
+
+	r := mux.NewRouter()
+	newDepartmentHandler(department).DepartmentRoutes(r, nil)
+
+	testCases := []struct {
+		name           string
+		url            string
+		method         string
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Get Specific Department",
+			url:            "/getDepartment/1",
+			method:         "GET",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":1,"name":"Aerodynamics","headId":1},"message":"Department Queried Successfully"}`,
+		},
+		{
+			name:           "Create Department",
+			url:            "/",
+			method:         "POST",
+			requestBody:    `{"name":"Aerodynamics"}`,
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":0,"name":"Aerodynamics","headId":null},"message":"Department Created Successfully"}`,
+		},
+		{
+			name:           "Create Department With Head",
+			url:            "/",
+			method:         "POST",
+			requestBody:    `{"name":"Aerodynamics","headId":1}`,
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"id":0,"name":"","headId":null},"message":"Invalid Request Body","code":"VALIDATION_ERROR","errors":[{"field":"headId","message":"can not be set on create, appoint the head once the lecturers are assigned"}]}`,
+		},
+		{
+			name:           "Update Department Without ID",
+			url:            "/",
+			method:         "PUT",
+			requestBody:    `{"name":"Aerodynamics","headId":1}`,
+			expectedStatus: 404,
+			expectedBody:   `{"status":"Error","data":{"id":0,"name":"","headId":null},"message":"department Not Found","code":"NOT_FOUND"}`,
+		},
+		{
+			name:           "Head From Another Department",
+			url:            "/",
+			method:         "PUT",
+			requestBody:    `{"id":1,"name":"Aerodynamics","headId":1}`,
+			expectedStatus: 422,
+			expectedBody:   `{"status":"Error","data":{"id":0,"name":"","headId":null},"message":"The Head Must Be A Lecturer Of The Department","code":"UNPROCESSABLE"}`,
+		},
+		{
+			name:           "Search By Head",
+			url:            "/search?filter=headId:eq:1",
+			method:         "GET",
+			requestBody:    `{"pagination":{"page":0,"pageSize":2}}`,
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"totalElements":1,"data":[{"id":1,"name":"Aerodynamics","headId":1}]},"message":"Department Queried Successfully"}`,
+		},
+		{
+			name:           "Get Lecturers",
+			url:            "/1/lecturers",
+			method:         "GET",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":[{"id":1,"firstname":"Adrian","lastname":"Newey","year":30,"departmentId":1,"email":null,"phone":null,"dateOfBirth":null,"status":"active"}],"message":"Lecturer Queried Successfully"}`,
+		},
+		{
+			name:           "Get Lecturers Of Missing Department",
+			url:            "/2/lecturers",
+			method:         "GET",
+			expectedStatus: 404,
+			expectedBody:   `{"status":"Error","data":null,"message":"department Not Found","code":"NOT_FOUND"}`,
+		},
+		{
+			name:           "Get Staff",
+			url:            "/1/staff",
+			method:         "GET",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":[{"id":1,"staffNumber":null,"firstname":"Toto","lastname":"Wolff","position":"Registrar","departmentId":1}],"message":"Staff Queried Successfully"}`,
+		},
+		{
+			name:           "Get Staff Bad ID",
+			url:            "/abc/staff",
+			method:         "GET",
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":null,"message":"Error Getting The ID","code":"VALIDATION_ERROR"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest(test.method, test.url, strings.NewReader(test.requestBody))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
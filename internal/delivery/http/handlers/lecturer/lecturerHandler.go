@@ -1,418 +1,84 @@
 package lecturer
 
 import (
-	"database/sql"
-	"encoding/json"
+	"net/http"
+
 	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/crud"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/middleware"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/response"
 	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
 	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	cou "github.com/shashaneRanasinghe/simpleAPI/internal/usecases/course"
 	lec "github.com/shashaneRanasinghe/simpleAPI/internal/usecases/lecturer"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
 	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
 	"github.com/tryfix/log"
-	"io"
-	"net/http"
-	"strconv"
 )
 
 type LecturerHandler struct {
-	lecturer lec.LecturerUsecase
-}
-
-func NewLecturerHandler(db *sql.DB) *LecturerHandler {
-	lecturerRepo := repository.NewLecturerRepository(db)
-	lecturer := lec.NewLecturer(lecturerRepo)
-	return &LecturerHandler{
-		lecturer: lecturer,
-	}
-}
-
-func (handler *LecturerHandler) LecturerRoutes(r *mux.Router) {
-	r.HandleFunc("/", handler.getAllLecturers).Methods("GET")
-	r.HandleFunc("/getLecturer/{id}", handler.getLecturer).Methods("GET")
-	r.HandleFunc("/", handler.createLecturer).Methods("POST")
-	r.HandleFunc("/", handler.updateLecturer).Methods("PUT")
-	r.HandleFunc("/{id}", handler.deleteLecturer).Methods("DELETE")
-	r.HandleFunc("/search", handler.searchLecturers).Methods("GET")
-
+	lecturer *crud.Handler[models.Lecturer]
+	course   cou.CourseUsecase
 }
 
-func (handler *LecturerHandler) getAllLecturers(w http.ResponseWriter, _ *http.Request) {
-	var respModel models.LecturerListResponse
-
-	w.Header().Set(consts.ContentType, consts.ApplicationJSON)
-
-	lecturers, err := handler.lecturer.GetAllLecturers()
-	if err != nil {
-		log.Error(consts.GetLecturersError, err)
-
-		respModel.Status = consts.Error
-		respModel.Message = consts.GetLecturersError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
-		return
-	}
-	w.WriteHeader(http.StatusOK)
-
-	respModel.Status = consts.Success
-	respModel.Data = lecturers
-	respModel.Message = consts.GetLecturer
-
-	b, err := json.Marshal(respModel)
-	if err != nil {
-		log.Error(consts.JSONMarshalError, err)
-	}
-
-	_, err = w.Write(b)
-	if err != nil {
-		log.Error(consts.ResponseWriteError, err)
-	}
+func NewLecturerHandler(lecturerRepo repository.LecturerRepository,
+	courseRepo repository.CourseRepository) *LecturerHandler {
+	return newLecturerHandler(lec.NewLecturer(lecturerRepo), cou.NewCourse(courseRepo, lecturerRepo))
 }
 
-func (handler *LecturerHandler) getLecturer(w http.ResponseWriter, r *http.Request) {
-	var respModel models.LecturerResponse
-	w.Header().Set(consts.ContentType, consts.ApplicationJSON)
-
-	params := mux.Vars(r)
-	id, err := strconv.Atoi(params["id"])
-	if err != nil {
-		log.Error(consts.IDError, err)
-		respModel.Status = consts.Error
-		respModel.Message = consts.GetLecturersError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
-		return
-	}
-
-	lecturer, err := handler.lecturer.GetLecturer(id)
-	if err != nil {
-		log.Error(consts.GetLecturersError, err)
-
-		respModel.Status = consts.Error
-		respModel.Message = consts.GetLecturersError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
-		return
-	}
-	w.WriteHeader(http.StatusOK)
-
-	respModel.Status = consts.Success
-	respModel.Data = *lecturer
-	respModel.Message = consts.GetLecturer
-
-	b, err := json.Marshal(respModel)
-	if err != nil {
-		log.Error(consts.JSONMarshalError, err)
-	}
-
-	_, err = w.Write(b)
-	if err != nil {
-		log.Error(consts.ResponseWriteError, err)
+func newLecturerHandler(lecturer lec.LecturerUsecase, course cou.CourseUsecase) *LecturerHandler {
+	return &LecturerHandler{
+		lecturer: crud.NewSoftDeleteHandler[models.Lecturer](lecturer, models.LecturerResource),
+		course:   course,
 	}
 }
 
-func (handler *LecturerHandler) createLecturer(w http.ResponseWriter, r *http.Request) {
-	var respModel models.LecturerResponse
-	var newLecturer models.Lecturer
-
-	w.Header().Set(consts.ContentType, consts.ApplicationJSON)
-
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		log.Error(consts.RequestBodyReadError, err)
-
-		respModel.Status = consts.Error
-		respModel.Message = consts.GetLecturersError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
-		return
-	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			log.Error(consts.RequestBodyCloseError, err)
-		}
-	}(r.Body)
-
-	err = json.Unmarshal(body, &newLecturer)
-	if err != nil {
-		log.Error(consts.JSONMarshalError, err)
-	}
-
-	lecturer1, err := handler.lecturer.CreateLecturer(&newLecturer)
-	if err != nil {
-		log.Error(consts.GetLecturersError, err)
-
-		respModel.Status = consts.Error
-		respModel.Message = consts.GetLecturersError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
-		return
-	}
-	w.WriteHeader(http.StatusOK)
-
-	respModel.Status = consts.Success
-	respModel.Data = *lecturer1
-	respModel.Message = consts.LecturerCreated
-
-	b, err := json.Marshal(respModel)
-	if err != nil {
-		log.Error(consts.JSONMarshalError, err)
-	}
-
-	_, err = w.Write(b)
-	if err != nil {
-		log.Error(consts.ResponseWriteError, err)
-	}
+// LecturerRoutes registers the lecturer routes, policy decides who may use them.
+// The courses of a lecturer are authorized as a list of courses
+func (handler *LecturerHandler) LecturerRoutes(r *mux.Router, policy *auth.Policy) {
+	handler.lecturer.Routes(r, middleware.Authorize(policy, "lecturer"))
+	handler.subRoutes(r, policy)
 }
 
-func (handler *LecturerHandler) updateLecturer(w http.ResponseWriter, r *http.Request) {
-	var respModel models.LecturerResponse
-	var updatedLecturer models.Lecturer
-
-	w.Header().Set(consts.ContentType, consts.ApplicationJSON)
-
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		log.Error(consts.RequestBodyReadError, err)
-
-		respModel.Status = consts.Error
-		respModel.Message = consts.GetLecturersError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
-		return
-	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			log.Error(consts.RequestBodyCloseError, err)
-		}
-	}(r.Body)
-
-	err = json.Unmarshal(body, &updatedLecturer)
-	if err != nil {
-		log.Error(consts.JSONMarshalError, err)
-	}
-
-	lecturer1, err := handler.lecturer.UpdateLecturer(&updatedLecturer)
-	if err != nil {
-		log.Error(consts.GetLecturersError, err)
-
-		respModel.Status = consts.Error
-		respModel.Message = consts.GetLecturersError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
-		return
-	}
-	w.WriteHeader(http.StatusOK)
-
-	respModel.Status = consts.Success
-	respModel.Data = *lecturer1
-	respModel.Message = consts.LecturerUpdated
-
-	b, err := json.Marshal(respModel)
-	if err != nil {
-		log.Error(consts.JSONMarshalError, err)
-	}
-
-	_, err = w.Write(b)
-	if err != nil {
-		log.Error(consts.ResponseWriteError, err)
-	}
+// V1Routes registers the lecturer routes of /api/v1/lecturers like
+// LecturerRoutes
+func (handler *LecturerHandler) V1Routes(r *mux.Router, policy *auth.Policy) {
+	handler.lecturer.V1Routes(r, middleware.Authorize(policy, "lecturer"))
+	handler.subRoutes(r, policy)
 }
 
-func (handler *LecturerHandler) deleteLecturer(w http.ResponseWriter, r *http.Request) {
-	var respModel models.LecturerResponse
-	w.Header().Set(consts.ContentType, consts.ApplicationJSON)
-
-	params := mux.Vars(r)
-	id, err := strconv.Atoi(params["id"])
-	if err != nil {
-		log.Error(consts.IDError, err)
-		respModel.Status = consts.Error
-		respModel.Message = consts.IDError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
-		return
-	}
-
-	lecturer, err := handler.lecturer.DeleteLecturer(id)
-	if err != nil {
-		log.Error(consts.GetLecturersError, err)
-
-		respModel.Status = consts.Error
-		respModel.Message = consts.LecturerDeleteError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
-		return
-	}
-	w.WriteHeader(http.StatusOK)
-
-	respModel.Status = consts.Success
-	respModel.Data = *lecturer
-	respModel.Message = consts.LecturerDeleted
-
-	b, err := json.Marshal(respModel)
-	if err != nil {
-		log.Error(consts.JSONMarshalError, err)
-	}
-
-	_, err = w.Write(b)
-	if err != nil {
-		log.Error(consts.ResponseWriteError, err)
-	}
+// subRoutes registers the routes that are the same in every version
+func (handler *LecturerHandler) subRoutes(r *mux.Router, policy *auth.Policy) {
+	authorizeCourse := middleware.Authorize(policy, "course")
+	r.Handle("/{id}/courses", authorizeCourse(auth.ActionList, handler.getCourses)).Methods("GET")
 }
 
-func (handler *LecturerHandler) searchLecturers(w http.ResponseWriter, r *http.Request) {
-	var respModel models.LecturerSearchResponse
-	var reqBody models.LecturerSearchRequest
+func (handler *LecturerHandler) getCourses(w http.ResponseWriter, r *http.Request) {
+	var respModel models.CourseListResponse
 
-	w.Header().Set(consts.ContentType, consts.ApplicationJSON)
-
-	body, err := io.ReadAll(r.Body)
+	id, err := crud.PathID(r)
 	if err != nil {
-		log.Error(consts.RequestBodyReadError, err)
-
 		respModel.Status = consts.Error
-		respModel.Message = consts.GetLecturersError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.IDError)
+		response.Write(w, response.Status(err), respModel)
 		return
 	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			log.Error(consts.RequestBodyCloseError, err)
-		}
-	}(r.Body)
-
-	err = json.Unmarshal(body, &reqBody)
-	if err != nil {
-		log.Error(consts.JSONMarshalError, err)
-	}
 
-	lecturers, err := handler.lecturer.SearchLecturer(reqBody.SearchString, reqBody.Pagination,
-		reqBody.SortBy)
+	list, err := handler.course.GetByLecturer(r.Context(), id)
 	if err != nil {
-		log.Error(consts.GetLecturersError, err)
+		log.Error(consts.GetCoursesError, err)
 
 		respModel.Status = consts.Error
-		respModel.Message = consts.GetLecturersError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.GetCoursesError)
+		response.Write(w, response.Status(err), respModel)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
 
 	respModel.Status = consts.Success
-	respModel.Data = *lecturers
-	respModel.Message = consts.GetLecturer
-
-	b, err := json.Marshal(respModel)
-	if err != nil {
-		log.Error(consts.JSONMarshalError, err)
-	}
-
-	_, err = w.Write(b)
-	if err != nil {
-		log.Error(consts.ResponseWriteError, err)
-	}
+	respModel.Data = list
+	respModel.Message = consts.GetCourse
+	response.Write(w, http.StatusOK, respModel)
 }
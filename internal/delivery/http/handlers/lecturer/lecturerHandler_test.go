@@ -6,6 +6,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
 	"github.com/shashaneRanasinghe/simpleAPI/mocks"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
 	"net/http/httptest"
 	"strings"
 	"testing"
@@ -17,24 +18,37 @@ var (
 		FirstName: "Charles",
 		LastName:  "Leclerc",
 		Year:      3,
+		Status:    "active",
 	}
 	lecturer1 = models.Lecturer{
 		ID:        1,
 		FirstName: "Charles",
 		LastName:  "Leclerc",
 		Year:      3,
+		Status:    "active",
 	}
 	lecturer2 = models.Lecturer{
 		ID:        2,
 		FirstName: "Carlos",
 		LastName:  "Sainz",
 		Year:      1,
+		Status:    "active",
+	}
+	course1 = models.Course{
+		ID:         1,
+		Code:       "AE101",
+		Title:      "Aerodynamics",
+		Credits:    3,
+		Semester:   "2023-S1",
+		LecturerID: 1,
 	}
 	errLecturer  = &models.Lecturer{}
 	lecturerList = []models.Lecturer{lecturer1, lecturer2}
 	ErrResponse  = errors.New("error Getting Lecturers")
 
-	expectedResponseError = `{"status":"Error","data":{"id":0,"firstname":"","lastname":"","year":0},"message":"Error Getting Lecturers "}`
+	expectedResponseError = `{"status":"Error","data":{"id":0,"firstname":"","lastname":"","year":0,"departmentId":null,"email":null,"phone":null,"dateOfBirth":null,"status":""},"message":"Error Getting Lecturers ","code":"INTERNAL_ERROR"}`
+	expectedCreateError   = `{"status":"Error","data":{"id":0,"firstname":"","lastname":"","year":0,"departmentId":null,"email":null,"phone":null,"dateOfBirth":null,"status":""},"message":"Error Creating Lecturer","code":"INTERNAL_ERROR"}`
+	expectedUpdateError   = `{"status":"Error","data":{"id":0,"firstname":"","lastname":"","year":0,"departmentId":null,"email":null,"phone":null,"dateOfBirth":null,"status":""},"message":"Error Updating Lecturer","code":"INTERNAL_ERROR"}`
 )
 
 func NewMockLecturerHandler_HappyPath(ctrl *gomock.Controller) *LecturerHandler {
@@ -45,17 +59,18 @@ func NewMockLecturerHandler_HappyPath(ctrl *gomock.Controller) *LecturerHandler
 		Data:          lecturerList,
 	}
 
-	lecturer.EXPECT().GetAllLecturers().Return(lecturerList, nil)
-	lecturer.EXPECT().GetLecturer(1).Return(&lecturer1, nil)
-	lecturer.EXPECT().CreateLecturer(&lecturer0).Return(&lecturer1, nil)
-	lecturer.EXPECT().UpdateLecturer(&lecturer1).Return(&lecturer1, nil)
-	lecturer.EXPECT().DeleteLecturer(1).Return(&lecturer1, nil)
-	lecturer.EXPECT().SearchLecturer("charl", models.Pagination{Page: 0, PageSize: 2},
+	lecturer.EXPECT().GetAll(gomock.Any(), nil).Return(lecturerList, nil)
+	lecturer.EXPECT().Get(gomock.Any(), 1).Return(&lecturer1, nil)
+	lecturer.EXPECT().Create(gomock.Any(), &lecturer0).Return(&lecturer1, nil)
+	lecturer.EXPECT().Update(gomock.Any(), &lecturer1).Return(&lecturer1, nil)
+	lecturer.EXPECT().Delete(gomock.Any(), 1).Return(&lecturer1, nil)
+	lecturer.EXPECT().Search(gomock.Any(), "charl", nil, models.Pagination{Page: 0, PageSize: 2},
 		models.SortBy{Column: "firstname", Direction: "ASC"}).Return(&data, nil)
 
-	return &LecturerHandler{
-		lecturer: lecturer,
-	}
+	course := mocks.NewMockCourseUsecase(ctrl)
+	course.EXPECT().GetByLecturer(gomock.Any(), 1).Return([]models.Course{course1}, nil)
+
+	return newLecturerHandler(lecturer, course)
 }
 
 func TestLecturerRoutes_HappyPath(t *testing.T) {
@@ -66,12 +81,7 @@ func TestLecturerRoutes_HappyPath(t *testing.T) {
 
 	lecturerHandler := NewMockLecturerHandler_HappyPath(ctrl)
 
-	r.HandleFunc("/", lecturerHandler.getAllLecturers).Methods("GET")
-	r.HandleFunc("/getLecturer/{id}", lecturerHandler.getLecturer).Methods("GET")
-	r.HandleFunc("/", lecturerHandler.createLecturer).Methods("POST")
-	r.HandleFunc("/", lecturerHandler.updateLecturer).Methods("PUT")
-	r.HandleFunc("/{id}", lecturerHandler.deleteLecturer).Methods("DELETE")
-	r.HandleFunc("/search", lecturerHandler.searchLecturers).Methods("GET") //lecturerHandler := NewLecturerHandler(database.NewDatabase().GetConnection())
+	lecturerHandler.LecturerRoutes(r, nil)
 
 	testCases := []struct {
 		name           string
@@ -87,7 +97,7 @@ func TestLecturerRoutes_HappyPath(t *testing.T) {
 			method:         "GET",
 			requestBody:    "",
 			expectedStatus: 200,
-			expectedBody:   `{"status":"Success","data":[{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3},{"id":2,"firstname":"Carlos","lastname":"Sainz","year":1}],"message":"Lecturer Queried Successfully"}`,
+			expectedBody:   `{"status":"Success","data":[{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3,"departmentId":null,"email":null,"phone":null,"dateOfBirth":null,"status":"active"},{"id":2,"firstname":"Carlos","lastname":"Sainz","year":1,"departmentId":null,"email":null,"phone":null,"dateOfBirth":null,"status":"active"}],"message":"Lecturer Queried Successfully"}`,
 		},
 		{
 			name:           "Get Specific Lecturers",
@@ -95,23 +105,23 @@ func TestLecturerRoutes_HappyPath(t *testing.T) {
 			method:         "GET",
 			requestBody:    "",
 			expectedStatus: 200,
-			expectedBody:   `{"status":"Success","data":{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3},"message":"Lecturer Queried Successfully"}`,
+			expectedBody:   `{"status":"Success","data":{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3,"departmentId":null,"email":null,"phone":null,"dateOfBirth":null,"status":"active"},"message":"Lecturer Queried Successfully"}`,
 		},
 		{
 			name:           "Create Lecturer",
 			url:            "/",
 			method:         "POST",
-			requestBody:    `{"firstname":"Charles","lastname":"Leclerc","year":3}`,
+			requestBody:    `{"firstname":"Charles","lastname":"Leclerc","year":3,"departmentId":null,"email":null,"phone":null,"dateOfBirth":null,"status":"active"}`,
 			expectedStatus: 200,
-			expectedBody:   `{"status":"Success","data":{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3},"message":"Lecturer Created Successfully"}`,
+			expectedBody:   `{"status":"Success","data":{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3,"departmentId":null,"email":null,"phone":null,"dateOfBirth":null,"status":"active"},"message":"Lecturer Created Successfully"}`,
 		},
 		{
 			name:           "Update Lecturer",
 			url:            "/",
 			method:         "PUT",
-			requestBody:    `{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3}`,
+			requestBody:    `{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3,"departmentId":null,"email":null,"phone":null,"dateOfBirth":null,"status":"active"}`,
 			expectedStatus: 200,
-			expectedBody:   `{"status":"Success","data":{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3},"message":"Lecturer Updated Successfully"}`,
+			expectedBody:   `{"status":"Success","data":{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3,"departmentId":null,"email":null,"phone":null,"dateOfBirth":null,"status":"active"},"message":"Lecturer Updated Successfully"}`,
 		},
 		{
 			name:           "Delete Specific Lecturer",
@@ -119,7 +129,7 @@ func TestLecturerRoutes_HappyPath(t *testing.T) {
 			method:         "DELETE",
 			requestBody:    "",
 			expectedStatus: 200,
-			expectedBody:   `{"status":"Success","data":{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3},"message":"Lecturer Deleted Successfully"}`,
+			expectedBody:   `{"status":"Success","data":{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3,"departmentId":null,"email":null,"phone":null,"dateOfBirth":null,"status":"active"},"message":"Lecturer Deleted Successfully"}`,
 		},
 		{
 			name:           "Search Lecturers",
@@ -127,7 +137,15 @@ func TestLecturerRoutes_HappyPath(t *testing.T) {
 			method:         "GET",
 			requestBody:    `{"searchString":"charl","sortBy": {"column":"firstname","direction":"ASC"},"pagination": {"page":0,"pageSize":2}}`,
 			expectedStatus: 200,
-			expectedBody:   `{"status":"Success","data":{"totalElements":2,"data":[{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3},{"id":2,"firstname":"Carlos","lastname":"Sainz","year":1}]},"message":"Lecturer Queried Successfully"}`,
+			expectedBody:   `{"status":"Success","data":{"totalElements":2,"data":[{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3,"departmentId":null,"email":null,"phone":null,"dateOfBirth":null,"status":"active"},{"id":2,"firstname":"Carlos","lastname":"Sainz","year":1,"departmentId":null,"email":null,"phone":null,"dateOfBirth":null,"status":"active"}]},"message":"Lecturer Queried Successfully"}`,
+		},
+		{
+			name:           "Get Lecturer Courses",
+			url:            "/1/courses",
+			method:         "GET",
+			requestBody:    "",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":[{"id":1,"code":"AE101","title":"Aerodynamics","credits":3,"semester":"2023-S1","lecturerId":1,"capacity":0,"minYear":0}],"message":"Course Queried Successfully"}`,
 		},
 	}
 
@@ -150,17 +168,19 @@ func TestLecturerRoutes_HappyPath(t *testing.T) {
 func NewMockLecturerHandler_ErrorPath(ctrl *gomock.Controller) *LecturerHandler {
 	lecturer := mocks.NewMockLecturerUsecase(ctrl)
 
-	lecturer.EXPECT().GetAllLecturers().Return(nil, ErrResponse)
-	lecturer.EXPECT().GetLecturer(1).Return(errLecturer, ErrResponse)
-	lecturer.EXPECT().CreateLecturer(&lecturer0).Return(errLecturer, ErrResponse)
-	lecturer.EXPECT().UpdateLecturer(&lecturer1).Return(errLecturer, ErrResponse)
-	lecturer.EXPECT().DeleteLecturer(1).Return(errLecturer, ErrResponse)
-	lecturer.EXPECT().SearchLecturer("charl", models.Pagination{Page: 0, PageSize: 2},
+	lecturer.EXPECT().GetAll(gomock.Any(), nil).Return(nil, ErrResponse)
+	lecturer.EXPECT().Get(gomock.Any(), 1).Return(errLecturer, ErrResponse)
+	lecturer.EXPECT().Create(gomock.Any(), &lecturer0).Return(errLecturer, ErrResponse)
+	lecturer.EXPECT().Update(gomock.Any(), &lecturer1).Return(errLecturer, ErrResponse)
+	lecturer.EXPECT().Delete(gomock.Any(), 1).Return(errLecturer, ErrResponse)
+	lecturer.EXPECT().Search(gomock.Any(), "charl", nil, models.Pagination{Page: 0, PageSize: 2},
 		models.SortBy{Column: "firstname", Direction: "ASC"}).Return(nil, ErrResponse)
 
-	return &LecturerHandler{
-		lecturer: lecturer,
-	}
+	course := mocks.NewMockCourseUsecase(ctrl)
+	course.EXPECT().GetByLecturer(gomock.Any(), 1).Return(nil, ErrResponse)
+	course.EXPECT().GetByLecturer(gomock.Any(), 2).Return(nil, apperrors.NotFound("lecturer Not Found"))
+
+	return newLecturerHandler(lecturer, course)
 }
 
 func TestLecturerRoutes_ErrorPath(t *testing.T) {
@@ -171,12 +191,7 @@ func TestLecturerRoutes_ErrorPath(t *testing.T) {
 
 	lecturerHandler := NewMockLecturerHandler_ErrorPath(ctrl)
 
-	r.HandleFunc("/", lecturerHandler.getAllLecturers).Methods("GET")
-	r.HandleFunc("/getLecturer/{id}", lecturerHandler.getLecturer).Methods("GET")
-	r.HandleFunc("/", lecturerHandler.createLecturer).Methods("POST")
-	r.HandleFunc("/", lecturerHandler.updateLecturer).Methods("PUT")
-	r.HandleFunc("/{id}", lecturerHandler.deleteLecturer).Methods("DELETE")
-	r.HandleFunc("/search", lecturerHandler.searchLecturers).Methods("GET") //lecturerHandler := NewLecturerHandler(database.NewDatabase().GetConnection())
+	lecturerHandler.LecturerRoutes(r, nil)
 
 	testCases := []struct {
 		name           string
@@ -192,7 +207,7 @@ func TestLecturerRoutes_ErrorPath(t *testing.T) {
 			method:         "GET",
 			requestBody:    "",
 			expectedStatus: 500,
-			expectedBody:   `{"status":"Error","data":null,"message":"Error Getting Lecturers "}`,
+			expectedBody:   `{"status":"Error","data":null,"message":"Error Getting Lecturers ","code":"INTERNAL_ERROR"}`,
 		},
 		{
 			name:           "Get Specific Lecturers",
@@ -206,17 +221,17 @@ func TestLecturerRoutes_ErrorPath(t *testing.T) {
 			name:           "Create Lecturer",
 			url:            "/",
 			method:         "POST",
-			requestBody:    `{"firstname":"Charles","lastname":"Leclerc","year":3}`,
+			requestBody:    `{"firstname":"Charles","lastname":"Leclerc","year":3,"departmentId":null,"email":null,"phone":null,"dateOfBirth":null,"status":"active"}`,
 			expectedStatus: 500,
-			expectedBody:   expectedResponseError,
+			expectedBody:   expectedCreateError,
 		},
 		{
 			name:           "Update Lecturer",
 			url:            "/",
 			method:         "PUT",
-			requestBody:    `{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3}`,
+			requestBody:    `{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3,"departmentId":null,"email":null,"phone":null,"dateOfBirth":null,"status":"active"}`,
 			expectedStatus: 500,
-			expectedBody:   expectedResponseError,
+			expectedBody:   expectedUpdateError,
 		},
 		{
 			name:           "Delete Specific Lecturer",
@@ -224,7 +239,7 @@ func TestLecturerRoutes_ErrorPath(t *testing.T) {
 			method:         "DELETE",
 			requestBody:    "",
 			expectedStatus: 500,
-			expectedBody:   `{"status":"Error","data":{"id":0,"firstname":"","lastname":"","year":0},"message":"Error Deleting Lecturer"}`,
+			expectedBody:   `{"status":"Error","data":{"id":0,"firstname":"","lastname":"","year":0,"departmentId":null,"email":null,"phone":null,"dateOfBirth":null,"status":""},"message":"Error Deleting Lecturer","code":"INTERNAL_ERROR"}`,
 		},
 		{
 			name:           "Search Lecturers",
@@ -232,7 +247,23 @@ func TestLecturerRoutes_ErrorPath(t *testing.T) {
 			method:         "GET",
 			requestBody:    `{"searchString":"charl","sortBy": {"column":"firstname","direction":"ASC"},"pagination": {"page":0,"pageSize":2}}`,
 			expectedStatus: 500,
-			expectedBody:   `{"status":"Error","data":{"totalElements":0,"data":null},"message":"Error Getting Lecturers "}`,
+			expectedBody:   `{"status":"Error","data":{"totalElements":0,"data":null},"message":"Error Getting Lecturers ","code":"INTERNAL_ERROR"}`,
+		},
+		{
+			name:           "Get Lecturer Courses",
+			url:            "/1/courses",
+			method:         "GET",
+			requestBody:    "",
+			expectedStatus: 500,
+			expectedBody:   `{"status":"Error","data":null,"message":"Error Getting Courses ","code":"INTERNAL_ERROR"}`,
+		},
+		{
+			name:           "Get Missing Lecturer Courses",
+			url:            "/2/courses",
+			method:         "GET",
+			requestBody:    "",
+			expectedStatus: 404,
+			expectedBody:   `{"status":"Error","data":null,"message":"lecturer Not Found","code":"NOT_FOUND"}`,
 		},
 	}
 
@@ -251,3 +282,34 @@ func TestLecturerRoutes_ErrorPath(t *testing.T) {
 		}
 	}
 }
+
+func TestLecturerRoutes_InvalidSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := mux.NewRouter()
+
+	lecturer := mocks.NewMockLecturerUsecase(ctrl)
+	lecturer.EXPECT().Search(gomock.Any(), "charl", nil, models.Pagination{Page: 0, PageSize: 2},
+		models.SortBy{Column: "password", Direction: "ASC"}).
+		Return(nil, apperrors.Validation(`Invalid Search Request : sort column "password" is not allowed`, nil))
+
+	lecturerHandler := newLecturerHandler(lecturer, mocks.NewMockCourseUsecase(ctrl))
+
+	lecturerHandler.LecturerRoutes(r, nil)
+
+	req := httptest.NewRequest("GET", "/search", strings.NewReader(`{"searchString":"charl","sortBy": {"column":"password","direction":"ASC"},"pagination": {"page":0,"pageSize":2}}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	expectedBody := `{"status":"Error","data":{"totalElements":0,"data":null},"message":"Invalid Search Request : sort column \"password\" is not allowed","code":"VALIDATION_ERROR"}`
+
+	if w.Code != 400 {
+		t.Errorf("Expected status code %d, but got %d", 400, w.Code)
+	}
+
+	if w.Body.String() != expectedBody {
+		t.Errorf("Expected response body %s, but got %s", expectedBody, w.Body.String())
+	}
+}
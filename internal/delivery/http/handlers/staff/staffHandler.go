@@ -1,18 +1,68 @@
 package staff
 
 import (
-	"database/sql"
-	"github.com/gorilla/mux"
 	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/crud"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/middleware"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/response"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	st "github.com/shashaneRanasinghe/simpleAPI/internal/usecases/staff"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
 )
 
-func StaffRoutes(r *mux.Router, db *sql.DB) {
-	r.HandleFunc("/", getAllStaff).Methods("GET")
+type StaffHandler struct {
+	staff   *crud.Handler[models.Staff]
+	usecase st.StaffUsecase
+}
+
+func NewStaffHandler(staffRepo repository.StaffRepository) *StaffHandler {
+	return newStaffHandler(st.NewStaff(staffRepo))
+}
+
+func newStaffHandler(staff st.StaffUsecase) *StaffHandler {
+	return &StaffHandler{
+		staff:   crud.NewHandler[models.Staff](staff, models.StaffResource),
+		usecase: staff,
+	}
 }
 
-func getAllStaff(w http.ResponseWriter, r *http.Request) {
-	_, err := w.Write([]byte("Staff : To be implemented"))
+// StaffRoutes registers the staff routes, policy decides who may use them.
+// Getting a staff member by the staff number is authorized as reading them
+func (handler *StaffHandler) StaffRoutes(r *mux.Router, policy *auth.Policy) {
+	authorize := middleware.Authorize(policy, "staff")
+	handler.staff.Routes(r, authorize)
+	r.Handle("/by-number/{number}", authorize(auth.ActionRead, handler.getByNumber)).Methods("GET")
+}
+
+// V1Routes registers the staff routes of /api/v1/staff like StaffRoutes
+func (handler *StaffHandler) V1Routes(r *mux.Router, policy *auth.Policy) {
+	authorize := middleware.Authorize(policy, "staff")
+	handler.staff.V1Routes(r, authorize)
+	r.Handle("/by-number/{number}", authorize(auth.ActionRead, handler.getByNumber)).Methods("GET")
+}
+
+func (handler *StaffHandler) getByNumber(w http.ResponseWriter, r *http.Request) {
+	var respModel models.StaffResponse
+
+	staff, err := handler.usecase.GetByNumber(r.Context(), mux.Vars(r)["number"])
 	if err != nil {
+		log.Error(consts.GetStaffError, err)
+
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.GetStaffError)
+		response.Write(w, response.Status(err), respModel)
 		return
 	}
+
+	respModel.Status = consts.Success
+	respModel.Data = *staff
+	respModel.Message = consts.GetStaff
+	response.Write(w, http.StatusOK, respModel)
 }
@@ -0,0 +1,329 @@
+package staff
+
+import (
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/mocks"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var (
+	staff0 = models.Staff{
+		ID:        0,
+		FirstName: "Charles",
+		LastName:  "Leclerc",
+		Position:  "Registrar",
+	}
+	staff1 = models.Staff{
+		ID:        1,
+		FirstName: "Charles",
+		LastName:  "Leclerc",
+		Position:  "Registrar",
+	}
+	staff2 = models.Staff{
+		ID:        2,
+		FirstName: "Carlos",
+		LastName:  "Sainz",
+		Position:  "Librarian",
+	}
+	errStaff    = &models.Staff{}
+	staffList   = []models.Staff{staff1, staff2}
+	ErrResponse = errors.New("error Getting Staff")
+
+	expectedResponseError = `{"status":"Error","data":{"id":0,"staffNumber":null,"firstname":"","lastname":"","position":"","departmentId":null},"message":"Error Getting Staff ","code":"INTERNAL_ERROR"}`
+	expectedCreateError   = `{"status":"Error","data":{"id":0,"staffNumber":null,"firstname":"","lastname":"","position":"","departmentId":null},"message":"Error Creating Staff","code":"INTERNAL_ERROR"}`
+	expectedUpdateError   = `{"status":"Error","data":{"id":0,"staffNumber":null,"firstname":"","lastname":"","position":"","departmentId":null},"message":"Error Updating Staff","code":"INTERNAL_ERROR"}`
+)
+
+func NewMockStaffHandler_HappyPath(ctrl *gomock.Controller) *StaffHandler {
+	staff := mocks.NewMockStaffUsecase(ctrl)
+
+	data := models.StaffSearchData{
+		TotalElements: 2,
+		Data:          staffList,
+	}
+
+	staff.EXPECT().GetAll(gomock.Any(), nil).Return(staffList, nil)
+	staff.EXPECT().Get(gomock.Any(), 1).Return(&staff1, nil)
+	staff.EXPECT().Create(gomock.Any(), &staff0).Return(&staff1, nil)
+	staff.EXPECT().Update(gomock.Any(), &staff1).Return(&staff1, nil)
+	staff.EXPECT().Delete(gomock.Any(), 1).Return(&staff1, nil)
+	staff.EXPECT().Search(gomock.Any(), "charl", nil, models.Pagination{Page: 0, PageSize: 2},
+		models.SortBy{Column: "firstname", Direction: "ASC"}).Return(&data, nil)
+
+	return newStaffHandler(staff)
+}
+
+func TestStaffRoutes_HappyPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := mux.NewRouter()
+
+	staffHandler := NewMockStaffHandler_HappyPath(ctrl)
+
+	staffHandler.StaffRoutes(r, nil)
+
+	testCases := []struct {
+		name           string
+		url            string
+		method         string
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Get All Staff",
+			url:            "/",
+			method:         "GET",
+			requestBody:    "",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":[{"id":1,"staffNumber":null,"firstname":"Charles","lastname":"Leclerc","position":"Registrar","departmentId":null},{"id":2,"staffNumber":null,"firstname":"Carlos","lastname":"Sainz","position":"Librarian","departmentId":null}],"message":"Staff Queried Successfully"}`,
+		},
+		{
+			name:           "Get Specific Staff",
+			url:            "/getStaff/1",
+			method:         "GET",
+			requestBody:    "",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":1,"staffNumber":null,"firstname":"Charles","lastname":"Leclerc","position":"Registrar","departmentId":null},"message":"Staff Queried Successfully"}`,
+		},
+		{
+			name:           "Create Staff",
+			url:            "/",
+			method:         "POST",
+			requestBody:    `{"firstname":"Charles","lastname":"Leclerc","position":"Registrar","departmentId":null}`,
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":1,"staffNumber":null,"firstname":"Charles","lastname":"Leclerc","position":"Registrar","departmentId":null},"message":"Staff Created Successfully"}`,
+		},
+		{
+			name:           "Update Staff",
+			url:            "/",
+			method:         "PUT",
+			requestBody:    `{"id":1,"firstname":"Charles","lastname":"Leclerc","position":"Registrar","departmentId":null}`,
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":1,"staffNumber":null,"firstname":"Charles","lastname":"Leclerc","position":"Registrar","departmentId":null},"message":"Staff Updated Successfully"}`,
+		},
+		{
+			name:           "Delete Specific Staff",
+			url:            "/1",
+			method:         "DELETE",
+			requestBody:    "",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":1,"staffNumber":null,"firstname":"Charles","lastname":"Leclerc","position":"Registrar","departmentId":null},"message":"Staff Deleted Successfully"}`,
+		},
+		{
+			name:           "Search Staff",
+			url:            "/search",
+			method:         "GET",
+			requestBody:    `{"searchString":"charl","sortBy": {"column":"firstname","direction":"ASC"},"pagination": {"page":0,"pageSize":2}}`,
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"totalElements":2,"data":[{"id":1,"staffNumber":null,"firstname":"Charles","lastname":"Leclerc","position":"Registrar","departmentId":null},{"id":2,"staffNumber":null,"firstname":"Carlos","lastname":"Sainz","position":"Librarian","departmentId":null}]},"message":"Staff Queried Successfully"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest(test.method, test.url, strings.NewReader(test.requestBody))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
+
+func NewMockStaffHandler_ErrorPath(ctrl *gomock.Controller) *StaffHandler {
+	staff := mocks.NewMockStaffUsecase(ctrl)
+
+	staff.EXPECT().GetAll(gomock.Any(), nil).Return(nil, ErrResponse)
+	staff.EXPECT().Get(gomock.Any(), 1).Return(errStaff, ErrResponse)
+	staff.EXPECT().Create(gomock.Any(), &staff0).Return(errStaff, ErrResponse)
+	staff.EXPECT().Update(gomock.Any(), &staff1).Return(errStaff, ErrResponse)
+	staff.EXPECT().Delete(gomock.Any(), 1).Return(errStaff, ErrResponse)
+	staff.EXPECT().Search(gomock.Any(), "charl", nil, models.Pagination{Page: 0, PageSize: 2},
+		models.SortBy{Column: "firstname", Direction: "ASC"}).Return(nil, ErrResponse)
+
+	return newStaffHandler(staff)
+}
+
+func TestStaffRoutes_ErrorPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := mux.NewRouter()
+
+	staffHandler := NewMockStaffHandler_ErrorPath(ctrl)
+
+	staffHandler.StaffRoutes(r, nil)
+
+	testCases := []struct {
+		name           string
+		url            string
+		method         string
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Get All Staff",
+			url:            "/",
+			method:         "GET",
+			requestBody:    "",
+			expectedStatus: 500,
+			expectedBody:   `{"status":"Error","data":null,"message":"Error Getting Staff ","code":"INTERNAL_ERROR"}`,
+		},
+		{
+			name:           "Get Specific Staff",
+			url:            "/getStaff/1",
+			method:         "GET",
+			requestBody:    "",
+			expectedStatus: 500,
+			expectedBody:   expectedResponseError,
+		},
+		{
+			name:           "Create Staff",
+			url:            "/",
+			method:         "POST",
+			requestBody:    `{"firstname":"Charles","lastname":"Leclerc","position":"Registrar","departmentId":null}`,
+			expectedStatus: 500,
+			expectedBody:   expectedCreateError,
+		},
+		{
+			name:           "Update Staff",
+			url:            "/",
+			method:         "PUT",
+			requestBody:    `{"id":1,"firstname":"Charles","lastname":"Leclerc","position":"Registrar","departmentId":null}`,
+			expectedStatus: 500,
+			expectedBody:   expectedUpdateError,
+		},
+		{
+			name:           "Delete Specific Staff",
+			url:            "/1",
+			method:         "DELETE",
+			requestBody:    "",
+			expectedStatus: 500,
+			expectedBody:   `{"status":"Error","data":{"id":0,"staffNumber":null,"firstname":"","lastname":"","position":"","departmentId":null},"message":"Error Deleting Staff","code":"INTERNAL_ERROR"}`,
+		},
+		{
+			name:           "Search Staff",
+			url:            "/search",
+			method:         "GET",
+			requestBody:    `{"searchString":"charl","sortBy": {"column":"firstname","direction":"ASC"},"pagination": {"page":0,"pageSize":2}}`,
+			expectedStatus: 500,
+			expectedBody:   `{"status":"Error","data":{"totalElements":0,"data":null},"message":"Error Getting Staff ","code":"INTERNAL_ERROR"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest(test.method, test.url, strings.NewReader(test.requestBody))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
+
+func TestStaffRoutes_InvalidSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := mux.NewRouter()
+
+	staff := mocks.NewMockStaffUsecase(ctrl)
+	staff.EXPECT().Search(gomock.Any(), "charl", nil, models.Pagination{Page: 0, PageSize: 2},
+		models.SortBy{Column: "password", Direction: "ASC"}).
+		Return(nil, apperrors.Validation(`Invalid Search Request : sort column "password" is not allowed`, nil))
+
+	staffHandler := newStaffHandler(staff)
+
+	staffHandler.StaffRoutes(r, nil)
+
+	req := httptest.NewRequest("GET", "/search", strings.NewReader(`{"searchString":"charl","sortBy": {"column":"password","direction":"ASC"},"pagination": {"page":0,"pageSize":2}}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	expectedBody := `{"status":"Error","data":{"totalElements":0,"data":null},"message":"Invalid Search Request : sort column \"password\" is not allowed","code":"VALIDATION_ERROR"}`
+
+	if w.Code != 400 {
+		t.Errorf("Expected status code %d, but got %d", 400, w.Code)
+	}
+
+	if w.Body.String() != expectedBody {
+		t.Errorf("Expected response body %s, but got %s", expectedBody, w.Body.String())
+	}
+}
+
+func TestStaffRoutes_GetByNumber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := mux.NewRouter()
+
+	number := "STF-2026-000001"
+	numbered := staff1
+	numbered.StaffNumber = &number
+
+	staff := mocks.NewMockStaffUsecase(ctrl)
+	staff.EXPECT().GetByNumber(gomock.Any(), "STF-2026-000001").Return(&numbered, nil)
+	staff.EXPECT().GetByNumber(gomock.Any(), "STF-2026-999999").
+		Return(errStaff, apperrors.NotFound(consts.StaffNotFound))
+
+	staffHandler := newStaffHandler(staff)
+
+	staffHandler.StaffRoutes(r, nil)
+
+	testCases := []struct {
+		name           string
+		url            string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Existing Number",
+			url:            "/by-number/STF-2026-000001",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":1,"staffNumber":"STF-2026-000001","firstname":"Charles","lastname":"Leclerc","position":"Registrar","departmentId":null},"message":"Staff Queried Successfully"}`,
+		},
+		{
+			name:           "Missing Number",
+			url:            "/by-number/STF-2026-999999",
+			expectedStatus: 404,
+			expectedBody:   `{"status":"Error","data":{"id":0,"staffNumber":null,"firstname":"","lastname":"","position":"","departmentId":null},"message":"staff Not Found","code":"NOT_FOUND"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest("GET", test.url, nil)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package student
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/crud"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/middleware"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/response"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+// enrolmentRoutes registers the enrolment routes of a student, the id in the
+// path is the student's
+func (handler *StudentHandler) enrolmentRoutes(r *mux.Router, authorize middleware.Authorizer) {
+	r.Handle("/{id}/enrolments", authorize(auth.ActionList, handler.getEnrolments)).Methods("GET")
+	r.Handle("/{id}/enrolments", authorize(auth.ActionCreate, handler.enrol)).Methods("POST")
+	r.Handle("/{id}/enrolments/{courseId}", authorize(auth.ActionDelete, handler.withdraw)).Methods("DELETE")
+}
+
+func (handler *StudentHandler) getEnrolments(w http.ResponseWriter, r *http.Request) {
+	var respModel models.EnrolmentListResponse
+
+	id, err := crud.PathID(r)
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.IDError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	list, err := handler.enrolment.GetByStudent(r.Context(), id)
+	if err != nil {
+		log.Error(consts.GetEnrolmentsError, err)
+
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.GetEnrolmentsError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	respModel.Status = consts.Success
+	respModel.Data = list
+	respModel.Message = consts.GetEnrolments
+	response.Write(w, http.StatusOK, respModel)
+}
+
+func (handler *StudentHandler) enrol(w http.ResponseWriter, r *http.Request) {
+	var respModel models.EnrolmentResponse
+	var reqBody models.EnrolmentRequest
+
+	id, err := crud.PathID(r)
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.IDError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	err = crud.ReadBody(r, &reqBody)
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.EnrolError)
+		respModel.Errors = apperrors.FieldsOf(err)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	enrolment, err := handler.enrolment.Enrol(r.Context(), id, reqBody.CourseID)
+	if err != nil {
+		log.Error(consts.EnrolError, err)
+
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.EnrolError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	respModel.Status = consts.Success
+	respModel.Data = *enrolment
+	respModel.Message = consts.StudentEnrolled
+	response.Write(w, http.StatusOK, respModel)
+}
+
+func (handler *StudentHandler) withdraw(w http.ResponseWriter, r *http.Request) {
+	var respModel models.EnrolmentResponse
+
+	id, err := crud.PathID(r)
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.IDError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	courseID, err := crud.PathInt(r, "courseId")
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.IDError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	enrolment, err := handler.enrolment.Withdraw(r.Context(), id, courseID)
+	if err != nil {
+		log.Error(consts.WithdrawError, err)
+
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.WithdrawError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	respModel.Status = consts.Success
+	respModel.Data = *enrolment
+	respModel.Message = consts.EnrolmentRemoved
+	response.Write(w, http.StatusOK, respModel)
+}
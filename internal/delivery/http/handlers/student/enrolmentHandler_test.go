@@ -0,0 +1,123 @@
+package student
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/mocks"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+)
+
+var enrolment1 = models.Enrolment{
+	StudentID:  1,
+	CourseID:   2,
+	EnrolledAt: time.Date(2023, 9, 1, 9, 0, 0, 0, time.UTC),
+}
+
+func TestEnrolmentRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	enrolment := mocks.NewMockEnrolmentUsecase(ctrl)
+	enrolment.EXPECT().GetByStudent(gomock.Any(), 1).Return([]models.Enrolment{enrolment1}, nil)
+	enrolment.EXPECT().Enrol(gomock.Any(), 1, 2).Return(&enrolment1, nil)
+	enrolment.EXPECT().Enrol(gomock.Any(), 1, 3).Return(nil, apperrors.Conflict(consts.CourseFullError, nil))
+	enrolment.EXPECT().Enrol(gomock.Any(), 1, 4).
+		Return(nil, apperrors.Unprocessable("The Course Is Open To Students In Year 3 Or Later"))
+	enrolment.EXPECT().Withdraw(gomock.Any(), 1, 2).Return(&enrolment1, nil)
+	enrolment.EXPECT().Withdraw(gomock.Any(), 1, 3).Return(nil, apperrors.NotFound(consts.EnrolmentNotFound))
+
+	r := mux.NewRouter()
+	newStudentHandler(mocks.NewMockStudentUsecase(ctrl), enrolment, mocks.NewMockGradeUsecase(ctrl)).StudentRoutes(r, nil)
+
+	testCases := []struct {
+		name           string
+		url            string
+		method         string
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Get Enrolments",
+			url:            "/1/enrolments",
+			method:         "GET",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":[{"studentId":1,"courseId":2,"enrolledAt":"2023-09-01T09:00:00Z"}],"message":"Enrolments Queried Successfully"}`,
+		},
+		{
+			name:           "Enrol",
+			url:            "/1/enrolments",
+			method:         "POST",
+			requestBody:    `{"courseId":2}`,
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"studentId":1,"courseId":2,"enrolledAt":"2023-09-01T09:00:00Z"},"message":"Student Enrolled Successfully"}`,
+		},
+		{
+			name:           "Enrol Without Course",
+			url:            "/1/enrolments",
+			method:         "POST",
+			requestBody:    `{}`,
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"studentId":0,"courseId":0,"enrolledAt":"0001-01-01T00:00:00Z"},"message":"Invalid Request Body","code":"VALIDATION_ERROR","errors":[{"field":"courseId","message":"must be at least 1"}]}`,
+		},
+		{
+			name:           "Enrol In Full Course",
+			url:            "/1/enrolments",
+			method:         "POST",
+			requestBody:    `{"courseId":3}`,
+			expectedStatus: 409,
+			expectedBody:   `{"status":"Error","data":{"studentId":0,"courseId":0,"enrolledAt":"0001-01-01T00:00:00Z"},"message":"The Course Is Full","code":"CONFLICT"}`,
+		},
+		{
+			name:           "Enrol Below The Year",
+			url:            "/1/enrolments",
+			method:         "POST",
+			requestBody:    `{"courseId":4}`,
+			expectedStatus: 422,
+			expectedBody:   `{"status":"Error","data":{"studentId":0,"courseId":0,"enrolledAt":"0001-01-01T00:00:00Z"},"message":"The Course Is Open To Students In Year 3 Or Later","code":"UNPROCESSABLE"}`,
+		},
+		{
+			name:           "Withdraw",
+			url:            "/1/enrolments/2",
+			method:         "DELETE",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"studentId":1,"courseId":2,"enrolledAt":"2023-09-01T09:00:00Z"},"message":"Enrolment Removed Successfully"}`,
+		},
+		{
+			name:           "Withdraw Missing",
+			url:            "/1/enrolments/3",
+			method:         "DELETE",
+			expectedStatus: 404,
+			expectedBody:   `{"status":"Error","data":{"studentId":0,"courseId":0,"enrolledAt":"0001-01-01T00:00:00Z"},"message":"enrolment Not Found","code":"NOT_FOUND"}`,
+		},
+		{
+			name:           "Withdraw Bad Course ID",
+			url:            "/1/enrolments/abc",
+			method:         "DELETE",
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"studentId":0,"courseId":0,"enrolledAt":"0001-01-01T00:00:00Z"},"message":"Error Getting The ID","code":"VALIDATION_ERROR"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest(test.method, test.url, strings.NewReader(test.requestBody))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package student
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/crud"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/middleware"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/response"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+// gradeRoutes registers the grade and transcript routes of a student, the id
+// in the path is the student's. The transcript is authorized as reading the
+// grades. A grade is recorded by the lecturer of the caller, an admin may
+// name the lecturer with the lecturerId query parameter
+func (handler *StudentHandler) gradeRoutes(r *mux.Router, authorize middleware.Authorizer) {
+	r.Handle("/{id}/grades", authorize(auth.ActionList, handler.getGrades)).Methods("GET")
+	r.Handle("/{id}/grades", authorize(auth.ActionCreate, handler.recordGrade)).Methods("POST")
+	r.Handle("/{id}/grades/{courseId}", authorize(auth.ActionUpdate, handler.updateGrade)).Methods("PUT")
+	r.Handle("/{id}/transcript", authorize(auth.ActionRead, handler.getTranscript)).Methods("GET")
+}
+
+func (handler *StudentHandler) getGrades(w http.ResponseWriter, r *http.Request) {
+	var respModel models.GradeListResponse
+
+	id, err := crud.PathID(r)
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.IDError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	list, err := handler.grade.GetByStudent(r.Context(), id)
+	if err != nil {
+		log.Error(consts.GetGradesError, err)
+
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.GetGradesError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	respModel.Status = consts.Success
+	respModel.Data = list
+	respModel.Message = consts.GetGrades
+	response.Write(w, http.StatusOK, respModel)
+}
+
+func (handler *StudentHandler) recordGrade(w http.ResponseWriter, r *http.Request) {
+	var respModel models.GradeResponse
+	var reqBody models.GradeRequest
+
+	id, err := crud.PathID(r)
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.IDError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	err = crud.ReadBody(r, &reqBody)
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.GradeRecordError)
+		respModel.Errors = apperrors.FieldsOf(err)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	lecturerID, err := namedLecturer(r)
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.GradeRecordError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	grade, err := handler.grade.Record(r.Context(), id, &reqBody, lecturerID)
+	if err != nil {
+		log.Error(consts.GradeRecordError, err)
+
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.GradeRecordError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	respModel.Status = consts.Success
+	respModel.Data = *grade
+	respModel.Message = consts.GradeRecorded
+	response.Write(w, http.StatusOK, respModel)
+}
+
+func (handler *StudentHandler) updateGrade(w http.ResponseWriter, r *http.Request) {
+	var respModel models.GradeResponse
+	var reqBody models.GradeChange
+
+	id, err := crud.PathID(r)
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.IDError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	courseID, err := crud.PathInt(r, "courseId")
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.IDError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	err = crud.ReadBody(r, &reqBody)
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.GradeRecordError)
+		respModel.Errors = apperrors.FieldsOf(err)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	lecturerID, err := namedLecturer(r)
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.GradeRecordError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	grade, err := handler.grade.Update(r.Context(), id, courseID, &reqBody, lecturerID)
+	if err != nil {
+		log.Error(consts.GradeRecordError, err)
+
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.GradeRecordError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	respModel.Status = consts.Success
+	respModel.Data = *grade
+	respModel.Message = consts.GradeUpdated
+	response.Write(w, http.StatusOK, respModel)
+}
+
+// namedLecturer returns the lecturer named by the lecturerId query parameter,
+// nil when there is none
+func namedLecturer(r *http.Request) (*int, error) {
+	value := r.URL.Query().Get("lecturerId")
+	if value == "" {
+		return nil, nil
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, apperrors.Validation(consts.LecturerLookupError, err)
+	}
+	return &id, nil
+}
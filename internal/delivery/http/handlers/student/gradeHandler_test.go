@@ -0,0 +1,291 @@
+package student
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/mocks"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+)
+
+var grade1 = models.Grade{
+	StudentID:  1,
+	CourseID:   2,
+	LecturerID: 5,
+	Grade:      "A-",
+	RecordedAt: time.Date(2024, 1, 20, 9, 0, 0, 0, time.UTC),
+}
+
+var transcript1 = models.Transcript{
+	Student: models.Student{ID: 1, FirstName: "Charles", LastName: "Leclerc", Year: 3,
+		Status: "active"},
+	Semesters: []models.SemesterResult{
+		{
+			Semester: "2023-S1",
+			Courses: []models.TranscriptLine{
+				{CourseID: 2, Code: "AE101", Title: "Aerodynamics", Semester: "2023-S1", Credits: 3, Grade: "A-",
+					Points: 3.7},
+			},
+			Credits: 3,
+			GPA:     3.7,
+		},
+	},
+	Credits: 3,
+	GPA:     3.7,
+}
+
+func newGradeRouter(ctrl *gomock.Controller, grade *mocks.MockGradeUsecase) *mux.Router {
+	r := mux.NewRouter()
+	newStudentHandler(mocks.NewMockStudentUsecase(ctrl), mocks.NewMockEnrolmentUsecase(ctrl), grade).
+		StudentRoutes(r, nil)
+	return r
+}
+
+func TestGradeRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	grade := mocks.NewMockGradeUsecase(ctrl)
+	grade.EXPECT().GetByStudent(gomock.Any(), 1).Return([]models.Grade{grade1}, nil)
+	five := 5
+	grade.EXPECT().Record(gomock.Any(), 1, &models.GradeRequest{CourseID: 2,
+		GradeChange: models.GradeChange{Grade: "A-"}}, nil).Return(&grade1, nil)
+	grade.EXPECT().Record(gomock.Any(), 1, &models.GradeRequest{CourseID: 2,
+		GradeChange: models.GradeChange{Grade: "A-"}}, &five).Return(&grade1, nil)
+	grade.EXPECT().Record(gomock.Any(), 1, &models.GradeRequest{CourseID: 3,
+		GradeChange: models.GradeChange{Grade: "B"}}, nil).
+		Return(nil, apperrors.Unprocessable(consts.NotEnrolledError))
+	grade.EXPECT().Update(gomock.Any(), 1, 2, &models.GradeChange{Grade: "A-"}, nil).Return(&grade1, nil)
+	grade.EXPECT().Update(gomock.Any(), 1, 3, &models.GradeChange{Grade: "A-"}, nil).
+		Return(nil, apperrors.NotFound(consts.GradeNotFound))
+	grade.EXPECT().Transcript(gomock.Any(), 1).Return(&transcript1, nil)
+	grade.EXPECT().Transcript(gomock.Any(), 2).Return(nil, apperrors.NotFound(consts.StudentNotFound))
+
+	r := newGradeRouter(ctrl, grade)
+
+	testCases := []struct {
+		name           string
+		url            string
+		method         string
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Get Grades",
+			url:            "/1/grades",
+			method:         "GET",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":[{"studentId":1,"courseId":2,"lecturerId":5,"grade":"A-","recordedAt":"2024-01-20T09:00:00Z"}],"message":"Grades Queried Successfully"}`,
+		},
+		{
+			name:           "Record Grade",
+			url:            "/1/grades",
+			method:         "POST",
+			requestBody:    `{"courseId":2,"grade":"A-"}`,
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"studentId":1,"courseId":2,"lecturerId":5,"grade":"A-","recordedAt":"2024-01-20T09:00:00Z"},"message":"Grade Recorded Successfully"}`,
+		},
+		{
+			name:           "Record Grade For A Named Lecturer",
+			url:            "/1/grades?lecturerId=5",
+			method:         "POST",
+			requestBody:    `{"courseId":2,"grade":"A-"}`,
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"studentId":1,"courseId":2,"lecturerId":5,"grade":"A-","recordedAt":"2024-01-20T09:00:00Z"},"message":"Grade Recorded Successfully"}`,
+		},
+		{
+			name:           "Record Grade With Lecturer In Body",
+			url:            "/1/grades",
+			method:         "POST",
+			requestBody:    `{"courseId":2,"lecturerId":5,"grade":"A-"}`,
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"studentId":0,"courseId":0,"lecturerId":0,"grade":"","recordedAt":"0001-01-01T00:00:00Z"},"message":"Invalid Request Body","code":"VALIDATION_ERROR","errors":[{"field":"lecturerId","message":"is not allowed"}]}`,
+		},
+		{
+			name:           "Record Grade For A Lecturer That Is Not A Number",
+			url:            "/1/grades?lecturerId=five",
+			method:         "POST",
+			requestBody:    `{"courseId":2,"grade":"A-"}`,
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"studentId":0,"courseId":0,"lecturerId":0,"grade":"","recordedAt":"0001-01-01T00:00:00Z"},"message":"lecturerId Must Be A Number","code":"VALIDATION_ERROR"}`,
+		},
+		{
+			name:           "Record Unknown Grade",
+			url:            "/1/grades",
+			method:         "POST",
+			requestBody:    `{"courseId":2,"grade":"E"}`,
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"studentId":0,"courseId":0,"lecturerId":0,"grade":"","recordedAt":"0001-01-01T00:00:00Z"},"message":"Invalid Request Body","code":"VALIDATION_ERROR","errors":[{"field":"grade","message":"must be one of A+, A, A-, B+, B, B-, C+, C, C-, D+, D, F"}]}`,
+		},
+		{
+			name:           "Record Grade Not Enrolled",
+			url:            "/1/grades",
+			method:         "POST",
+			requestBody:    `{"courseId":3,"grade":"B"}`,
+			expectedStatus: 422,
+			expectedBody:   `{"status":"Error","data":{"studentId":0,"courseId":0,"lecturerId":0,"grade":"","recordedAt":"0001-01-01T00:00:00Z"},"message":"The Student Is Not Enrolled In The Course","code":"UNPROCESSABLE"}`,
+		},
+		{
+			name:           "Update Grade",
+			url:            "/1/grades/2",
+			method:         "PUT",
+			requestBody:    `{"grade":"A-"}`,
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"studentId":1,"courseId":2,"lecturerId":5,"grade":"A-","recordedAt":"2024-01-20T09:00:00Z"},"message":"Grade Updated Successfully"}`,
+		},
+		{
+			name:           "Update Missing Grade",
+			url:            "/1/grades/3",
+			method:         "PUT",
+			requestBody:    `{"grade":"A-"}`,
+			expectedStatus: 404,
+			expectedBody:   `{"status":"Error","data":{"studentId":0,"courseId":0,"lecturerId":0,"grade":"","recordedAt":"0001-01-01T00:00:00Z"},"message":"grade Not Found","code":"NOT_FOUND"}`,
+		},
+		{
+			name:           "Update Grade With Course In Body",
+			url:            "/1/grades/2",
+			method:         "PUT",
+			requestBody:    `{"courseId":2,"grade":"A-"}`,
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"studentId":0,"courseId":0,"lecturerId":0,"grade":"","recordedAt":"0001-01-01T00:00:00Z"},"message":"Invalid Request Body","code":"VALIDATION_ERROR","errors":[{"field":"courseId","message":"is not allowed"}]}`,
+		},
+		{
+			name:           "Get Transcript",
+			url:            "/1/transcript",
+			method:         "GET",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"student":{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"semesters":[{"semester":"2023-S1","courses":[{"courseId":2,"code":"AE101","title":"Aerodynamics","semester":"2023-S1","credits":3,"grade":"A-","points":3.7}],"credits":3,"gpa":3.7}],"credits":3,"gpa":3.7},"message":"Transcript Queried Successfully"}`,
+		},
+		{
+			name:           "Get Transcript Of Missing Student",
+			url:            "/2/transcript",
+			method:         "GET",
+			expectedStatus: 404,
+			expectedBody:   `{"status":"Error","data":{"student":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"semesters":null,"credits":0,"gpa":0},"message":"student Not Found","code":"NOT_FOUND"}`,
+		},
+		{
+			name:           "Get Transcript In Unknown Format",
+			url:            "/1/transcript?format=docx",
+			method:         "GET",
+			expectedStatus: 400,
+			expectedBody:   `{"status":"Error","data":{"student":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"semesters":null,"credits":0,"gpa":0},"message":"Unknown Transcript Format, It Must Be One Of json, text or pdf","code":"VALIDATION_ERROR"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest(test.method, test.url, strings.NewReader(test.requestBody))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
+
+func TestTranscriptDownloads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	grade := mocks.NewMockGradeUsecase(ctrl)
+	grade.EXPECT().Transcript(gomock.Any(), 1).Return(&transcript1, nil).Times(4)
+
+	r := newGradeRouter(ctrl, grade)
+
+	testCases := []struct {
+		name                string
+		url                 string
+		accept              string
+		expectedType        string
+		expectedDisposition string
+		expectedPrefix      string
+	}{
+		{
+			name:                "Text By Query",
+			url:                 "/1/transcript?format=text",
+			expectedType:        "text/plain; charset=utf-8",
+			expectedDisposition: `attachment; filename="transcript-1.txt"`,
+			expectedPrefix:      "Academic Transcript\n",
+		},
+		{
+			name:                "Text By Accept",
+			url:                 "/1/transcript",
+			accept:              "text/plain",
+			expectedType:        "text/plain; charset=utf-8",
+			expectedDisposition: `attachment; filename="transcript-1.txt"`,
+			expectedPrefix:      "Academic Transcript\n",
+		},
+		{
+			name:                "PDF By Query",
+			url:                 "/1/transcript?format=pdf",
+			expectedType:        "application/pdf",
+			expectedDisposition: `attachment; filename="transcript-1.pdf"`,
+			expectedPrefix:      "%PDF-1.4\n",
+		},
+		{
+			name:                "Query Before Accept",
+			url:                 "/1/transcript?format=pdf",
+			accept:              "text/plain",
+			expectedType:        "application/pdf",
+			expectedDisposition: `attachment; filename="transcript-1.pdf"`,
+			expectedPrefix:      "%PDF-1.4\n",
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest("GET", test.url, nil)
+		req.Header.Set("Accept", test.accept)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != 200 {
+			t.Errorf("Test %s : Expected status code 200, but got %d", test.name, w.Code)
+		}
+		if w.Header().Get("Content-Type") != test.expectedType {
+			t.Errorf("Test %s : Expected content type %s, but got %s", test.name, test.expectedType,
+				w.Header().Get("Content-Type"))
+		}
+		if w.Header().Get("Content-Disposition") != test.expectedDisposition {
+			t.Errorf("Test %s : Expected content disposition %s, but got %s", test.name, test.expectedDisposition,
+				w.Header().Get("Content-Disposition"))
+		}
+		if !strings.HasPrefix(w.Body.String(), test.expectedPrefix) {
+			t.Errorf("Test %s : Expected the body to start with %q, but got %q", test.name, test.expectedPrefix,
+				w.Body.String())
+		}
+	}
+}
+
+func TestTranscriptLines(t *testing.T) {
+	expected := []string{
+		"Academic Transcript",
+		"",
+		"Student : Charles Leclerc (1)",
+		"Year    : 3",
+		"",
+		"Semester 2023-S1",
+		"  Code       Title                                Credits Grade Points",
+		"  AE101      Aerodynamics                               3    A-   3.70",
+		"  Credits 3, GPA 3.70",
+		"",
+		"Total credits 3, GPA 3.70",
+	}
+
+	lines := transcriptLines(&transcript1)
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected the lines\n%s\nbut got\n%s", strings.Join(expected, "\n"), strings.Join(lines, "\n"))
+	}
+}
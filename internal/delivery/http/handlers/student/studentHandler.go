@@ -1,419 +1,94 @@
 package student
 
 import (
-	"database/sql"
-	"encoding/json"
+	"net/http"
+
 	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/crud"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/middleware"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/response"
 	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
 	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	enr "github.com/shashaneRanasinghe/simpleAPI/internal/usecases/enrolment"
+	gr "github.com/shashaneRanasinghe/simpleAPI/internal/usecases/grade"
 	st "github.com/shashaneRanasinghe/simpleAPI/internal/usecases/student"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
 	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
 	"github.com/tryfix/log"
-	"io"
-	"net/http"
-	"strconv"
 )
 
 type StudentHandler struct {
-	student st.StudentUsecase
-}
-
-func NewStudentHandler(db *sql.DB) *StudentHandler {
-	studentRepo := repository.NewStudentRepository(db)
-	student := st.NewStudent(studentRepo)
-	return &StudentHandler{
-		student: student,
-	}
+	student   *crud.Handler[models.Student]
+	usecase   st.StudentUsecase
+	enrolment enr.EnrolmentUsecase
+	grade     gr.GradeUsecase
 }
 
-func (handler *StudentHandler) StudentRoutes(r *mux.Router) {
-
-	r.HandleFunc("/", handler.getAllStudents).Methods("GET")
-	r.HandleFunc("/getStudent/{id}", handler.getStudent).Methods("GET")
-	r.HandleFunc("/", handler.createStudent).Methods("POST")
-	r.HandleFunc("/", handler.updateStudent).Methods("PUT")
-	r.HandleFunc("/{id}", handler.deleteStudent).Methods("DELETE")
-	r.HandleFunc("/search", handler.searchStudents).Methods("GET")
-
+func NewStudentHandler(studentRepo repository.StudentRepository, enrolmentRepo repository.EnrolmentRepository,
+	gradeRepo repository.GradeRepository) *StudentHandler {
+	return newStudentHandler(st.NewStudent(studentRepo), enr.NewEnrolment(enrolmentRepo, studentRepo),
+		gr.NewGrade(gradeRepo, studentRepo))
 }
 
-func (handler *StudentHandler) getAllStudents(w http.ResponseWriter, _ *http.Request) {
-	var respModel models.StudentListResponse
-
-	w.Header().Set(consts.ContentType, consts.ApplicationJSON)
-
-	students, err := handler.student.GetAllStudents()
-	if err != nil {
-		log.Error(consts.GetStudentsError, err)
-
-		respModel.Status = consts.Error
-		respModel.Message = consts.GetStudentsError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
-		return
-	}
-	w.WriteHeader(http.StatusOK)
-
-	respModel.Status = consts.Success
-	respModel.Data = students
-	respModel.Message = consts.GetStudent
-
-	b, err := json.Marshal(respModel)
-	if err != nil {
-		log.Error(consts.JSONMarshalError, err)
-	}
-
-	_, err = w.Write(b)
-	if err != nil {
-		log.Error(consts.ResponseWriteError, err)
+func newStudentHandler(student st.StudentUsecase, enrolment enr.EnrolmentUsecase,
+	grade gr.GradeUsecase) *StudentHandler {
+	return &StudentHandler{
+		student:   crud.NewSoftDeleteHandler[models.Student](student, models.StudentResource),
+		usecase:   student,
+		enrolment: enrolment,
+		grade:     grade,
 	}
 }
 
-func (handler *StudentHandler) getStudent(w http.ResponseWriter, r *http.Request) {
-	var respModel models.StudentResponse
-	w.Header().Set(consts.ContentType, consts.ApplicationJSON)
-
-	params := mux.Vars(r)
-	id, err := strconv.Atoi(params["id"])
-	if err != nil {
-		log.Error(consts.IDError, err)
-		respModel.Status = consts.Error
-		respModel.Message = consts.GetStudentsError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
-		return
-	}
-
-	student, err := handler.student.GetStudent(id)
-	if err != nil {
-		log.Error(consts.GetStudentsError, err)
-
-		respModel.Status = consts.Error
-		respModel.Message = consts.GetStudentsError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
-		return
-	}
-	w.WriteHeader(http.StatusOK)
-
-	respModel.Status = consts.Success
-	respModel.Data = *student
-	respModel.Message = consts.GetStudent
-
-	b, err := json.Marshal(respModel)
-	if err != nil {
-		log.Error(consts.JSONMarshalError, err)
-	}
-
-	_, err = w.Write(b)
-	if err != nil {
-		log.Error(consts.ResponseWriteError, err)
-	}
+// StudentRoutes registers the student routes, policy decides who may use
+// them. Getting a student by the registration number is authorized as reading
+// the student
+func (handler *StudentHandler) StudentRoutes(r *mux.Router, policy *auth.Policy) {
+	authorize := middleware.Authorize(policy, "student")
+	handler.student.Routes(r, authorize)
+	handler.subRoutes(r, policy)
 }
 
-func (handler *StudentHandler) createStudent(w http.ResponseWriter, r *http.Request) {
-	var respModel models.StudentResponse
-	var newStudent models.Student
-
-	w.Header().Set(consts.ContentType, consts.ApplicationJSON)
-
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		log.Error(consts.RequestBodyReadError, err)
-
-		respModel.Status = consts.Error
-		respModel.Message = consts.GetStudentsError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
-		return
-	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			log.Error(consts.RequestBodyCloseError, err)
-		}
-	}(r.Body)
-
-	err = json.Unmarshal(body, &newStudent)
-	if err != nil {
-		log.Error(consts.JSONMarshalError, err)
-	}
-
-	student1, err := handler.student.CreateStudent(&newStudent)
-	if err != nil {
-		log.Error(consts.GetStudentsError, err)
-
-		respModel.Status = consts.Error
-		respModel.Message = consts.GetStudentsError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
-		return
-	}
-	w.WriteHeader(http.StatusOK)
-
-	respModel.Status = consts.Success
-	respModel.Data = *student1
-	respModel.Message = consts.StudentCreated
-
-	b, err := json.Marshal(respModel)
-	if err != nil {
-		log.Error(consts.JSONMarshalError, err)
-	}
-
-	_, err = w.Write(b)
-	if err != nil {
-		log.Error(consts.ResponseWriteError, err)
-	}
+// V1Routes registers the student routes of /api/v1/students like
+// StudentRoutes
+func (handler *StudentHandler) V1Routes(r *mux.Router, policy *auth.Policy) {
+	handler.student.V1Routes(r, middleware.Authorize(policy, "student"))
+	handler.subRoutes(r, policy)
 }
 
-func (handler *StudentHandler) updateStudent(w http.ResponseWriter, r *http.Request) {
-	var respModel models.StudentResponse
-	var updatedStudent models.Student
-
-	w.Header().Set(consts.ContentType, consts.ApplicationJSON)
-
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		log.Error(consts.RequestBodyReadError, err)
-
-		respModel.Status = consts.Error
-		respModel.Message = consts.GetStudentsError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
-		return
-	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			log.Error(consts.RequestBodyCloseError, err)
-		}
-	}(r.Body)
-
-	err = json.Unmarshal(body, &updatedStudent)
-	if err != nil {
-		log.Error(consts.JSONMarshalError, err)
-	}
-
-	student1, err := handler.student.UpdateStudent(&updatedStudent)
-	if err != nil {
-		log.Error(consts.GetStudentsError, err)
-
-		respModel.Status = consts.Error
-		respModel.Message = consts.GetStudentsError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
-		return
-	}
-	w.WriteHeader(http.StatusOK)
-
-	respModel.Status = consts.Success
-	respModel.Data = *student1
-	respModel.Message = consts.StudentUpdated
-
-	b, err := json.Marshal(respModel)
-	if err != nil {
-		log.Error(consts.JSONMarshalError, err)
-	}
-
-	_, err = w.Write(b)
-	if err != nil {
-		log.Error(consts.ResponseWriteError, err)
-	}
+// subRoutes registers the routes that are the same in every version, the
+// enrolments, grades and transcript of a student and getting a student by
+// the registration number
+func (handler *StudentHandler) subRoutes(r *mux.Router, policy *auth.Policy) {
+	authorize := middleware.Authorize(policy, "student")
+	r.Handle("/by-number/{number}", authorize(auth.ActionRead, handler.getByNumber)).Methods("GET")
+	handler.enrolmentRoutes(r, middleware.Authorize(policy, "enrolment"))
+	handler.gradeRoutes(r, middleware.Authorize(policy, "grade"))
 }
 
-func (handler *StudentHandler) deleteStudent(w http.ResponseWriter, r *http.Request) {
+func (handler *StudentHandler) getByNumber(w http.ResponseWriter, r *http.Request) {
 	var respModel models.StudentResponse
-	w.Header().Set(consts.ContentType, consts.ApplicationJSON)
-
-	params := mux.Vars(r)
-	id, err := strconv.Atoi(params["id"])
-	if err != nil {
-		log.Error(consts.IDError, err)
-		respModel.Status = consts.Error
-		respModel.Message = consts.IDError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
-		return
-	}
 
-	student, err := handler.student.DeleteStudent(id)
+	student, err := handler.usecase.GetByNumber(r.Context(), mux.Vars(r)["number"])
 	if err != nil {
 		log.Error(consts.GetStudentsError, err)
 
 		respModel.Status = consts.Error
-		respModel.Message = consts.StudentDeleteError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
-		return
-	}
-	w.WriteHeader(http.StatusOK)
-
-	respModel.Status = consts.Success
-	respModel.Data = *student
-	respModel.Message = consts.StudentDeleted
-
-	b, err := json.Marshal(respModel)
-	if err != nil {
-		log.Error(consts.JSONMarshalError, err)
-	}
-
-	_, err = w.Write(b)
-	if err != nil {
-		log.Error(consts.ResponseWriteError, err)
-	}
-}
-
-func (handler *StudentHandler) searchStudents(w http.ResponseWriter, r *http.Request) {
-	var respModel models.StudentSearchResponse
-	var reqBody models.StudentSearchRequest
-
-	w.Header().Set(consts.ContentType, consts.ApplicationJSON)
-
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		log.Error(consts.RequestBodyReadError, err)
-
-		respModel.Status = consts.Error
-		respModel.Message = consts.GetStudentsError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.GetStudentsError)
+		response.Write(w, response.Status(err), respModel)
 		return
 	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			log.Error(consts.RequestBodyCloseError, err)
-		}
-	}(r.Body)
-
-	err = json.Unmarshal(body, &reqBody)
-	if err != nil {
-		log.Error(consts.JSONMarshalError, err)
-	}
-
-	students, err := handler.student.SearchStudent(reqBody.SearchString, reqBody.Pagination,
-		reqBody.SortBy)
-	if err != nil {
-		log.Error(consts.GetStudentsError, err)
 
-		respModel.Status = consts.Error
-		respModel.Message = consts.GetStudentsError
-
-		w.WriteHeader(http.StatusInternalServerError)
-		b, err := json.Marshal(respModel)
-		if err != nil {
-			log.Error(consts.JSONMarshalError, err)
-		}
-
-		_, err = w.Write(b)
-		if err != nil {
-			log.Error(consts.ResponseWriteError, err)
-		}
+	crud.SetETag(w, *student)
+	if crud.NotModified(r, *student) {
+		w.WriteHeader(http.StatusNotModified)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
 
 	respModel.Status = consts.Success
-	respModel.Data = *students
+	respModel.Data = *student
 	respModel.Message = consts.GetStudent
-
-	b, err := json.Marshal(respModel)
-	if err != nil {
-		log.Error(consts.JSONMarshalError, err)
-	}
-
-	_, err = w.Write(b)
-	if err != nil {
-		log.Error(consts.ResponseWriteError, err)
-	}
+	response.Write(w, http.StatusOK, respModel)
 }
@@ -6,6 +6,8 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
 	"github.com/shashaneRanasinghe/simpleAPI/mocks"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
 	"net/http/httptest"
 	"strings"
 	"testing"
@@ -17,24 +19,29 @@ var (
 		FirstName: "Charles",
 		LastName:  "Leclerc",
 		Year:      3,
+		Status:    "active",
 	}
 	student1 = models.Student{
 		ID:        1,
 		FirstName: "Charles",
 		LastName:  "Leclerc",
 		Year:      3,
+		Status:    "active",
 	}
 	student2 = models.Student{
 		ID:        2,
 		FirstName: "Carlos",
 		LastName:  "Sainz",
 		Year:      1,
+		Status:    "active",
 	}
 	errStudent  = &models.Student{}
 	studentList = []models.Student{student1, student2}
 	ErrResponse = errors.New("error Getting Students")
 
-	expectedResponseError = `{"status":"Error","data":{"id":0,"firstname":"","lastname":"","year":0},"message":"Error Getting Students "}`
+	expectedResponseError = `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"Error Getting Students ","code":"INTERNAL_ERROR"}`
+	expectedCreateError   = `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"Error Creating Student","code":"INTERNAL_ERROR"}`
+	expectedUpdateError   = `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"Error Updating Student","code":"INTERNAL_ERROR"}`
 )
 
 func NewMockStudentHandler_HappyPath(ctrl *gomock.Controller) *StudentHandler {
@@ -45,17 +52,15 @@ func NewMockStudentHandler_HappyPath(ctrl *gomock.Controller) *StudentHandler {
 		Data:          studentList,
 	}
 
-	student.EXPECT().GetAllStudents().Return(studentList, nil)
-	student.EXPECT().GetStudent(1).Return(&student1, nil)
-	student.EXPECT().CreateStudent(&student0).Return(&student1, nil)
-	student.EXPECT().UpdateStudent(&student1).Return(&student1, nil)
-	student.EXPECT().DeleteStudent(1).Return(&student1, nil)
-	student.EXPECT().SearchStudent("charl", models.Pagination{Page: 0, PageSize: 2},
+	student.EXPECT().GetAll(gomock.Any(), nil).Return(studentList, nil)
+	student.EXPECT().Get(gomock.Any(), 1).Return(&student1, nil)
+	student.EXPECT().Create(gomock.Any(), &student0).Return(&student1, nil)
+	student.EXPECT().Update(gomock.Any(), &student1).Return(&student1, nil)
+	student.EXPECT().Delete(gomock.Any(), 1).Return(&student1, nil)
+	student.EXPECT().Search(gomock.Any(), "charl", nil, models.Pagination{Page: 0, PageSize: 2},
 		models.SortBy{Column: "firstname", Direction: "ASC"}).Return(&data, nil)
 
-	return &StudentHandler{
-		student: student,
-	}
+	return newStudentHandler(student, mocks.NewMockEnrolmentUsecase(ctrl), mocks.NewMockGradeUsecase(ctrl))
 }
 
 func TestStudentRoutes_HappyPath(t *testing.T) {
@@ -66,12 +71,7 @@ func TestStudentRoutes_HappyPath(t *testing.T) {
 
 	studentHandler := NewMockStudentHandler_HappyPath(ctrl)
 
-	r.HandleFunc("/", studentHandler.getAllStudents).Methods("GET")
-	r.HandleFunc("/getStudent/{id}", studentHandler.getStudent).Methods("GET")
-	r.HandleFunc("/", studentHandler.createStudent).Methods("POST")
-	r.HandleFunc("/", studentHandler.updateStudent).Methods("PUT")
-	r.HandleFunc("/{id}", studentHandler.deleteStudent).Methods("DELETE")
-	r.HandleFunc("/search", studentHandler.searchStudents).Methods("GET") //studentHandler := NewStudentHandler(database.NewDatabase().GetConnection())
+	studentHandler.StudentRoutes(r, nil)
 
 	testCases := []struct {
 		name           string
@@ -87,7 +87,7 @@ func TestStudentRoutes_HappyPath(t *testing.T) {
 			method:         "GET",
 			requestBody:    "",
 			expectedStatus: 200,
-			expectedBody:   `{"status":"Success","data":[{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3},{"id":2,"firstname":"Carlos","lastname":"Sainz","year":1}],"message":"Student Queried Successfully"}`,
+			expectedBody:   `{"status":"Success","data":[{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},{"id":2,"registrationNumber":null,"firstname":"Carlos","lastname":"Sainz","year":1,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}],"message":"Student Queried Successfully"}`,
 		},
 		{
 			name:           "Get Specific Students",
@@ -95,23 +95,23 @@ func TestStudentRoutes_HappyPath(t *testing.T) {
 			method:         "GET",
 			requestBody:    "",
 			expectedStatus: 200,
-			expectedBody:   `{"status":"Success","data":{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3},"message":"Student Queried Successfully"}`,
+			expectedBody:   `{"status":"Success","data":{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Queried Successfully"}`,
 		},
 		{
 			name:           "Create Student",
 			url:            "/",
 			method:         "POST",
-			requestBody:    `{"firstname":"Charles","lastname":"Leclerc","year":3}`,
+			requestBody:    `{"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}`,
 			expectedStatus: 200,
-			expectedBody:   `{"status":"Success","data":{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3},"message":"Student Created Successfully"}`,
+			expectedBody:   `{"status":"Success","data":{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Created Successfully"}`,
 		},
 		{
 			name:           "Update Student",
 			url:            "/",
 			method:         "PUT",
-			requestBody:    `{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3}`,
+			requestBody:    `{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}`,
 			expectedStatus: 200,
-			expectedBody:   `{"status":"Success","data":{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3},"message":"Student Updated Successfully"}`,
+			expectedBody:   `{"status":"Success","data":{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Updated Successfully"}`,
 		},
 		{
 			name:           "Delete Specific Student",
@@ -119,7 +119,7 @@ func TestStudentRoutes_HappyPath(t *testing.T) {
 			method:         "DELETE",
 			requestBody:    "",
 			expectedStatus: 200,
-			expectedBody:   `{"status":"Success","data":{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3},"message":"Student Deleted Successfully"}`,
+			expectedBody:   `{"status":"Success","data":{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Deleted Successfully"}`,
 		},
 		{
 			name:           "Search Students",
@@ -127,7 +127,91 @@ func TestStudentRoutes_HappyPath(t *testing.T) {
 			method:         "GET",
 			requestBody:    `{"searchString":"charl","sortBy": {"column":"firstname","direction":"ASC"},"pagination": {"page":0,"pageSize":2}}`,
 			expectedStatus: 200,
-			expectedBody:   `{"status":"Success","data":{"totalElements":2,"data":[{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3},{"id":2,"firstname":"Carlos","lastname":"Sainz","year":1}]},"message":"Student Queried Successfully"}`,
+			expectedBody:   `{"status":"Success","data":{"totalElements":2,"data":[{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},{"id":2,"registrationNumber":null,"firstname":"Carlos","lastname":"Sainz","year":1,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}]},"message":"Student Queried Successfully"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest(test.method, test.url, strings.NewReader(test.requestBody))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
+
+func TestStudentV1Routes_HappyPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := mux.NewRouter()
+
+	studentHandler := NewMockStudentHandler_HappyPath(ctrl)
+
+	studentHandler.V1Routes(r.PathPrefix("/api/v1/students").Subrouter(), nil)
+
+	testCases := []struct {
+		name           string
+		url            string
+		method         string
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Get All Students",
+			url:            "/api/v1/students",
+			method:         "GET",
+			requestBody:    "",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":[{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},{"id":2,"registrationNumber":null,"firstname":"Carlos","lastname":"Sainz","year":1,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}],"message":"Student Queried Successfully"}`,
+		},
+		{
+			name:           "Get Specific Students",
+			url:            "/api/v1/students/1",
+			method:         "GET",
+			requestBody:    "",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Queried Successfully"}`,
+		},
+		{
+			name:           "Create Student",
+			url:            "/api/v1/students",
+			method:         "POST",
+			requestBody:    `{"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}`,
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Created Successfully"}`,
+		},
+		{
+			name:           "Update Student",
+			url:            "/api/v1/students/1",
+			method:         "PUT",
+			requestBody:    `{"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}`,
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Updated Successfully"}`,
+		},
+		{
+			name:           "Delete Specific Student",
+			url:            "/api/v1/students/1",
+			method:         "DELETE",
+			requestBody:    "",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Deleted Successfully"}`,
+		},
+		{
+			name:           "Search Students",
+			url:            "/api/v1/students/search?q=charl&sortBy=firstname&direction=ASC&page=0&pageSize=2",
+			method:         "GET",
+			requestBody:    "",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"totalElements":2,"data":[{"id":1,"registrationNumber":null,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},{"id":2,"registrationNumber":null,"firstname":"Carlos","lastname":"Sainz","year":1,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}]},"message":"Student Queried Successfully"}`,
 		},
 	}
 
@@ -150,17 +234,15 @@ func TestStudentRoutes_HappyPath(t *testing.T) {
 func NewMockStudentHandler_ErrorPath(ctrl *gomock.Controller) *StudentHandler {
 	student := mocks.NewMockStudentUsecase(ctrl)
 
-	student.EXPECT().GetAllStudents().Return(nil, ErrResponse)
-	student.EXPECT().GetStudent(1).Return(errStudent, ErrResponse)
-	student.EXPECT().CreateStudent(&student0).Return(errStudent, ErrResponse)
-	student.EXPECT().UpdateStudent(&student1).Return(errStudent, ErrResponse)
-	student.EXPECT().DeleteStudent(1).Return(errStudent, ErrResponse)
-	student.EXPECT().SearchStudent("charl", models.Pagination{Page: 0, PageSize: 2},
+	student.EXPECT().GetAll(gomock.Any(), nil).Return(nil, ErrResponse)
+	student.EXPECT().Get(gomock.Any(), 1).Return(errStudent, ErrResponse)
+	student.EXPECT().Create(gomock.Any(), &student0).Return(errStudent, ErrResponse)
+	student.EXPECT().Update(gomock.Any(), &student1).Return(errStudent, ErrResponse)
+	student.EXPECT().Delete(gomock.Any(), 1).Return(errStudent, ErrResponse)
+	student.EXPECT().Search(gomock.Any(), "charl", nil, models.Pagination{Page: 0, PageSize: 2},
 		models.SortBy{Column: "firstname", Direction: "ASC"}).Return(nil, ErrResponse)
 
-	return &StudentHandler{
-		student: student,
-	}
+	return newStudentHandler(student, mocks.NewMockEnrolmentUsecase(ctrl), mocks.NewMockGradeUsecase(ctrl))
 }
 
 func TestStudentRoutes_ErrorPath(t *testing.T) {
@@ -171,12 +253,7 @@ func TestStudentRoutes_ErrorPath(t *testing.T) {
 
 	studentHandler := NewMockStudentHandler_ErrorPath(ctrl)
 
-	r.HandleFunc("/", studentHandler.getAllStudents).Methods("GET")
-	r.HandleFunc("/getStudent/{id}", studentHandler.getStudent).Methods("GET")
-	r.HandleFunc("/", studentHandler.createStudent).Methods("POST")
-	r.HandleFunc("/", studentHandler.updateStudent).Methods("PUT")
-	r.HandleFunc("/{id}", studentHandler.deleteStudent).Methods("DELETE")
-	r.HandleFunc("/search", studentHandler.searchStudents).Methods("GET") //studentHandler := NewStudentHandler(database.NewDatabase().GetConnection())
+	studentHandler.StudentRoutes(r, nil)
 
 	testCases := []struct {
 		name           string
@@ -192,7 +269,7 @@ func TestStudentRoutes_ErrorPath(t *testing.T) {
 			method:         "GET",
 			requestBody:    "",
 			expectedStatus: 500,
-			expectedBody:   `{"status":"Error","data":null,"message":"Error Getting Students "}`,
+			expectedBody:   `{"status":"Error","data":null,"message":"Error Getting Students ","code":"INTERNAL_ERROR"}`,
 		},
 		{
 			name:           "Get Specific Students",
@@ -206,17 +283,17 @@ func TestStudentRoutes_ErrorPath(t *testing.T) {
 			name:           "Create Student",
 			url:            "/",
 			method:         "POST",
-			requestBody:    `{"firstname":"Charles","lastname":"Leclerc","year":3}`,
+			requestBody:    `{"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}`,
 			expectedStatus: 500,
-			expectedBody:   expectedResponseError,
+			expectedBody:   expectedCreateError,
 		},
 		{
 			name:           "Update Student",
 			url:            "/",
 			method:         "PUT",
-			requestBody:    `{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3}`,
+			requestBody:    `{"id":1,"firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"}`,
 			expectedStatus: 500,
-			expectedBody:   expectedResponseError,
+			expectedBody:   expectedUpdateError,
 		},
 		{
 			name:           "Delete Specific Student",
@@ -224,7 +301,7 @@ func TestStudentRoutes_ErrorPath(t *testing.T) {
 			method:         "DELETE",
 			requestBody:    "",
 			expectedStatus: 500,
-			expectedBody:   `{"status":"Error","data":{"id":0,"firstname":"","lastname":"","year":0},"message":"Error Deleting Student"}`,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"Error Deleting Student","code":"INTERNAL_ERROR"}`,
 		},
 		{
 			name:           "Search Students",
@@ -232,7 +309,7 @@ func TestStudentRoutes_ErrorPath(t *testing.T) {
 			method:         "GET",
 			requestBody:    `{"searchString":"charl","sortBy": {"column":"firstname","direction":"ASC"},"pagination": {"page":0,"pageSize":2}}`,
 			expectedStatus: 500,
-			expectedBody:   `{"status":"Error","data":{"totalElements":0,"data":null},"message":"Error Getting Students "}`,
+			expectedBody:   `{"status":"Error","data":{"totalElements":0,"data":null},"message":"Error Getting Students ","code":"INTERNAL_ERROR"}`,
 		},
 	}
 
@@ -251,3 +328,89 @@ func TestStudentRoutes_ErrorPath(t *testing.T) {
 		}
 	}
 }
+
+func TestStudentRoutes_InvalidSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := mux.NewRouter()
+
+	student := mocks.NewMockStudentUsecase(ctrl)
+	student.EXPECT().Search(gomock.Any(), "charl", nil, models.Pagination{Page: 0, PageSize: 2},
+		models.SortBy{Column: "password", Direction: "ASC"}).
+		Return(nil, apperrors.Validation(`Invalid Search Request : sort column "password" is not allowed`, nil))
+
+	studentHandler := newStudentHandler(student, mocks.NewMockEnrolmentUsecase(ctrl), mocks.NewMockGradeUsecase(ctrl))
+
+	studentHandler.StudentRoutes(r, nil)
+
+	req := httptest.NewRequest("GET", "/search", strings.NewReader(`{"searchString":"charl","sortBy": {"column":"password","direction":"ASC"},"pagination": {"page":0,"pageSize":2}}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	expectedBody := `{"status":"Error","data":{"totalElements":0,"data":null},"message":"Invalid Search Request : sort column \"password\" is not allowed","code":"VALIDATION_ERROR"}`
+
+	if w.Code != 400 {
+		t.Errorf("Expected status code %d, but got %d", 400, w.Code)
+	}
+
+	if w.Body.String() != expectedBody {
+		t.Errorf("Expected response body %s, but got %s", expectedBody, w.Body.String())
+	}
+}
+
+func TestStudentRoutes_GetByNumber(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := mux.NewRouter()
+
+	number := "STU-2026-000001"
+	numbered := student1
+	numbered.RegistrationNumber = &number
+
+	student := mocks.NewMockStudentUsecase(ctrl)
+	student.EXPECT().GetByNumber(gomock.Any(), "STU-2026-000001").Return(&numbered, nil)
+	student.EXPECT().GetByNumber(gomock.Any(), "STU-2026-999999").
+		Return(errStudent, apperrors.NotFound(consts.StudentNotFound))
+
+	studentHandler := newStudentHandler(student, mocks.NewMockEnrolmentUsecase(ctrl), mocks.NewMockGradeUsecase(ctrl))
+
+	studentHandler.StudentRoutes(r, nil)
+
+	testCases := []struct {
+		name           string
+		url            string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Existing Number",
+			url:            "/by-number/STU-2026-000001",
+			expectedStatus: 200,
+			expectedBody:   `{"status":"Success","data":{"id":1,"registrationNumber":"STU-2026-000001","firstname":"Charles","lastname":"Leclerc","year":3,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":"active"},"message":"Student Queried Successfully"}`,
+		},
+		{
+			name:           "Missing Number",
+			url:            "/by-number/STU-2026-999999",
+			expectedStatus: 404,
+			expectedBody:   `{"status":"Error","data":{"id":0,"registrationNumber":null,"firstname":"","lastname":"","year":0,"email":null,"phone":null,"dateOfBirth":null,"enrolmentDate":null,"status":""},"message":"student Not Found","code":"NOT_FOUND"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		req := httptest.NewRequest("GET", test.url, nil)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		if w.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, w.Code)
+		}
+
+		if w.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected response body %s, but got %s", test.name, test.expectedBody, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package student
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/crud"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/response"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/pdf"
+	"github.com/tryfix/log"
+)
+
+// The formats a transcript can be downloaded in
+const (
+	formatJSON = "json"
+	formatText = "text"
+	formatPDF  = "pdf"
+)
+
+// getTranscript writes the transcript in the format asked for by the format
+// query parameter or else the Accept header, JSON in the response envelope
+// by default. The text and PDF renderings are sent as attachments
+func (handler *StudentHandler) getTranscript(w http.ResponseWriter, r *http.Request) {
+	var respModel models.TranscriptResponse
+
+	id, err := crud.PathID(r)
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.IDError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	format, err := transcriptFormat(r)
+	if err != nil {
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.TranscriptFormatError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	transcript, err := handler.grade.Transcript(r.Context(), id)
+	if err != nil {
+		log.Error(consts.GetTranscriptError, err)
+
+		respModel.Status = consts.Error
+		respModel.Code = apperrors.CodeOf(err)
+		respModel.Message = apperrors.MessageOf(err, consts.GetTranscriptError)
+		response.Write(w, response.Status(err), respModel)
+		return
+	}
+
+	switch format {
+	case formatText:
+		writeAttachment(w, consts.TextPlain+"; charset=utf-8", fmt.Sprintf("transcript-%d.txt", id),
+			func(out io.Writer) error {
+				_, err := io.WriteString(out, strings.Join(transcriptLines(transcript), "\n")+"\n")
+				return err
+			})
+	case formatPDF:
+		writeAttachment(w, consts.ApplicationPDF, fmt.Sprintf("transcript-%d.pdf", id),
+			func(out io.Writer) error {
+				doc := pdf.New()
+				for _, line := range transcriptLines(transcript) {
+					doc.Line(line)
+				}
+				_, err := doc.WriteTo(out)
+				return err
+			})
+	default:
+		respModel.Status = consts.Success
+		respModel.Data = *transcript
+		respModel.Message = consts.GetTranscript
+		response.Write(w, http.StatusOK, respModel)
+	}
+}
+
+// transcriptFormat returns the requested transcript format, an unknown
+// format query parameter is a validation error
+func transcriptFormat(r *http.Request) (string, error) {
+	format := r.URL.Query().Get("format")
+	switch format {
+	case formatJSON, formatText, formatPDF:
+		return format, nil
+	case "":
+	default:
+		return "", apperrors.Validation(consts.TranscriptFormatError, nil)
+	}
+
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, consts.ApplicationPDF):
+		return formatPDF, nil
+	case strings.Contains(accept, consts.TextPlain):
+		return formatText, nil
+	default:
+		return formatJSON, nil
+	}
+}
+
+// writeAttachment writes the file render produces as a download
+func writeAttachment(w http.ResponseWriter, contentType string, filename string, render func(w io.Writer) error) {
+	w.Header().Set(consts.ContentType, contentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.WriteHeader(http.StatusOK)
+
+	err := render(w)
+	if err != nil {
+		log.Error(consts.ResponseWriteError, err)
+	}
+}
+
+// transcriptLines renders the transcript as lines of text with aligned
+// columns, the text and the PDF downloads share it
+func transcriptLines(transcript *models.Transcript) []string {
+	student := transcript.Student
+	lines := []string{
+		"Academic Transcript",
+		"",
+		fmt.Sprintf("Student : %s %s (%d)", student.FirstName, student.LastName, student.ID),
+		fmt.Sprintf("Year    : %d", student.Year),
+	}
+
+	for _, semester := range transcript.Semesters {
+		lines = append(lines, "",
+			"Semester "+semester.Semester,
+			fmt.Sprintf("  %-10s %-36s %7s %5s %6s", "Code", "Title", "Credits", "Grade", "Points"))
+		for _, course := range semester.Courses {
+			lines = append(lines, fmt.Sprintf("  %-10s %-36s %7d %5s %6.2f", course.Code, truncate(course.Title, 36),
+				course.Credits, course.Grade, course.Points))
+		}
+		lines = append(lines, fmt.Sprintf("  Credits %d, GPA %.2f", semester.Credits, semester.GPA))
+	}
+
+	return append(lines, "", fmt.Sprintf("Total credits %d, GPA %.2f", transcript.Credits, transcript.GPA))
+}
+
+// truncate shortens text to at most max characters
+func truncate(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max-3]) + "..."
+}
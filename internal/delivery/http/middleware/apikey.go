@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+// APIKeyAuthenticator returns the API key matching a key sent by a client
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (*models.APIKey, error)
+}
+
+// APIKey accepts an API key in the X-API-Key header as an alternative to a
+// bearer token. A valid key puts claims holding its scopes into the request
+// context, which the other authentication middlewares then accept, an
+// invalid one is answered with 401. Requests without the header are passed on
+func APIKey(keys APIKeyAuthenticator) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(consts.APIKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			apiKey, err := keys.Authenticate(r.Context(), key)
+			if err != nil {
+				log.Debug(consts.InvalidAPIKeyError, err)
+				unauthorized(w, err)
+				return
+			}
+
+			claims := &auth.Claims{
+				RegisteredClaims: jwt.RegisteredClaims{Subject: "apikey:" + strconv.Itoa(apiKey.ID)},
+				Scopes:           apiKey.Scopes,
+			}
+			next.ServeHTTP(w, r.WithContext(auth.WithClaims(r.Context(), claims)))
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+)
+
+type apiKeyAuthenticatorFunc func(ctx context.Context, key string) (*models.APIKey, error)
+
+func (f apiKeyAuthenticatorFunc) Authenticate(ctx context.Context, key string) (*models.APIKey, error) {
+	return f(ctx, key)
+}
+
+func TestAPIKey(t *testing.T) {
+	keys := apiKeyAuthenticatorFunc(func(ctx context.Context, key string) (*models.APIKey, error) {
+		if key != "sak_valid.secret" {
+			return nil, apperrors.Unauthorized(consts.InvalidAPIKeyError, nil)
+		}
+		return &models.APIKey{ID: 3, Scopes: []string{"student:read"}}, nil
+	})
+	verifier := auth.NewVerifier(auth.NewKeySet(), "", "")
+
+	testCases := []struct {
+		name            string
+		key             string
+		expectedStatus  int
+		expectedBody    string
+		expectedSubject string
+		expectedScopes  []string
+	}{
+		{
+			name:            "Valid Key",
+			key:             "sak_valid.secret",
+			expectedStatus:  http.StatusOK,
+			expectedSubject: "apikey:3",
+			expectedScopes:  []string{"student:read"},
+		},
+		{
+			name:           "Invalid Key",
+			key:            "sak_other.secret",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"status":"Error","data":null,"message":"The API Key Is Invalid Or Revoked","code":"UNAUTHORIZED"}`,
+		},
+		{
+			name:           "No Key",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"status":"Error","data":null,"message":"A Bearer Token Is Required","code":"UNAUTHORIZED"}`,
+		},
+	}
+
+	for _, test := range testCases {
+		var claims *auth.Claims
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, _ = auth.ClaimsFrom(r.Context())
+		})
+
+		req := httptest.NewRequest("GET", "/student/", nil)
+		if test.key != "" {
+			req.Header.Set(consts.APIKeyHeader, test.key)
+		}
+		rr := httptest.NewRecorder()
+
+		// the bearer token middleware runs after and accepts the key
+		APIKey(keys)(Authenticate(verifier, nil)(next)).ServeHTTP(rr, req)
+
+		if rr.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, rr.Code)
+		}
+		if test.expectedBody != "" && rr.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected body %s, but got %s", test.name, test.expectedBody, rr.Body.String())
+		}
+		if test.expectedSubject == "" {
+			continue
+		}
+		if claims == nil || claims.Subject != test.expectedSubject || !reflect.DeepEqual(claims.Scopes, test.expectedScopes) {
+			t.Errorf("Test %s : Expected subject %q with scopes %v, but got %+v", test.name, test.expectedSubject,
+				test.expectedScopes, claims)
+		}
+	}
+}
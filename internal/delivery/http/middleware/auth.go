@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/response"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+// Authenticate requires a valid bearer token on every request except the
+// allowed paths and the requests an earlier middleware, such as APIKey,
+// already authenticated. The verified claims are put into the request
+// context, see auth.ClaimsFrom. A missing or invalid token is answered with 401
+func Authenticate(verifier *auth.Verifier, allow []string) mux.MiddlewareFunc {
+	allowed := map[string]bool{}
+	for _, path := range allow {
+		allowed[path] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := auth.ClaimsFrom(r.Context()); ok || allowed[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token, ok := bearerToken(r)
+			if !ok {
+				unauthorized(w, apperrors.Unauthorized(consts.MissingTokenError, nil))
+				return
+			}
+
+			claims, err := verifier.Verify(token)
+			if err != nil {
+				log.Debug(consts.InvalidTokenError, err)
+				unauthorized(w, apperrors.Unauthorized(consts.InvalidTokenError, err))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithClaims(r.Context(), claims)))
+		})
+	}
+}
+
+// bearerToken returns the token of the Authorization header
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+func unauthorized(w http.ResponseWriter, err error) {
+	var respModel models.Response[interface{}]
+
+	respModel.Status = consts.Error
+	respModel.Code = apperrors.CodeOf(err)
+	respModel.Message = apperrors.MessageOf(err, consts.InvalidTokenError)
+
+	w.Header().Set("WWW-Authenticate", `Bearer realm="simpleAPI"`)
+	response.Write(w, response.Status(err), respModel)
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+)
+
+func TestAuthenticate(t *testing.T) {
+	secret := []byte("a-secret-of-at-least-thirty-two-bytes")
+	verifier := auth.NewVerifier(auth.NewKeySet(auth.Key{ID: "k1", Algorithm: auth.HS256, Key: secret}), "", "")
+
+	sign := func(expiresAt time.Time) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   "user-1",
+				ExpiresAt: jwt.NewNumericDate(expiresAt),
+			},
+		})
+		signed, err := token.SignedString(secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+
+	testCases := []struct {
+		name            string
+		path            string
+		authorization   string
+		expectedStatus  int
+		expectedBody    string
+		expectedSubject string
+	}{
+		{
+			name:            "Valid Token",
+			path:            "/student/",
+			authorization:   "Bearer " + sign(time.Now().Add(time.Hour)),
+			expectedStatus:  http.StatusOK,
+			expectedSubject: "user-1",
+		},
+		{
+			name:           "Missing Token",
+			path:           "/student/",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"status":"Error","data":null,"message":"A Bearer Token Is Required","code":"UNAUTHORIZED"}`,
+		},
+		{
+			name:           "Wrong Scheme",
+			path:           "/student/",
+			authorization:  "Basic dXNlcjpwYXNz",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"status":"Error","data":null,"message":"A Bearer Token Is Required","code":"UNAUTHORIZED"}`,
+		},
+		{
+			name:           "Expired Token",
+			path:           "/student/",
+			authorization:  "Bearer " + sign(time.Now().Add(-time.Hour)),
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"status":"Error","data":null,"message":"The Bearer Token Is Invalid Or Expired","code":"UNAUTHORIZED"}`,
+		},
+		{
+			name:           "Allowed Path",
+			path:           "/metrics",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, test := range testCases {
+		var subject string
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			subject = auth.SubjectFrom(r.Context())
+		})
+
+		req := httptest.NewRequest("GET", test.path, nil)
+		if test.authorization != "" {
+			req.Header.Set("Authorization", test.authorization)
+		}
+		rr := httptest.NewRecorder()
+
+		Authenticate(verifier, []string{"/health", "/metrics"})(next).ServeHTTP(rr, req)
+
+		if rr.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, rr.Code)
+		}
+		if test.expectedBody != "" && rr.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected body %s, but got %s", test.name, test.expectedBody, rr.Body.String())
+		}
+		if test.expectedStatus == http.StatusUnauthorized && rr.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("Test %s : Expected a WWW-Authenticate header", test.name)
+		}
+		if subject != test.expectedSubject {
+			t.Errorf("Test %s : Expected subject %q, but got %q", test.name, test.expectedSubject, subject)
+		}
+	}
+}
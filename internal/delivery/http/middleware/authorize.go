@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/response"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+// Authorizer wraps a route handler with the policy check of an action
+type Authorizer func(action auth.Action, next http.HandlerFunc) http.Handler
+
+// Authorize returns the Authorizer of the routes of resource. The caller's
+// subject and roles are taken from the claims Authenticate put into the
+// request context, a request the policy denies is answered with 403.
+// A nil policy allows every request, it is used when authentication is off
+func Authorize(policy *auth.Policy, resource string) Authorizer {
+	return func(action auth.Action, next http.HandlerFunc) http.Handler {
+		if policy == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			req := auth.Request{
+				Resource:   resource,
+				Action:     action,
+				ResourceID: mux.Vars(r)["id"],
+			}
+			if claims, ok := auth.ClaimsFrom(r.Context()); ok {
+				req.Subject = claims.Subject
+				req.Roles = claims.Roles
+				req.Scopes = claims.Scopes
+			}
+
+			decision := policy.Evaluate(req)
+			if !decision.Allowed {
+				log.Info(fmt.Sprintf("authorization denied : subject %q roles %v %s %s %q",
+					req.Subject, req.Roles, req.Action, req.Resource, req.ResourceID))
+				forbidden(w)
+				return
+			}
+
+			log.Debug(fmt.Sprintf("authorization allowed : subject %q roles %v %s %s %q by %s",
+				req.Subject, req.Roles, req.Action, req.Resource, req.ResourceID, decision))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func forbidden(w http.ResponseWriter) {
+	var respModel models.Response[interface{}]
+
+	err := apperrors.Forbidden(consts.ForbiddenError)
+	respModel.Status = consts.Error
+	respModel.Code = apperrors.CodeOf(err)
+	respModel.Message = apperrors.MessageOf(err, consts.ForbiddenError)
+	response.Write(w, response.Status(err), respModel)
+}
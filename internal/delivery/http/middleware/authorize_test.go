@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+)
+
+func TestAuthorize(t *testing.T) {
+	testCases := []struct {
+		name           string
+		policy         *auth.Policy
+		claims         *auth.Claims
+		method         string
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Allowed",
+			policy:         auth.DefaultPolicy(),
+			claims:         &auth.Claims{Roles: []string{"lecturer"}},
+			method:         "GET",
+			path:           "/student/getStudent/7",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Denied Action",
+			policy:         auth.DefaultPolicy(),
+			claims:         &auth.Claims{Roles: []string{"lecturer"}},
+			method:         "DELETE",
+			path:           "/student/7",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"status":"Error","data":null,"message":"You Are Not Allowed To Perform This Action","code":"FORBIDDEN"}`,
+		},
+		{
+			name:           "Own Record",
+			policy:         auth.DefaultPolicy(),
+			claims:         &auth.Claims{Roles: []string{"student"}},
+			method:         "GET",
+			path:           "/student/getStudent/7",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Another Record",
+			policy:         auth.DefaultPolicy(),
+			claims:         &auth.Claims{Roles: []string{"student"}},
+			method:         "GET",
+			path:           "/student/getStudent/8",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "No Claims",
+			policy:         auth.DefaultPolicy(),
+			method:         "GET",
+			path:           "/student/getStudent/7",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "No Policy",
+			method:         "DELETE",
+			path:           "/student/7",
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, test := range testCases {
+		next := func(w http.ResponseWriter, r *http.Request) {}
+		authorize := Authorize(test.policy, "student")
+
+		r := mux.NewRouter()
+		r.Handle("/student/getStudent/{id}", authorize(auth.ActionRead, next)).Methods("GET")
+		r.Handle("/student/{id}", authorize(auth.ActionDelete, next)).Methods("DELETE")
+
+		req := httptest.NewRequest(test.method, test.path, nil)
+		if test.claims != nil {
+			test.claims.Subject = "7"
+			req = req.WithContext(auth.WithClaims(context.Background(), test.claims))
+		}
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		if rr.Code != test.expectedStatus {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expectedStatus, rr.Code)
+		}
+		if test.expectedBody != "" && rr.Body.String() != test.expectedBody {
+			t.Errorf("Test %s : Expected body %s, but got %s", test.name, test.expectedBody, rr.Body.String())
+		}
+	}
+}
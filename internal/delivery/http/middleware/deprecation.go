@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// Deprecated marks the responses of the requests under the path prefixes of
+// successors as deprecated since deprecated with the Deprecation header of
+// RFC 9745, eg. Deprecation: @1792281600, and names the route that replaces
+// the prefix in a Link header. The Sunset header of RFC 8594 tells when the
+// routes go away, it is left out when sunset is zero. It is used on the
+// router ahead of the authentication, so a request rejected there is marked
+// as well
+func Deprecated(deprecated time.Time, sunset time.Time, successors map[string]string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			successor, ok := successorOf(successors, r.URL.Path)
+			if ok {
+				w.Header().Set("Deprecation", "@"+strconv.FormatInt(deprecated.Unix(), 10))
+				if !sunset.IsZero() {
+					w.Header().Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+				}
+				if successor != "" {
+					w.Header().Set("Link", "<"+successor+`>; rel="successor-version"`)
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// successorOf returns the successor of the prefix path is under, the prefix
+// matches whole segments so /staff does not match /staffing
+func successorOf(successors map[string]string, path string) (string, bool) {
+	for prefix, successor := range successors {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return successor, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+)
+
+func TestDeprecated(t *testing.T) {
+	deprecated := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	successors := map[string]string{"/student": "/api/v1/students", "/staff": ""}
+	testCases := []struct {
+		name     string
+		path     string
+		sunset   time.Time
+		expected http.Header
+	}{
+		{name: "With Sunset", path: "/student/getStudent/1", sunset: time.Date(2027, time.April, 18, 0, 0, 0, 0, time.UTC),
+			expected: http.Header{
+				"Deprecation": {"@1792281600"},
+				"Sunset":      {"Sun, 18 Apr 2027 00:00:00 GMT"},
+				"Link":        {`</api/v1/students>; rel="successor-version"`},
+			}},
+		{name: "Without Sunset Or Successor", path: "/staff", expected: http.Header{"Deprecation": {"@1792281600"}}},
+		{name: "Not Deprecated", path: "/api/v1/students/1", expected: http.Header{}},
+		{name: "Prefix Of Another Segment", path: "/students/1", expected: http.Header{}},
+	}
+
+	for _, test := range testCases {
+		served := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			served = true
+		})
+
+		rr := httptest.NewRecorder()
+		Deprecated(deprecated, test.sunset, successors)(next).ServeHTTP(rr,
+			httptest.NewRequest("GET", test.path, nil))
+
+		if !served {
+			t.Errorf("Test %s : Expected the request to be served", test.name)
+		}
+		for name := range test.expected {
+			if rr.Header().Get(name) != test.expected.Get(name) {
+				t.Errorf("Test %s : Expected %s %q, but got %q", test.name, name, test.expected.Get(name),
+					rr.Header().Get(name))
+			}
+		}
+		if len(rr.Header()) != len(test.expected) {
+			t.Errorf("Test %s : Expected the headers %v, but got %v", test.name, test.expected, rr.Header())
+		}
+	}
+}
+
+func TestDeprecated_Unauthenticated(t *testing.T) {
+	secret := []byte("a-secret-of-at-least-thirty-two-bytes")
+	verifier := auth.NewVerifier(auth.NewKeySet(auth.Key{ID: "k1", Algorithm: auth.HS256, Key: secret}), "", "")
+
+	router := mux.NewRouter()
+	router.Use(Deprecated(time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC),
+		time.Date(2027, time.April, 18, 0, 0, 0, 0, time.UTC), map[string]string{"/student": "/api/v1/students"}))
+	router.Use(Authenticate(verifier, nil))
+	router.HandleFunc("/student/getStudent/{id}", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected the request to be rejected")
+	})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/student/getStudent/1", nil))
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, but got %d", http.StatusUnauthorized, rr.Code)
+	}
+	if rr.Header().Get("Deprecation") != "@1792281600" || rr.Header().Get("Sunset") != "Sun, 18 Apr 2027 00:00:00 GMT" ||
+		rr.Header().Get("Link") != `</api/v1/students>; rel="successor-version"` {
+		t.Errorf("Expected the deprecation headers, but got %v", rr.Header())
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDBTimeout(t *testing.T) {
+	testCases := []struct {
+		name        string
+		timeout     time.Duration
+		hasDeadline bool
+	}{
+		{name: "With Timeout", timeout: time.Second, hasDeadline: true},
+		{name: "Disabled", timeout: 0, hasDeadline: false},
+	}
+
+	for _, test := range testCases {
+		var hasDeadline bool
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, hasDeadline = r.Context().Deadline()
+		})
+
+		DBTimeout(test.timeout)(next).ServeHTTP(httptest.NewRecorder(),
+			httptest.NewRequest("GET", "/", nil))
+
+		if hasDeadline != test.hasDeadline {
+			t.Errorf("Test %s : Expected deadline %v, but got %v", test.name, test.hasDeadline, hasDeadline)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+// Write writes the response model as JSON with the given status code
+func Write(w http.ResponseWriter, status int, respModel interface{}) {
+	w.Header().Set(consts.ContentType, consts.ApplicationJSON)
+	w.WriteHeader(status)
+
+	b, err := json.Marshal(respModel)
+	if err != nil {
+		log.Error(consts.JSONMarshalError, err)
+	}
+
+	_, err = w.Write(b)
+	if err != nil {
+		log.Error(consts.ResponseWriteError, err)
+	}
+}
+
+// Status maps an error returned by the usecases to its HTTP status code
+func Status(err error) int {
+	switch apperrors.CodeOf(err) {
+	case apperrors.CodeNotFound:
+		return http.StatusNotFound
+	case apperrors.CodeValidation:
+		return http.StatusBadRequest
+	case apperrors.CodeConflict:
+		return http.StatusConflict
+	case apperrors.CodePrecondition:
+		return http.StatusPreconditionFailed
+	case apperrors.CodeUnprocessable:
+		return http.StatusUnprocessableEntity
+	case apperrors.CodeTimeout:
+		return http.StatusGatewayTimeout
+	case apperrors.CodeUnauthorized:
+		return http.StatusUnauthorized
+	case apperrors.CodeForbidden:
+		return http.StatusForbidden
+	case apperrors.CodeMediaType:
+		return http.StatusUnsupportedMediaType
+	case apperrors.CodeTooLarge:
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,55 @@
+package response
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+)
+
+func TestStatus(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "Not Found", err: apperrors.NotFound("student Not Found"), expected: http.StatusNotFound},
+		{name: "Validation", err: apperrors.Validation("Invalid ID", nil), expected: http.StatusBadRequest},
+		{name: "Conflict", err: apperrors.Conflict("Duplicate", nil), expected: http.StatusConflict},
+		{name: "Precondition Failed", err: apperrors.PreconditionFailed("Changed"),
+			expected: http.StatusPreconditionFailed},
+		{name: "Unprocessable", err: apperrors.Unprocessable("Rule Broken"), expected: http.StatusUnprocessableEntity},
+		{name: "Timeout", err: apperrors.Timeout(errors.New("context deadline exceeded")), expected: http.StatusGatewayTimeout},
+		{name: "Unauthorized", err: apperrors.Unauthorized("Missing Token", nil), expected: http.StatusUnauthorized},
+		{name: "Forbidden", err: apperrors.Forbidden("Not Allowed"), expected: http.StatusForbidden},
+		{name: "Unsupported Media Type", err: apperrors.UnsupportedMediaType("Wrong Type", nil),
+			expected: http.StatusUnsupportedMediaType},
+		{name: "Too Large", err: apperrors.TooLarge("Too Large"), expected: http.StatusRequestEntityTooLarge},
+		{name: "Internal", err: apperrors.Internal(errors.New("db down")), expected: http.StatusInternalServerError},
+		{name: "Untyped", err: errors.New("error"), expected: http.StatusInternalServerError},
+	}
+
+	for _, test := range testCases {
+		if actual := Status(test.err); actual != test.expected {
+			t.Errorf("Test %s : Expected status code %d, but got %d", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	Write(w, http.StatusCreated, map[string]string{"status": "Success"})
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("Expected status code %d, but got %d", http.StatusCreated, w.Code)
+	}
+	if w.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("Expected content type %s, but got %s", "application/json", w.Header().Get("Content-Type"))
+	}
+	if w.Body.String() != `{"status":"Success"}` {
+		t.Errorf("Expected response body %s, but got %s", `{"status":"Success"}`, w.Body.String())
+	}
+}
@@ -0,0 +1,51 @@
+// Package jobs holds the background jobs of the service
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tryfix/log"
+)
+
+// Purger removes for good the records soft deleted before a time and
+// returns how many were removed
+type Purger interface {
+	Purge(ctx context.Context, before time.Time) (int, error)
+}
+
+// Purge removes the records every purger soft deleted more than retention
+// ago, once on start and then every interval until ctx is done. The purgers
+// are named in the logs, a failing purger does not stop the others
+func Purge(ctx context.Context, interval time.Duration, retention time.Duration, purgers map[string]Purger) {
+	names := make([]string, 0, len(purgers))
+	for name := range purgers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		before := time.Now().Add(-retention)
+		for _, name := range names {
+			purged, err := purgers[name].Purge(ctx, before)
+			if err != nil {
+				log.Error("Error purging the deleted "+name+" ", err)
+				continue
+			}
+			if purged != 0 {
+				log.Info(fmt.Sprintf("purged %d deleted %s", purged, name))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// purgerFunc is a Purger made of a function
+type purgerFunc func(ctx context.Context, before time.Time) (int, error)
+
+func (f purgerFunc) Purge(ctx context.Context, before time.Time) (int, error) {
+	return f(ctx, before)
+}
+
+func TestPurge(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	retention := 24 * time.Hour
+	cutoffs := make(chan time.Time, 10)
+
+	purgers := map[string]Purger{
+		"lecturers": purgerFunc(func(ctx context.Context, before time.Time) (int, error) {
+			return 0, errors.New("connection refused")
+		}),
+		"students": purgerFunc(func(ctx context.Context, before time.Time) (int, error) {
+			cutoffs <- before
+			return 1, nil
+		}),
+	}
+
+	done := make(chan struct{})
+	start := time.Now()
+	go func() {
+		Purge(ctx, 10*time.Millisecond, retention, purgers)
+		close(done)
+	}()
+
+	// the failing purger must not stop the others, on start or on a tick
+	for i := 0; i < 2; i++ {
+		select {
+		case before := <-cutoffs:
+			if expected := start.Add(-retention); before.Before(expected) || before.After(time.Now().Add(-retention)) {
+				t.Errorf("Expected a cutoff retention ago, around %v, but got %v", expected, before)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected purge %d to run", i+1)
+		}
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the purge to stop with its context")
+	}
+}
@@ -0,0 +1,261 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	_ "modernc.org/sqlite"
+)
+
+func newMockMigrator(t *testing.T, migrations []Migration) (*Migrator, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating the mock database %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	return &Migrator{db: db, migrations: migrations}, mock
+}
+
+var testMigrations = []Migration{
+	{Version: 1, Name: "create_a", Up: "CREATE TABLE a (id INT);", Down: "DROP TABLE a;"},
+	{Version: 2, Name: "create_b", Up: "CREATE TABLE b (id INT);\nCREATE INDEX b_id ON b (id);",
+		Down: "DROP TABLE b;"},
+}
+
+func expectApplied(mock sqlmock.Sqlmock, versions ...int) {
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS schema_migrations")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	rows := sqlmock.NewRows([]string{"version", "applied_at"})
+	for _, v := range versions {
+		rows.AddRow(v, time.Date(2023, 1, v, 0, 0, 0, 0, time.UTC))
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version, applied_at FROM schema_migrations;")).
+		WillReturnRows(rows)
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/0002_create_b.up.sql":   {Data: []byte("CREATE TABLE b (id INT);")},
+		"sql/0002_create_b.down.sql": {Data: []byte("DROP TABLE b;")},
+		"sql/0001_create_a.up.sql":   {Data: []byte("CREATE TABLE a (id INT);")},
+		"sql/0001_create_a.down.sql": {Data: []byte("DROP TABLE a;")},
+	}
+
+	actual, err := load(fsys, "sql")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	expected := []Migration{
+		{Version: 1, Name: "create_a", Up: "CREATE TABLE a (id INT);", Down: "DROP TABLE a;"},
+		{Version: 2, Name: "create_b", Up: "CREATE TABLE b (id INT);", Down: "DROP TABLE b;"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected %v, but got %v", expected, actual)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{name: "Bad File Name", fsys: fstest.MapFS{
+			"sql/create_a.up.sql": {Data: []byte("CREATE TABLE a (id INT);")},
+		}},
+		{name: "Missing Down", fsys: fstest.MapFS{
+			"sql/0001_create_a.up.sql": {Data: []byte("CREATE TABLE a (id INT);")},
+		}},
+		{name: "Missing Directory", fsys: fstest.MapFS{}},
+		{name: "Two Names", fsys: fstest.MapFS{
+			"sql/0001_create_a.up.sql":   {Data: []byte("CREATE TABLE a (id INT);")},
+			"sql/0001_create_b.down.sql": {Data: []byte("DROP TABLE b;")},
+		}},
+	}
+
+	for _, test := range testCases {
+		_, err := load(test.fsys, "sql")
+		if err == nil {
+			t.Errorf("Test %s : Expected an error", test.name)
+		}
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	mysql, err := New(nil, "mysql")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	sqlite, err := New(nil, "sqlite")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if len(mysql.migrations) != len(sqlite.migrations) {
+		t.Fatalf("Expected the same migrations for every driver, but got %d and %d",
+			len(mysql.migrations), len(sqlite.migrations))
+	}
+	for i, m := range mysql.migrations {
+		if m.Version != i+1 {
+			t.Errorf("Expected migration %d, but got %d_%s", i+1, m.Version, m.Name)
+		}
+		if s := sqlite.migrations[i]; s.Version != m.Version || s.Name != m.Name {
+			t.Errorf("Expected sqlite migration %d_%s, but got %d_%s", m.Version, m.Name, s.Version, s.Name)
+		}
+	}
+}
+
+func TestNew_UnknownDriver(t *testing.T) {
+	_, err := New(nil, "memory")
+	if err == nil {
+		t.Error("Expected an error")
+	}
+}
+
+func TestMigrator_Up(t *testing.T) {
+	migrator, mock := newMockMigrator(t, testMigrations)
+
+	expectApplied(mock, 1)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE b (id INT);")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("CREATE INDEX b_id ON b (id);")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations")).
+		WithArgs(2, "create_b", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	applied, err := migrator.Up(context.Background())
+	if err != nil || applied != 1 {
+		t.Errorf("Expected 1 applied migration, but got %d, %v", applied, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMigrator_Up_Failure(t *testing.T) {
+	migrator, mock := newMockMigrator(t, testMigrations)
+
+	expectApplied(mock)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE a (id INT);")).
+		WillReturnError(sqlmock.ErrCancelled)
+	mock.ExpectRollback()
+
+	applied, err := migrator.Up(context.Background())
+	if err == nil || applied != 0 {
+		t.Errorf("Expected an error and no applied migrations, but got %d, %v", applied, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMigrator_Down(t *testing.T) {
+	migrator, mock := newMockMigrator(t, testMigrations)
+
+	expectApplied(mock, 1, 2)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DROP TABLE b;")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM schema_migrations WHERE version = ?;")).
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	reverted, err := migrator.Down(context.Background(), 1)
+	if err != nil || reverted != 1 {
+		t.Errorf("Expected 1 reverted migration, but got %d, %v", reverted, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMigrator_Status(t *testing.T) {
+	migrator, mock := newMockMigrator(t, testMigrations)
+
+	expectApplied(mock, 1)
+
+	statuses, err := migrator.Status(context.Background())
+	if err != nil || len(statuses) != 2 {
+		t.Fatalf("Expected 2 statuses, but got %v, %v", statuses, err)
+	}
+	if statuses[0].AppliedAt == nil || statuses[1].AppliedAt != nil {
+		t.Errorf("Expected only the first migration to be applied, but got %v", statuses)
+	}
+}
+
+func TestMigrator_SQLite(t *testing.T) {
+	db, err := sql.Open("sqlite", "file::memory:")
+	if err != nil {
+		t.Fatalf("Error opening the database %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	migrator, err := New(db, "sqlite")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	ctx := context.Background()
+	total := len(migrator.migrations)
+
+	applied, err := migrator.Up(ctx)
+	if err != nil || applied != total {
+		t.Fatalf("Expected %d applied migrations, but got %d, %v", total, applied, err)
+	}
+
+	applied, err = migrator.Up(ctx)
+	if err != nil || applied != 0 {
+		t.Errorf("Expected nothing to apply twice, but got %d, %v", applied, err)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	for _, s := range statuses {
+		if s.AppliedAt == nil {
+			t.Errorf("Expected %d_%s to be applied", s.Version, s.Name)
+		}
+	}
+
+	reverted, err := migrator.Down(ctx, total)
+	if err != nil || reverted != total {
+		t.Errorf("Expected %d reverted migrations, but got %d, %v", total, reverted, err)
+	}
+}
+
+func TestStatements(t *testing.T) {
+	testCases := []struct {
+		name     string
+		script   string
+		expected []string
+	}{
+		{name: "Single", script: "DROP TABLE a;\n", expected: []string{"DROP TABLE a;"}},
+		{name: "Multi Line", script: "CREATE TABLE a (\n  id INT\n);\nDROP TABLE b;",
+			expected: []string{"CREATE TABLE a (\n  id INT\n);", "DROP TABLE b;"}},
+		{name: "No Semicolon", script: "DROP TABLE a", expected: []string{"DROP TABLE a"}},
+		{name: "Empty", script: "\n\n", expected: nil},
+	}
+
+	for _, test := range testCases {
+		actual := statements(test.script)
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Test %s : Expected %q, but got %q", test.name, test.expected, actual)
+		}
+	}
+}
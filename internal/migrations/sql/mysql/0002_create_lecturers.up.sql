@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS lecturers (
+    id        INT          NOT NULL AUTO_INCREMENT,
+    firstname VARCHAR(100) NOT NULL,
+    lastname  VARCHAR(100) NOT NULL,
+    year      INT          NOT NULL,
+    PRIMARY KEY (id)
+);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS staff (
+    id        INT          NOT NULL AUTO_INCREMENT,
+    firstname VARCHAR(100) NOT NULL,
+    lastname  VARCHAR(100) NOT NULL,
+    position  VARCHAR(100) NOT NULL,
+    PRIMARY KEY (id)
+);
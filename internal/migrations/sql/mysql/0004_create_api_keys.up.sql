@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS api_keys (
+    id         INT           NOT NULL AUTO_INCREMENT,
+    name       VARCHAR(100)  NOT NULL,
+    prefix     VARCHAR(32)   NOT NULL,
+    hash       CHAR(64)      NOT NULL,
+    scopes     VARCHAR(1000) NOT NULL,
+    created_at DATETIME      NOT NULL,
+    revoked_at DATETIME      NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY api_keys_prefix (prefix)
+);
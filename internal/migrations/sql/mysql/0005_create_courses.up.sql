@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS courses (
+    id          INT          NOT NULL AUTO_INCREMENT,
+    code        VARCHAR(20)  NOT NULL,
+    title       VARCHAR(100) NOT NULL,
+    credits     INT          NOT NULL,
+    semester    VARCHAR(20)  NOT NULL,
+    lecturer_id INT          NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY courses_code (code),
+    KEY courses_lecturer_id (lecturer_id),
+    CONSTRAINT courses_lecturer_fk FOREIGN KEY (lecturer_id) REFERENCES lecturers (id)
+);
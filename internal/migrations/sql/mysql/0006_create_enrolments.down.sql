@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS enrolments;
+
+ALTER TABLE courses
+    DROP COLUMN capacity,
+    DROP COLUMN min_year;
@@ -0,0 +1,13 @@
+ALTER TABLE courses
+    ADD COLUMN capacity INT NOT NULL DEFAULT 0,
+    ADD COLUMN min_year INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS enrolments (
+    student_id  INT      NOT NULL,
+    course_id   INT      NOT NULL,
+    enrolled_at DATETIME NOT NULL,
+    PRIMARY KEY (student_id, course_id),
+    KEY enrolments_course_id (course_id),
+    CONSTRAINT enrolments_student_fk FOREIGN KEY (student_id) REFERENCES students (id),
+    CONSTRAINT enrolments_course_fk FOREIGN KEY (course_id) REFERENCES courses (id)
+);
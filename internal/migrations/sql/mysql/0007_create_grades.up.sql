@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS grades (
+    student_id  INT        NOT NULL,
+    course_id   INT        NOT NULL,
+    lecturer_id INT        NOT NULL,
+    grade       VARCHAR(2) NOT NULL,
+    recorded_at DATETIME   NOT NULL,
+    PRIMARY KEY (student_id, course_id),
+    KEY grades_course_id (course_id),
+    KEY grades_lecturer_id (lecturer_id),
+    CONSTRAINT grades_student_fk FOREIGN KEY (student_id) REFERENCES students (id),
+    CONSTRAINT grades_course_fk FOREIGN KEY (course_id) REFERENCES courses (id),
+    CONSTRAINT grades_lecturer_fk FOREIGN KEY (lecturer_id) REFERENCES lecturers (id)
+);
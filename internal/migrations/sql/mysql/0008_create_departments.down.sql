@@ -0,0 +1,9 @@
+ALTER TABLE staff DROP FOREIGN KEY staff_department_fk;
+
+ALTER TABLE staff DROP COLUMN department_id;
+
+ALTER TABLE lecturers DROP FOREIGN KEY lecturers_department_fk;
+
+ALTER TABLE lecturers DROP COLUMN department_id;
+
+DROP TABLE IF EXISTS departments;
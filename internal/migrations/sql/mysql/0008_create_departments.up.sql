@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS departments (
+    id      INT          NOT NULL AUTO_INCREMENT,
+    name    VARCHAR(100) NOT NULL,
+    head_id INT          NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY departments_name (name),
+    KEY departments_head_id (head_id),
+    CONSTRAINT departments_head_fk FOREIGN KEY (head_id) REFERENCES lecturers (id)
+);
+
+ALTER TABLE lecturers
+    ADD COLUMN department_id INT NULL,
+    ADD KEY lecturers_department_id (department_id),
+    ADD CONSTRAINT lecturers_department_fk FOREIGN KEY (department_id) REFERENCES departments (id);
+
+ALTER TABLE staff
+    ADD COLUMN department_id INT NULL,
+    ADD KEY staff_department_id (department_id),
+    ADD CONSTRAINT staff_department_fk FOREIGN KEY (department_id) REFERENCES departments (id);
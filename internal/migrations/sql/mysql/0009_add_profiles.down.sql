@@ -0,0 +1,14 @@
+ALTER TABLE lecturers
+    DROP KEY lecturers_email,
+    DROP COLUMN email,
+    DROP COLUMN phone,
+    DROP COLUMN date_of_birth,
+    DROP COLUMN status;
+
+ALTER TABLE students
+    DROP KEY students_email,
+    DROP COLUMN email,
+    DROP COLUMN phone,
+    DROP COLUMN date_of_birth,
+    DROP COLUMN enrolment_date,
+    DROP COLUMN status;
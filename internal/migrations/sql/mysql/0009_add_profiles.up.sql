@@ -0,0 +1,14 @@
+ALTER TABLE students
+    ADD COLUMN email          VARCHAR(254) NULL,
+    ADD COLUMN phone          VARCHAR(20)  NULL,
+    ADD COLUMN date_of_birth  DATE         NULL,
+    ADD COLUMN enrolment_date DATE         NULL,
+    ADD COLUMN status         VARCHAR(20)  NOT NULL DEFAULT 'active',
+    ADD UNIQUE KEY students_email (email);
+
+ALTER TABLE lecturers
+    ADD COLUMN email         VARCHAR(254) NULL,
+    ADD COLUMN phone         VARCHAR(20)  NULL,
+    ADD COLUMN date_of_birth DATE         NULL,
+    ADD COLUMN status        VARCHAR(20)  NOT NULL DEFAULT 'active',
+    ADD UNIQUE KEY lecturers_email (email);
@@ -0,0 +1,9 @@
+ALTER TABLE staff
+    DROP KEY staff_staff_number,
+    DROP COLUMN staff_number;
+
+ALTER TABLE students
+    DROP KEY students_registration_number,
+    DROP COLUMN registration_number;
+
+DROP TABLE IF EXISTS number_sequences;
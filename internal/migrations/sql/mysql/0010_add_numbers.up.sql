@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS number_sequences (
+    name  VARCHAR(100) NOT NULL,
+    value INT          NOT NULL,
+    PRIMARY KEY (name)
+);
+
+ALTER TABLE students
+    ADD COLUMN registration_number VARCHAR(50) NULL AFTER id,
+    ADD UNIQUE KEY students_registration_number (registration_number);
+
+ALTER TABLE staff
+    ADD COLUMN staff_number VARCHAR(50) NULL AFTER id,
+    ADD UNIQUE KEY staff_staff_number (staff_number);
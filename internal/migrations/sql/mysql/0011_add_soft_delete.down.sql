@@ -0,0 +1,7 @@
+ALTER TABLE lecturers
+    DROP KEY lecturers_deleted_at,
+    DROP COLUMN deleted_at;
+
+ALTER TABLE students
+    DROP KEY students_deleted_at,
+    DROP COLUMN deleted_at;
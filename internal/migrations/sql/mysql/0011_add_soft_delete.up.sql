@@ -0,0 +1,7 @@
+ALTER TABLE students
+    ADD COLUMN deleted_at DATETIME NULL,
+    ADD KEY students_deleted_at (deleted_at);
+
+ALTER TABLE lecturers
+    ADD COLUMN deleted_at DATETIME NULL,
+    ADD KEY lecturers_deleted_at (deleted_at);
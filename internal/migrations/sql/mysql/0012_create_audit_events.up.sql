@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS audit_events (
+    id         INT          NOT NULL AUTO_INCREMENT,
+    actor      VARCHAR(255) NOT NULL,
+    entity     VARCHAR(50)  NOT NULL,
+    entity_id  INT          NOT NULL,
+    action     VARCHAR(20)  NOT NULL,
+    created_at DATETIME     NOT NULL,
+    changes    TEXT         NOT NULL,
+    PRIMARY KEY (id),
+    KEY audit_events_entity (entity, entity_id)
+);
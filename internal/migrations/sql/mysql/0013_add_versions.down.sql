@@ -0,0 +1,5 @@
+ALTER TABLE lecturers
+    DROP COLUMN version;
+
+ALTER TABLE students
+    DROP COLUMN version;
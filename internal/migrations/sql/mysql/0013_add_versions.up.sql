@@ -0,0 +1,5 @@
+ALTER TABLE students
+    ADD COLUMN version INT NOT NULL DEFAULT 1;
+
+ALTER TABLE lecturers
+    ADD COLUMN version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS students (
+    id        INTEGER      NOT NULL PRIMARY KEY AUTOINCREMENT,
+    firstname VARCHAR(100) NOT NULL,
+    lastname  VARCHAR(100) NOT NULL,
+    year      INT          NOT NULL
+);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS api_keys (
+    id         INTEGER       NOT NULL PRIMARY KEY AUTOINCREMENT,
+    name       VARCHAR(100)  NOT NULL,
+    prefix     VARCHAR(32)   NOT NULL UNIQUE,
+    hash       CHAR(64)      NOT NULL,
+    scopes     VARCHAR(1000) NOT NULL,
+    created_at DATETIME      NOT NULL,
+    revoked_at DATETIME      NULL
+);
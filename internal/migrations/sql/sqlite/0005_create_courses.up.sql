@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS courses (
+    id          INTEGER      NOT NULL PRIMARY KEY AUTOINCREMENT,
+    code        VARCHAR(20)  NOT NULL UNIQUE,
+    title       VARCHAR(100) NOT NULL,
+    credits     INT          NOT NULL,
+    semester    VARCHAR(20)  NOT NULL,
+    lecturer_id INT          NOT NULL REFERENCES lecturers (id)
+);
+
+CREATE INDEX IF NOT EXISTS courses_lecturer_id ON courses (lecturer_id);
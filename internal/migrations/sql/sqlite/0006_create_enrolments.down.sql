@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS enrolments;
+
+ALTER TABLE courses DROP COLUMN capacity;
+
+ALTER TABLE courses DROP COLUMN min_year;
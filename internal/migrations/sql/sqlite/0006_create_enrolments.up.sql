@@ -0,0 +1,12 @@
+ALTER TABLE courses ADD COLUMN capacity INT NOT NULL DEFAULT 0;
+
+ALTER TABLE courses ADD COLUMN min_year INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS enrolments (
+    student_id  INT      NOT NULL REFERENCES students (id),
+    course_id   INT      NOT NULL REFERENCES courses (id),
+    enrolled_at DATETIME NOT NULL,
+    PRIMARY KEY (student_id, course_id)
+);
+
+CREATE INDEX IF NOT EXISTS enrolments_course_id ON enrolments (course_id);
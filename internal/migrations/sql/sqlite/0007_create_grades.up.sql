@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS grades (
+    student_id  INT        NOT NULL REFERENCES students (id),
+    course_id   INT        NOT NULL REFERENCES courses (id),
+    lecturer_id INT        NOT NULL REFERENCES lecturers (id),
+    grade       VARCHAR(2) NOT NULL,
+    recorded_at DATETIME   NOT NULL,
+    PRIMARY KEY (student_id, course_id)
+);
+
+CREATE INDEX IF NOT EXISTS grades_course_id ON grades (course_id);
+
+CREATE INDEX IF NOT EXISTS grades_lecturer_id ON grades (lecturer_id);
@@ -0,0 +1,36 @@
+-- SQLite can not drop a foreign key column, the tables are copied aside and
+-- created again without it. The foreign keys are checked at commit, once the
+-- lecturers the courses reference are back
+PRAGMA defer_foreign_keys = ON;
+
+CREATE TABLE staff_copy AS SELECT id, firstname, lastname, position FROM staff;
+
+DROP TABLE staff;
+
+CREATE TABLE staff (
+    id        INTEGER      NOT NULL PRIMARY KEY AUTOINCREMENT,
+    firstname VARCHAR(100) NOT NULL,
+    lastname  VARCHAR(100) NOT NULL,
+    position  VARCHAR(100) NOT NULL
+);
+
+INSERT INTO staff (id, firstname, lastname, position) SELECT id, firstname, lastname, position FROM staff_copy;
+
+DROP TABLE staff_copy;
+
+CREATE TABLE lecturers_copy AS SELECT id, firstname, lastname, year FROM lecturers;
+
+DROP TABLE lecturers;
+
+CREATE TABLE lecturers (
+    id        INTEGER      NOT NULL PRIMARY KEY AUTOINCREMENT,
+    firstname VARCHAR(100) NOT NULL,
+    lastname  VARCHAR(100) NOT NULL,
+    year      INT          NOT NULL
+);
+
+INSERT INTO lecturers (id, firstname, lastname, year) SELECT id, firstname, lastname, year FROM lecturers_copy;
+
+DROP TABLE lecturers_copy;
+
+DROP TABLE IF EXISTS departments;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS departments (
+    id      INTEGER      NOT NULL PRIMARY KEY AUTOINCREMENT,
+    name    VARCHAR(100) NOT NULL UNIQUE,
+    head_id INT          REFERENCES lecturers (id)
+);
+
+CREATE INDEX IF NOT EXISTS departments_head_id ON departments (head_id);
+
+ALTER TABLE lecturers ADD COLUMN department_id INT REFERENCES departments (id);
+
+CREATE INDEX IF NOT EXISTS lecturers_department_id ON lecturers (department_id);
+
+ALTER TABLE staff ADD COLUMN department_id INT REFERENCES departments (id);
+
+CREATE INDEX IF NOT EXISTS staff_department_id ON staff (department_id);
@@ -0,0 +1,21 @@
+DROP INDEX IF EXISTS lecturers_email;
+
+ALTER TABLE lecturers DROP COLUMN status;
+
+ALTER TABLE lecturers DROP COLUMN date_of_birth;
+
+ALTER TABLE lecturers DROP COLUMN phone;
+
+ALTER TABLE lecturers DROP COLUMN email;
+
+DROP INDEX IF EXISTS students_email;
+
+ALTER TABLE students DROP COLUMN status;
+
+ALTER TABLE students DROP COLUMN enrolment_date;
+
+ALTER TABLE students DROP COLUMN date_of_birth;
+
+ALTER TABLE students DROP COLUMN phone;
+
+ALTER TABLE students DROP COLUMN email;
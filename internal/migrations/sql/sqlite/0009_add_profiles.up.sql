@@ -0,0 +1,21 @@
+ALTER TABLE students ADD COLUMN email VARCHAR(254);
+
+ALTER TABLE students ADD COLUMN phone VARCHAR(20);
+
+ALTER TABLE students ADD COLUMN date_of_birth DATE;
+
+ALTER TABLE students ADD COLUMN enrolment_date DATE;
+
+ALTER TABLE students ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active';
+
+CREATE UNIQUE INDEX IF NOT EXISTS students_email ON students (email);
+
+ALTER TABLE lecturers ADD COLUMN email VARCHAR(254);
+
+ALTER TABLE lecturers ADD COLUMN phone VARCHAR(20);
+
+ALTER TABLE lecturers ADD COLUMN date_of_birth DATE;
+
+ALTER TABLE lecturers ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active';
+
+CREATE UNIQUE INDEX IF NOT EXISTS lecturers_email ON lecturers (email);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS staff_staff_number;
+
+ALTER TABLE staff DROP COLUMN staff_number;
+
+DROP INDEX IF EXISTS students_registration_number;
+
+ALTER TABLE students DROP COLUMN registration_number;
+
+DROP TABLE IF EXISTS number_sequences;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS number_sequences (
+    name  VARCHAR(100) NOT NULL PRIMARY KEY,
+    value INTEGER      NOT NULL
+);
+
+ALTER TABLE students ADD COLUMN registration_number VARCHAR(50);
+
+CREATE UNIQUE INDEX IF NOT EXISTS students_registration_number ON students (registration_number);
+
+ALTER TABLE staff ADD COLUMN staff_number VARCHAR(50);
+
+CREATE UNIQUE INDEX IF NOT EXISTS staff_staff_number ON staff (staff_number);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS lecturers_deleted_at;
+
+ALTER TABLE lecturers DROP COLUMN deleted_at;
+
+DROP INDEX IF EXISTS students_deleted_at;
+
+ALTER TABLE students DROP COLUMN deleted_at;
@@ -0,0 +1,7 @@
+ALTER TABLE students ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS students_deleted_at ON students (deleted_at);
+
+ALTER TABLE lecturers ADD COLUMN deleted_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS lecturers_deleted_at ON lecturers (deleted_at);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS audit_events (
+    id         INTEGER      NOT NULL PRIMARY KEY AUTOINCREMENT,
+    actor      VARCHAR(255) NOT NULL,
+    entity     VARCHAR(50)  NOT NULL,
+    entity_id  INT          NOT NULL,
+    action     VARCHAR(20)  NOT NULL,
+    created_at DATETIME     NOT NULL,
+    changes    TEXT         NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS audit_events_entity ON audit_events (entity, entity_id);
@@ -0,0 +1,3 @@
+ALTER TABLE students ADD COLUMN version INT NOT NULL DEFAULT 1;
+
+ALTER TABLE lecturers ADD COLUMN version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+)
+
+type APIKeyResponse = Response[APIKey]
+
+type APIKeyListResponse = ListResponse[APIKey]
+
+type NewAPIKeyResponse = Response[NewAPIKey]
+
+// APIKey is a credential of a service client. Only the hash of the key is
+// stored, Prefix is the public part of the key it is looked up by
+type APIKey struct {
+	ID        int        `json:"id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	Hash      string     `json:"-"`
+	Scopes    []string   `json:"scopes"`
+	CreatedAt time.Time  `json:"createdAt"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+}
+
+// Revoked tells whether the key can no longer be used
+func (k *APIKey) Revoked() bool {
+	return k.RevokedAt != nil
+}
+
+// NewAPIKey is a created or rotated key together with the key itself, which
+// is shown only once
+type NewAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// APIKeyRequest is the body of the create API key request
+type APIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+func (k *APIKeyRequest) Validate() []apperrors.FieldError {
+	var r rules
+	r.name("name", k.Name)
+	if len(k.Scopes) == 0 {
+		r.add("scopes", "at least one scope is required")
+	}
+	for i, scope := range k.Scopes {
+		if err := auth.ValidateScope(scope); err != nil {
+			r.add(fmt.Sprintf("scopes[%d]", i), err.Error())
+		}
+	}
+	return r
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditResponse = SearchResponse[AuditEvent]
+
+// AuditAction is the kind of change an audit event records
+type AuditAction string
+
+const (
+	AuditCreate  AuditAction = "create"
+	AuditUpdate  AuditAction = "update"
+	AuditDelete  AuditAction = "delete"
+	AuditRestore AuditAction = "restore"
+	AuditPurge   AuditAction = "purge"
+)
+
+// AuditEvent records a change made to a record. Actor is the subject of the
+// caller that made it, Entity the lower case resource name, eg. student, and
+// Changes the fields that changed keyed by their JSON name
+type AuditEvent struct {
+	ID        int                    `json:"id"`
+	Actor     string                 `json:"actor"`
+	Entity    string                 `json:"entity"`
+	EntityID  int                    `json:"entityId"`
+	Action    AuditAction            `json:"action"`
+	Timestamp time.Time              `json:"timestamp"`
+	Changes   map[string]AuditChange `json:"changes"`
+}
+
+// AuditChange holds the JSON values of a field before and after a change,
+// null when the field was not set
+type AuditChange struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
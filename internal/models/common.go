@@ -1,5 +1,17 @@
 package models
 
+import (
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/filter"
+)
+
+// The page size of a search that does not set one and the largest page a
+// search can ask for
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
 type Pagination struct {
 	Page     int `json:"page"`
 	PageSize int `json:"pageSize"`
@@ -9,3 +21,79 @@ type SortBy struct {
 	Column    string `json:"column"`
 	Direction string `json:"direction"`
 }
+
+type Response[T any] struct {
+	Status  string                 `json:"status"`
+	Data    T                      `json:"data"`
+	Message string                 `json:"message"`
+	Code    apperrors.Code         `json:"code,omitempty"`
+	Errors  []apperrors.FieldError `json:"errors,omitempty"`
+}
+
+// SearchRequest searches the records matching the search string. Filter
+// keeps only the records matching the filter expression, it is read from
+// the filter query parameter and never from the body
+type SearchRequest struct {
+	SearchString string      `json:"searchString"`
+	Filter       filter.Expr `json:"-"`
+	SortBy       SortBy      `json:"sortBy"`
+	Pagination   Pagination  `json:"pagination"`
+}
+
+type SearchData[T any] struct {
+	TotalElements int `json:"totalElements"`
+	Data          []T `json:"data"`
+}
+
+type SearchResponse[T any] struct {
+	Status  string                 `json:"status"`
+	Data    SearchData[T]          `json:"data"`
+	Message string                 `json:"message"`
+	Code    apperrors.Code         `json:"code,omitempty"`
+	Errors  []apperrors.FieldError `json:"errors,omitempty"`
+}
+
+type ListResponse[T any] struct {
+	Status  string                 `json:"status"`
+	Data    []T                    `json:"data"`
+	Message string                 `json:"message"`
+	Code    apperrors.Code         `json:"code,omitempty"`
+	Errors  []apperrors.FieldError `json:"errors,omitempty"`
+}
+
+// Resource holds the names and messages used when serving an entity, the
+// restore messages are only used by the soft deleted entities
+type Resource struct {
+	Name         string
+	Queried      string
+	Created      string
+	Updated      string
+	Deleted      string
+	Restored     string
+	GetError     string
+	CreateError  string
+	UpdateError  string
+	PatchError   string
+	DeleteError  string
+	RestoreError string
+	NotFound     string
+}
+
+// Versioned is implemented by the entities whose changes are counted, the
+// version is incremented by every change and starts at 1
+type Versioned interface {
+	CurrentVersion() int
+}
+
+func (s *SearchRequest) Validate() []apperrors.FieldError {
+	return s.Pagination.Validate()
+}
+
+// Validate rejects a negative page and a page size above MaxPageSize, a page
+// size of 0 is given DefaultPageSize by the search and audit usecases
+func (p Pagination) Validate() []apperrors.FieldError {
+	var r rules
+	r.min("pagination.page", p.Page, 0)
+	r.between("pagination.pageSize", p.PageSize, 0, MaxPageSize)
+	return r
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+)
+
+type CourseResponse = Response[Course]
+
+type CourseSearchRequest = SearchRequest
+
+type CourseSearchData = SearchData[Course]
+
+type CourseSearchResponse = SearchResponse[Course]
+
+type CourseListResponse = ListResponse[Course]
+
+// Course is a course taught by the lecturer with LecturerID. At most
+// Capacity students can enrol, 0 means there is no limit, and only the
+// students in MinYear or later, 0 means every year
+type Course struct {
+	ID         int    `json:"id"`
+	Code       string `json:"code"`
+	Title      string `json:"title"`
+	Credits    int    `json:"credits"`
+	Semester   string `json:"semester"`
+	LecturerID int    `json:"lecturerId"`
+	Capacity   int    `json:"capacity"`
+	MinYear    int    `json:"minYear"`
+}
+
+var CourseResource = Resource{
+	Name:        "Course",
+	Queried:     consts.GetCourse,
+	Created:     consts.CourseCreated,
+	Updated:     consts.CourseUpdated,
+	Deleted:     consts.CourseDeleted,
+	GetError:    consts.GetCoursesError,
+	CreateError: consts.CourseCreateError,
+	UpdateError: consts.CourseUpdateError,
+	PatchError:  consts.CoursePatchError,
+	DeleteError: consts.CourseDeleteError,
+	NotFound:    consts.CourseNotFound,
+}
+
+func (c *Course) Validate() []apperrors.FieldError {
+	var r rules
+	r.text("code", c.Code, maxCodeLength)
+	r.name("title", c.Title)
+	r.between("credits", c.Credits, 1, MaxCredits)
+	r.text("semester", c.Semester, maxCodeLength)
+	r.min("lecturerId", c.LecturerID, 1)
+	r.min("capacity", c.Capacity, 0)
+	r.between("minYear", c.MinYear, 0, MaxYear)
+	return r
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DateLayout is how a Date is written in JSON and stored in the databases
+const DateLayout = "2006-01-02"
+
+// Date is a calendar day without a time of day, eg. a date of birth
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the date of the given day
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// ParseDate parses a date written like DateLayout
+func ParseDate(value string) (Date, error) {
+	t, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{t}, nil
+}
+
+func (d Date) String() string {
+	return d.Format(DateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	parsed, err := ParseDate(value)
+	if err != nil {
+		return fmt.Errorf("%q is not a date like %s", value, DateLayout)
+	}
+	*d = parsed
+	return nil
+}
+
+// Value stores the date as text, which both MySQL DATE columns and SQLite
+// accept
+func (d Date) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan reads a date returned as a time by MySQL with parseTime and by
+// SQLite, or as text otherwise
+func (d *Date) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case time.Time:
+		*d = NewDate(src.Year(), src.Month(), src.Day())
+		return nil
+	case string:
+		return d.scanText(src)
+	case []byte:
+		return d.scanText(string(src))
+	}
+	return fmt.Errorf("cannot scan %T into a date", src)
+}
+
+func (d *Date) scanText(value string) error {
+	if len(value) > len(DateLayout) {
+		value = value[:len(DateLayout)]
+	}
+	parsed, err := ParseDate(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
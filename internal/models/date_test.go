@@ -0,0 +1,50 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDate_JSON(t *testing.T) {
+	var profile struct {
+		DateOfBirth *Date `json:"dateOfBirth"`
+	}
+
+	err := json.Unmarshal([]byte(`{"dateOfBirth":"1997-10-16"}`), &profile)
+	if err != nil || *profile.DateOfBirth != NewDate(1997, time.October, 16) {
+		t.Errorf("Expected 1997-10-16, but got %v, %v", profile.DateOfBirth, err)
+	}
+
+	body, err := json.Marshal(profile)
+	if err != nil || string(body) != `{"dateOfBirth":"1997-10-16"}` {
+		t.Errorf("Expected the date to be written back, but got %s, %v", body, err)
+	}
+
+	err = json.Unmarshal([]byte(`{"dateOfBirth":"1997-02-30"}`), &profile)
+	if err == nil {
+		t.Errorf("Expected an error for a day that does not exist")
+	}
+}
+
+func TestDate_Scan(t *testing.T) {
+	expected := NewDate(1997, time.October, 16)
+
+	testCases := []struct {
+		name string
+		src  interface{}
+	}{
+		{name: "Time", src: time.Date(1997, time.October, 16, 0, 0, 0, 0, time.Local)},
+		{name: "Text", src: "1997-10-16"},
+		{name: "Bytes", src: []byte("1997-10-16")},
+		{name: "Text With Time", src: "1997-10-16 00:00:00"},
+	}
+
+	for _, test := range testCases {
+		var actual Date
+		err := actual.Scan(test.src)
+		if err != nil || actual != expected {
+			t.Errorf("Test %s : Expected %v, but got %v, %v", test.name, expected, actual, err)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+)
+
+type DepartmentResponse = Response[Department]
+
+type DepartmentSearchData = SearchData[Department]
+
+type DepartmentSearchResponse = SearchResponse[Department]
+
+type DepartmentListResponse = ListResponse[Department]
+
+// Department groups lecturers and staff, it is headed by one of its
+// lecturers or by nobody when HeadID is nil
+type Department struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	HeadID *int   `json:"headId"`
+}
+
+var DepartmentResource = Resource{
+	Name:        "Department",
+	Queried:     consts.GetDepartment,
+	Created:     consts.DepartmentCreated,
+	Updated:     consts.DepartmentUpdated,
+	Deleted:     consts.DepartmentDeleted,
+	GetError:    consts.GetDepartmentsError,
+	CreateError: consts.DepartmentCreateError,
+	UpdateError: consts.DepartmentUpdateError,
+	PatchError:  consts.DepartmentPatchError,
+	DeleteError: consts.DepartmentDeleteError,
+	NotFound:    consts.DepartmentNotFound,
+}
+
+func (d *Department) Validate() []apperrors.FieldError {
+	var r rules
+	r.name("name", d.Name)
+	r.optionalID("headId", d.HeadID)
+	return r
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+)
+
+type EnrolmentResponse = Response[Enrolment]
+
+type EnrolmentListResponse = ListResponse[Enrolment]
+
+// Enrolment links a student to a course they take
+type Enrolment struct {
+	StudentID  int       `json:"studentId"`
+	CourseID   int       `json:"courseId"`
+	EnrolledAt time.Time `json:"enrolledAt"`
+}
+
+// EnrolmentRequest is the body of the enrol request, the student is taken
+// from the path
+type EnrolmentRequest struct {
+	CourseID int `json:"courseId"`
+}
+
+func (e *EnrolmentRequest) Validate() []apperrors.FieldError {
+	var r rules
+	r.min("courseId", e.CourseID, 1)
+	return r
+}
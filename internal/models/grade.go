@@ -0,0 +1,104 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+)
+
+type GradeResponse = Response[Grade]
+
+type GradeListResponse = ListResponse[Grade]
+
+type TranscriptResponse = Response[Transcript]
+
+// GradePoints maps the letter grades to their grade points
+var GradePoints = map[string]float64{
+	"A+": 4.0, "A": 4.0, "A-": 3.7,
+	"B+": 3.3, "B": 3.0, "B-": 2.7,
+	"C+": 2.3, "C": 2.0, "C-": 1.7,
+	"D+": 1.3, "D": 1.0,
+	"F": 0.0,
+}
+
+// Grade is the grade a student got for a course, recorded by the lecturer
+// with LecturerID
+type Grade struct {
+	StudentID  int       `json:"studentId"`
+	CourseID   int       `json:"courseId"`
+	LecturerID int       `json:"lecturerId"`
+	Grade      string    `json:"grade"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// GradeChange is the body of the update grade request, the student and the
+// course are taken from the path and the lecturer from the caller
+type GradeChange struct {
+	Grade string `json:"grade"`
+}
+
+func (g *GradeChange) Validate() []apperrors.FieldError {
+	var r rules
+	if _, ok := GradePoints[g.Grade]; !ok {
+		r.add("grade", "must be one of "+strings.Join(letterGrades(), ", "))
+	}
+	return r
+}
+
+// GradeRequest is the body of the record grade request
+type GradeRequest struct {
+	CourseID int `json:"courseId"`
+	GradeChange
+}
+
+func (g *GradeRequest) Validate() []apperrors.FieldError {
+	var r rules
+	r.min("courseId", g.CourseID, 1)
+	return append(r, g.GradeChange.Validate()...)
+}
+
+// letterGrades returns the letter grades from the best to the worst
+func letterGrades() []string {
+	letters := make([]string, 0, len(GradePoints))
+	for letter := range GradePoints {
+		letters = append(letters, letter)
+	}
+	sort.Slice(letters, func(i, j int) bool {
+		a, b := letters[i], letters[j]
+		if GradePoints[a] != GradePoints[b] {
+			return GradePoints[a] > GradePoints[b]
+		}
+		return strings.HasSuffix(a, "+") || strings.HasSuffix(b, "-")
+	})
+	return letters
+}
+
+// TranscriptLine is a graded course on a transcript
+type TranscriptLine struct {
+	CourseID int     `json:"courseId"`
+	Code     string  `json:"code"`
+	Title    string  `json:"title"`
+	Semester string  `json:"semester"`
+	Credits  int     `json:"credits"`
+	Grade    string  `json:"grade"`
+	Points   float64 `json:"points"`
+}
+
+// SemesterResult holds the graded courses of a semester and their GPA
+type SemesterResult struct {
+	Semester string           `json:"semester"`
+	Courses  []TranscriptLine `json:"courses"`
+	Credits  int              `json:"credits"`
+	GPA      float64          `json:"gpa"`
+}
+
+// Transcript is the academic record of a student, the GPA is weighted by
+// the credits of the courses
+type Transcript struct {
+	Student   Student          `json:"student"`
+	Semesters []SemesterResult `json:"semesters"`
+	Credits   int              `json:"credits"`
+	GPA       float64          `json:"gpa"`
+}
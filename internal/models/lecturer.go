@@ -1,37 +1,80 @@
 package models
 
-type LecturerResponse struct {
-	Status  string   `json:"status"`
-	Data    Lecturer `json:"data"`
-	Message string   `json:"message"`
-}
+import (
+	"time"
+
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+)
+
+type LecturerResponse = Response[Lecturer]
+
+type LecturerSearchRequest = SearchRequest
+
+type LecturerSearchData = SearchData[Lecturer]
 
-type LecturerSearchRequest struct {
-	SearchString string     `json:"searchString"`
-	SortBy       SortBy     `json:"sortBy"`
-	Pagination   Pagination `json:"pagination"`
+type LecturerSearchResponse = SearchResponse[Lecturer]
+
+type LecturerListResponse = ListResponse[Lecturer]
+
+// LecturerStatuses are the statuses a lecturer can have
+var LecturerStatuses = []string{StatusActive, "on_leave", "retired"}
+
+// Lecturer belongs to the department with DepartmentID, or to none when it
+// is nil. The profile fields other than Status may be null like those of a
+// Student, and so is DeletedAt. Version counts the changes like that of a
+// Student
+type Lecturer struct {
+	ID           int        `json:"id"`
+	FirstName    string     `json:"firstname"`
+	LastName     string     `json:"lastname"`
+	Year         int        `json:"year"`
+	DepartmentID *int       `json:"departmentId"`
+	Email        *string    `json:"email"`
+	Phone        *string    `json:"phone"`
+	DateOfBirth  *Date      `json:"dateOfBirth"`
+	Status       string     `json:"status"`
+	DeletedAt    *time.Time `json:"deletedAt,omitempty"`
+	Version      int        `json:"-"`
 }
 
-type LecturerSearchData struct {
-	TotalElements int        `json:"totalElements"`
-	Data          []Lecturer `json:"data"`
+var LecturerResource = Resource{
+	Name:         "Lecturer",
+	Queried:      consts.GetLecturer,
+	Created:      consts.LecturerCreated,
+	Updated:      consts.LecturerUpdated,
+	Deleted:      consts.LecturerDeleted,
+	Restored:     consts.LecturerRestored,
+	GetError:     consts.GetLecturersError,
+	CreateError:  consts.LecturerCreateError,
+	UpdateError:  consts.LecturerUpdateError,
+	PatchError:   consts.LecturerPatchError,
+	DeleteError:  consts.LecturerDeleteError,
+	RestoreError: consts.LecturerRestoreError,
+	NotFound:     consts.LecturerNotFound,
 }
 
-type LecturerSearchResponse struct {
-	Status  string             `json:"status"`
-	Data    LecturerSearchData `json:"data"`
-	Message string             `json:"message"`
+func (l Lecturer) CurrentVersion() int {
+	return l.Version
 }
 
-type LecturerListResponse struct {
-	Status  string     `json:"status"`
-	Data    []Lecturer `json:"data"`
-	Message string     `json:"message"`
+// Normalize lower cases the email and makes a lecturer without a status active
+func (l *Lecturer) Normalize() {
+	normalizeEmail(l.Email)
+	if l.Status == "" {
+		l.Status = StatusActive
+	}
 }
 
-type Lecturer struct {
-	ID        int    `json:"id"`
-	FirstName string `json:"firstname"`
-	LastName  string `json:"lastname"`
-	Year      int    `json:"year"`
+func (l *Lecturer) Validate() []apperrors.FieldError {
+	var r rules
+	r.name("firstname", l.FirstName)
+	r.name("lastname", l.LastName)
+	r.min("year", l.Year, 0)
+	r.optionalID("departmentId", l.DepartmentID)
+	r.email("email", l.Email)
+	r.phone("phone", l.Phone)
+	r.past("dateOfBirth", l.DateOfBirth)
+	r.oneOf("status", l.Status, LecturerStatuses)
+	return r
 }
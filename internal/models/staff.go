@@ -0,0 +1,52 @@
+package models
+
+import (
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+)
+
+type StaffResponse = Response[Staff]
+
+type StaffSearchRequest = SearchRequest
+
+type StaffSearchData = SearchData[Staff]
+
+type StaffSearchResponse = SearchResponse[Staff]
+
+type StaffListResponse = ListResponse[Staff]
+
+// Staff belongs to the department with DepartmentID, or to none when it is
+// nil. The StaffNumber is generated when the staff member is created and
+// never changes, the staff created before the numbers were added are
+// numbered when the server starts
+type Staff struct {
+	ID           int     `json:"id"`
+	StaffNumber  *string `json:"staffNumber"`
+	FirstName    string  `json:"firstname"`
+	LastName     string  `json:"lastname"`
+	Position     string  `json:"position"`
+	DepartmentID *int    `json:"departmentId"`
+}
+
+var StaffResource = Resource{
+	Name:        "Staff",
+	Queried:     consts.GetStaff,
+	Created:     consts.StaffCreated,
+	Updated:     consts.StaffUpdated,
+	Deleted:     consts.StaffDeleted,
+	GetError:    consts.GetStaffError,
+	CreateError: consts.StaffCreateError,
+	UpdateError: consts.StaffUpdateError,
+	PatchError:  consts.StaffPatchError,
+	DeleteError: consts.StaffDeleteError,
+	NotFound:    consts.StaffNotFound,
+}
+
+func (s *Staff) Validate() []apperrors.FieldError {
+	var r rules
+	r.name("firstname", s.FirstName)
+	r.name("lastname", s.LastName)
+	r.name("position", s.Position)
+	r.optionalID("departmentId", s.DepartmentID)
+	return r
+}
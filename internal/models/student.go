@@ -1,37 +1,89 @@
 package models
 
-type StudentResponse struct {
-	Status  string  `json:"status"`
-	Data    Student `json:"data"`
-	Message string  `json:"message"`
-}
+import (
+	"time"
+
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+)
+
+type StudentResponse = Response[Student]
+
+type StudentSearchRequest = SearchRequest
+
+type StudentSearchData = SearchData[Student]
+
+type StudentSearchResponse = SearchResponse[Student]
 
-type StudentSearchRequest struct {
-	SearchString string     `json:"searchString"`
-	SortBy       SortBy     `json:"sortBy"`
-	Pagination   Pagination `json:"pagination"`
+type StudentListResponse = ListResponse[Student]
+
+// StatusActive is the status of a student or lecturer when none is given
+const StatusActive = "active"
+
+// StudentStatuses are the statuses a student can have
+var StudentStatuses = []string{StatusActive, "suspended", "graduated", "withdrawn"}
+
+// Student is in its Year of study. The RegistrationNumber is generated when
+// the student is created and never changes, the students created before the
+// numbers were added are numbered when the server starts. The profile
+// fields other than Status may be null, the students stored before they
+// were added have none. DeletedAt is set when the student is soft deleted.
+// Version counts the changes made to the student, it is served as the ETag
+// rather than in the body
+type Student struct {
+	ID                 int        `json:"id"`
+	RegistrationNumber *string    `json:"registrationNumber"`
+	FirstName          string     `json:"firstname"`
+	LastName           string     `json:"lastname"`
+	Year               int        `json:"year"`
+	Email              *string    `json:"email"`
+	Phone              *string    `json:"phone"`
+	DateOfBirth        *Date      `json:"dateOfBirth"`
+	EnrolmentDate      *Date      `json:"enrolmentDate"`
+	Status             string     `json:"status"`
+	DeletedAt          *time.Time `json:"deletedAt,omitempty"`
+	Version            int        `json:"-"`
 }
 
-type StudentSearchData struct {
-	TotalElements int       `json:"totalElements"`
-	Data          []Student `json:"data"`
+var StudentResource = Resource{
+	Name:         "Student",
+	Queried:      consts.GetStudent,
+	Created:      consts.StudentCreated,
+	Updated:      consts.StudentUpdated,
+	Deleted:      consts.StudentDeleted,
+	Restored:     consts.StudentRestored,
+	GetError:     consts.GetStudentsError,
+	CreateError:  consts.StudentCreateError,
+	UpdateError:  consts.StudentUpdateError,
+	PatchError:   consts.StudentPatchError,
+	DeleteError:  consts.StudentDeleteError,
+	RestoreError: consts.StudentRestoreError,
+	NotFound:     consts.StudentNotFound,
 }
 
-type StudentSearchResponse struct {
-	Status  string            `json:"status"`
-	Data    StudentSearchData `json:"data"`
-	Message string            `json:"message"`
+func (s Student) CurrentVersion() int {
+	return s.Version
 }
 
-type StudentListResponse struct {
-	Status  string    `json:"status"`
-	Data    []Student `json:"data"`
-	Message string    `json:"message"`
+// Normalize lower cases the email and makes a student without a status active
+func (s *Student) Normalize() {
+	normalizeEmail(s.Email)
+	if s.Status == "" {
+		s.Status = StatusActive
+	}
 }
 
-type Student struct {
-	ID        int    `json:"id"`
-	FirstName string `json:"firstname"`
-	LastName  string `json:"lastname"`
-	Year      int    `json:"year"`
+func (s *Student) Validate() []apperrors.FieldError {
+	var r rules
+	r.name("firstname", s.FirstName)
+	r.name("lastname", s.LastName)
+	r.between("year", s.Year, MinYear, MaxYear)
+	r.email("email", s.Email)
+	r.phone("phone", s.Phone)
+	r.past("dateOfBirth", s.DateOfBirth)
+	if s.DateOfBirth != nil && s.EnrolmentDate != nil && !s.EnrolmentDate.After(s.DateOfBirth.Time) {
+		r.add("enrolmentDate", "must be after the date of birth")
+	}
+	r.oneOf("status", s.Status, StudentStatuses)
+	return r
 }
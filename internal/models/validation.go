@@ -0,0 +1,123 @@
+package models
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+)
+
+const (
+	maxNameLength = 100
+	maxCodeLength = 20
+	// maxEmailLength is the longest address SMTP can deliver to
+	maxEmailLength = 254
+	MinYear        = 1
+	MaxYear        = 6
+	MaxCredits     = 30
+)
+
+// phonePattern allows an optional leading + then digits, spaces, dashes and
+// brackets, eg. +44 (0)20 7946 0958
+var phonePattern = regexp.MustCompile(`^\+?[0-9 ()-]{7,20}$`)
+
+// Validator is implemented by the request bodies that have validation rules.
+// Validate returns the rejected fields, or nil when the body is valid
+type Validator interface {
+	Validate() []apperrors.FieldError
+}
+
+// Normalizer is implemented by the request bodies whose fields have a
+// canonical form or a default. Normalize is called before Validate
+type Normalizer interface {
+	Normalize()
+}
+
+// normalizeEmail trims and lower cases an address, so the unique index
+// treats addresses differing only in case as the same in every database
+func normalizeEmail(email *string) {
+	if email != nil {
+		*email = strings.ToLower(strings.TrimSpace(*email))
+	}
+}
+
+// rules collects the field errors of a request body
+type rules []apperrors.FieldError
+
+func (r *rules) add(field string, message string) {
+	*r = append(*r, apperrors.FieldError{Field: field, Message: message})
+}
+
+func (r *rules) name(field string, value string) {
+	r.text(field, value, maxNameLength)
+}
+
+// text requires a value of at most max characters
+func (r *rules) text(field string, value string, max int) {
+	switch {
+	case strings.TrimSpace(value) == "":
+		r.add(field, "is required")
+	case len(value) > max:
+		r.add(field, fmt.Sprintf("must be at most %d characters", max))
+	}
+}
+
+func (r *rules) between(field string, value int, min int, max int) {
+	if value < min || value > max {
+		r.add(field, fmt.Sprintf("must be between %d and %d", min, max))
+	}
+}
+
+func (r *rules) min(field string, value int, min int) {
+	if value < min {
+		r.add(field, fmt.Sprintf("must be at least %d", min))
+	}
+}
+
+// optionalID requires a valid id when a value that may be null is set
+func (r *rules) optionalID(field string, value *int) {
+	if value != nil {
+		r.min(field, *value, 1)
+	}
+}
+
+// oneOf requires the value to be one of allowed
+func (r *rules) oneOf(field string, value string, allowed []string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	r.add(field, "must be one of "+strings.Join(allowed, ", "))
+}
+
+// email requires a bare address, eg. charles@example.com, when it is set
+func (r *rules) email(field string, value *string) {
+	if value == nil {
+		return
+	}
+	if len(*value) > maxEmailLength {
+		r.add(field, fmt.Sprintf("must be at most %d characters", maxEmailLength))
+		return
+	}
+	address, err := mail.ParseAddress(*value)
+	if err != nil || address.Address != *value {
+		r.add(field, "must be an email address")
+	}
+}
+
+func (r *rules) phone(field string, value *string) {
+	if value != nil && !phonePattern.MatchString(*value) {
+		r.add(field, "must be a phone number")
+	}
+}
+
+// past requires a date before today when it is set
+func (r *rules) past(field string, value *Date) {
+	if value != nil && !value.Before(time.Now().UTC().Truncate(24*time.Hour)) {
+		r.add(field, "must be in the past")
+	}
+}
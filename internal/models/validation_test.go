@@ -0,0 +1,167 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+)
+
+func ptr(value string) *string {
+	return &value
+}
+
+func TestValidate(t *testing.T) {
+	birthday := NewDate(1997, time.October, 16)
+	enrolled := NewDate(2016, time.September, 1)
+	tomorrow := Date{time.Now().UTC().AddDate(0, 0, 1)}
+
+	testCases := []struct {
+		name     string
+		body     Validator
+		expected []apperrors.FieldError
+	}{
+		{
+			name:     "Valid Student",
+			body:     &Student{FirstName: "Charles", LastName: "Leclerc", Year: 3},
+			expected: nil,
+		},
+		{
+			name: "Empty Student",
+			body: &Student{},
+			expected: []apperrors.FieldError{
+				{Field: "firstname", Message: "is required"},
+				{Field: "lastname", Message: "is required"},
+				{Field: "year", Message: "must be between 1 and 6"},
+			},
+		},
+		{
+			name: "Blank Name And Year Out Of Range",
+			body: &Student{FirstName: "  ", LastName: "Leclerc", Year: 12},
+			expected: []apperrors.FieldError{
+				{Field: "firstname", Message: "is required"},
+				{Field: "year", Message: "must be between 1 and 6"},
+			},
+		},
+		{
+			name: "Name Too Long",
+			body: &Lecturer{FirstName: strings.Repeat("a", 101), LastName: "Leclerc"},
+			expected: []apperrors.FieldError{
+				{Field: "firstname", Message: "must be at most 100 characters"},
+			},
+		},
+		{
+			name: "Negative Lecturer Year",
+			body: &Lecturer{FirstName: "Charles", LastName: "Leclerc", Year: -1},
+			expected: []apperrors.FieldError{
+				{Field: "year", Message: "must be at least 0"},
+			},
+		},
+		{
+			name: "Staff Without Position",
+			body: &Staff{FirstName: "Toto", LastName: "Wolff"},
+			expected: []apperrors.FieldError{
+				{Field: "position", Message: "is required"},
+			},
+		},
+		{
+			name: "Valid Course",
+			body: &Course{Code: "CS101", Title: "Programming", Credits: 3, Semester: "2023-S1",
+				LecturerID: 1},
+			expected: nil,
+		},
+		{
+			name: "Course Without Lecturer",
+			body: &Course{Code: strings.Repeat("C", 21), Title: "Programming", Credits: 31, Semester: "2023-S1"},
+			expected: []apperrors.FieldError{
+				{Field: "code", Message: "must be at most 20 characters"},
+				{Field: "credits", Message: "must be between 1 and 30"},
+				{Field: "lecturerId", Message: "must be at least 1"},
+			},
+		},
+		{
+			name:     "Valid Grade",
+			body:     &GradeRequest{CourseID: 2, GradeChange: GradeChange{Grade: "B+"}},
+			expected: nil,
+		},
+		{
+			name: "Grade Off The Scale",
+			body: &GradeRequest{GradeChange: GradeChange{Grade: "b"}},
+			expected: []apperrors.FieldError{
+				{Field: "courseId", Message: "must be at least 1"},
+				{Field: "grade", Message: "must be one of A+, A, A-, B+, B, B-, C+, C, C-, D+, D, F"},
+			},
+		},
+		{
+			name: "Valid Student Profile",
+			body: &Student{FirstName: "Charles", LastName: "Leclerc", Year: 3, Email: ptr(" Charles@Example.com "),
+				Phone: ptr("+377 93 15 00 00"), DateOfBirth: &birthday, EnrolmentDate: &enrolled, Status: "graduated"},
+			expected: nil,
+		},
+		{
+			name: "Invalid Student Profile",
+			body: &Student{FirstName: "Charles", LastName: "Leclerc", Year: 3, Email: ptr("Charles <c@example.com>"),
+				Phone: ptr("call me"), DateOfBirth: &enrolled, EnrolmentDate: &birthday, Status: "expelled"},
+			expected: []apperrors.FieldError{
+				{Field: "email", Message: "must be an email address"},
+				{Field: "phone", Message: "must be a phone number"},
+				{Field: "enrolmentDate", Message: "must be after the date of birth"},
+				{Field: "status", Message: "must be one of active, suspended, graduated, withdrawn"},
+			},
+		},
+		{
+			name: "Lecturer Born Tomorrow",
+			body: &Lecturer{FirstName: "Adrian", LastName: "Newey", Year: 30, DateOfBirth: &tomorrow,
+				Status: "on_leave"},
+			expected: []apperrors.FieldError{
+				{Field: "dateOfBirth", Message: "must be in the past"},
+			},
+		},
+		{
+			name:     "Department Without Head",
+			body:     &Department{Name: "Aerodynamics"},
+			expected: nil,
+		},
+		{
+			name: "Department With Invalid Head",
+			body: &Department{HeadID: new(int)},
+			expected: []apperrors.FieldError{
+				{Field: "name", Message: "is required"},
+				{Field: "headId", Message: "must be at least 1"},
+			},
+		},
+		{
+			name: "Staff With Invalid Department",
+			body: &Staff{FirstName: "Toto", LastName: "Wolff", Position: "Registrar", DepartmentID: new(int)},
+			expected: []apperrors.FieldError{
+				{Field: "departmentId", Message: "must be at least 1"},
+			},
+		},
+		{
+			name: "Negative Page Size",
+			body: &SearchRequest{Pagination: Pagination{Page: 0, PageSize: -2}},
+			expected: []apperrors.FieldError{
+				{Field: "pagination.pageSize", Message: "must be between 0 and 100"},
+			},
+		},
+		{
+			name: "Page Size Above The Maximum",
+			body: &SearchRequest{Pagination: Pagination{Page: 0, PageSize: MaxPageSize + 1}},
+			expected: []apperrors.FieldError{
+				{Field: "pagination.pageSize", Message: "must be between 0 and 100"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		if normalizer, ok := test.body.(Normalizer); ok {
+			normalizer.Normalize()
+		}
+		actual := test.body.Validate()
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Test %s : Expected %v, but got %v", test.name, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+// APIKeyRepository stores the API keys of the service clients
+type APIKeyRepository interface {
+	GetAll(ctx context.Context) ([]models.APIKey, error)
+	Get(ctx context.Context, id int) (*models.APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error)
+	Create(ctx context.Context, key *models.APIKey) (*models.APIKey, error)
+	// Rotate replaces the prefix and hash of the key
+	Rotate(ctx context.Context, id int, prefix string, hash string) (*models.APIKey, error)
+	// Revoke marks the key as revoked at the given time, a key that is
+	// already revoked keeps its revocation time
+	Revoke(ctx context.Context, id int, at time.Time) (*models.APIKey, error)
+}
+
+const apiKeyColumns = "id, name, prefix, hash, scopes, created_at, revoked_at"
+
+type apiKeyRepository struct {
+	db *sql.DB
+}
+
+func NewAPIKeyRepository(db *sql.DB) *apiKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+func (s *apiKeyRepository) GetAll(ctx context.Context) ([]models.APIKey, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+apiKeyColumns+" FROM api_keys ORDER BY id;")
+	if err != nil {
+		log.Error(consts.DBResultsError, err)
+		return nil, dbError(ctx, err)
+	}
+	defer closeRows(rows)
+
+	var list []models.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			log.Error(consts.DBScanRowError, err)
+			return nil, dbError(ctx, err)
+		}
+		list = append(list, *key)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Error(consts.DBRowsError, err)
+		return nil, dbError(ctx, err)
+	}
+	return list, nil
+}
+
+func (s *apiKeyRepository) Get(ctx context.Context, id int) (*models.APIKey, error) {
+	return s.getBy(ctx, "id", id)
+}
+
+func (s *apiKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	return s.getBy(ctx, "prefix", prefix)
+}
+
+func (s *apiKeyRepository) getBy(ctx context.Context, column string, value interface{}) (*models.APIKey, error) {
+	row := s.db.QueryRowContext(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE "+column+" = ?;", value)
+
+	key, err := scanAPIKey(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NotFound(consts.APIKeyNotFound)
+		}
+		log.Error(consts.DBResultsError, err)
+		return nil, dbError(ctx, err)
+	}
+	return key, nil
+}
+
+func (s *apiKeyRepository) Create(ctx context.Context, key *models.APIKey) (*models.APIKey, error) {
+	result, err := s.db.ExecContext(ctx, "INSERT INTO api_keys (name, prefix, hash, scopes, created_at) "+
+		"VALUES (?, ?, ?, ?, ?);", key.Name, key.Prefix, key.Hash, strings.Join(key.Scopes, ","), key.CreatedAt)
+	if err != nil {
+		log.Error(consts.DBResultsError, err)
+		return nil, dbError(ctx, err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		log.Error(consts.DBResultIDError, err)
+		return nil, dbError(ctx, err)
+	}
+
+	key.ID = int(id)
+	return key, nil
+}
+
+func (s *apiKeyRepository) Rotate(ctx context.Context, id int, prefix string, hash string) (*models.APIKey, error) {
+	result, err := s.db.ExecContext(ctx, "UPDATE api_keys SET prefix = ?, hash = ? WHERE id = ?;",
+		prefix, hash, id)
+	if err != nil {
+		log.Error(consts.DBResultsError, err)
+		return nil, dbError(ctx, err)
+	}
+
+	err = checkAPIKeyAffected(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, id)
+}
+
+func (s *apiKeyRepository) Revoke(ctx context.Context, id int, at time.Time) (*models.APIKey, error) {
+	result, err := s.db.ExecContext(ctx, "UPDATE api_keys SET revoked_at = COALESCE(revoked_at, ?) WHERE id = ?;",
+		at, id)
+	if err != nil {
+		log.Error(consts.DBResultsError, err)
+		return nil, dbError(ctx, err)
+	}
+
+	err = checkAPIKeyAffected(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, id)
+}
+
+func checkAPIKeyAffected(ctx context.Context, result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		log.Error(consts.DBRowsAffectedError, err)
+		return dbError(ctx, err)
+	}
+	if affected == 0 {
+		return apperrors.NotFound(consts.APIKeyNotFound)
+	}
+	return nil
+}
+
+// scanner is a *sql.Row or *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanAPIKey reads a row selected with apiKeyColumns
+func scanAPIKey(row scanner) (*models.APIKey, error) {
+	var key models.APIKey
+	var scopes string
+	var revokedAt sql.NullTime
+
+	err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.Hash, &scopes, &key.CreatedAt, &revokedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if scopes != "" {
+		key.Scopes = strings.Split(scopes, ",")
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return &key, nil
+}
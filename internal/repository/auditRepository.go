@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+// The actors of the changes not made by an authenticated caller, the purge
+// job and the requests served with authentication disabled
+const (
+	systemActor    = "system"
+	anonymousActor = "anonymous"
+)
+
+// AuditRepository reads the audit events, they are written by the
+// repositories of the audited entities in the transaction of each change
+// and never updated or deleted
+type AuditRepository interface {
+	// List returns a page of the events of the entity, newest first. An empty
+	// entity lists the events of every entity and an entityID of 0 the events
+	// of every record of the entity
+	List(ctx context.Context, entity string, entityID int,
+		pagination models.Pagination) (*models.SearchData[models.AuditEvent], error)
+}
+
+const auditColumns = "id, actor, entity, entity_id, action, created_at, changes"
+
+type auditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) *auditRepository {
+	return &auditRepository{
+		db: db,
+	}
+}
+
+func (s *auditRepository) List(ctx context.Context, entity string, entityID int,
+	pagination models.Pagination) (*models.SearchData[models.AuditEvent], error) {
+
+	err := checkPagination(pagination)
+	if err != nil {
+		log.Error(consts.InvalidSearchError, err)
+		return nil, err
+	}
+
+	var conditions []string
+	var args []interface{}
+	if entity != "" {
+		conditions = append(conditions, "entity = ?")
+		args = append(args, entity)
+	}
+	if entityID != 0 {
+		conditions = append(conditions, "entity_id = ?")
+		args = append(args, entityID)
+	}
+	where := ""
+	if len(conditions) != 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, pagination.Page, pagination.PageSize)
+
+	rows, err := s.db.QueryContext(ctx, "SELECT "+auditColumns+", Count(*) Over () AS TotalCount FROM audit_events"+
+		where+" ORDER BY id DESC LIMIT ?,?;", args...)
+	if err != nil {
+		log.Error(consts.DBResultsError, err)
+		return nil, dbError(ctx, err)
+	}
+	defer closeRows(rows)
+
+	var resp models.SearchData[models.AuditEvent]
+	for rows.Next() {
+		var event models.AuditEvent
+		var changes string
+
+		err := rows.Scan(&event.ID, &event.Actor, &event.Entity, &event.EntityID, &event.Action,
+			&event.Timestamp, &changes, &resp.TotalElements)
+		if err != nil {
+			log.Error(consts.DBScanRowError, err)
+			return nil, dbError(ctx, err)
+		}
+
+		err = json.Unmarshal([]byte(changes), &event.Changes)
+		if err != nil {
+			log.Error(consts.DBScanRowError, err)
+			return nil, apperrors.Internal(err)
+		}
+		resp.Data = append(resp.Data, event)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Error(consts.DBRowsError, err)
+		return nil, dbError(ctx, err)
+	}
+
+	log.Debug("list audit_events response : ", resp)
+	return &resp, nil
+}
+
+// insertAuditEvent writes the event in the transaction of the change it records
+func insertAuditEvent(ctx context.Context, tx *sql.Tx, event models.AuditEvent) error {
+	changes, err := json.Marshal(event.Changes)
+	if err != nil {
+		log.Error(consts.AuditError, err)
+		return apperrors.Internal(err)
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT INTO audit_events (actor, entity, entity_id, action, created_at, changes) "+
+		"VALUES (?, ?, ?, ?, ?, ?);", event.Actor, event.Entity, event.EntityID, event.Action, event.Timestamp,
+		string(changes))
+	if err != nil {
+		log.Error(consts.DBResultsError, err)
+		return dbError(ctx, err)
+	}
+	return nil
+}
+
+// actor returns the subject of the caller of ctx
+func actor(ctx context.Context) string {
+	subject := auth.SubjectFrom(ctx)
+	if subject == "" {
+		return anonymousActor
+	}
+	return subject
+}
+
+// newAuditEvent returns the event of a change of a record of table from
+// before to after, before is nil for a create and after for a purge
+func newAuditEvent[T any](table Table[T], actor string, action models.AuditAction, before *T,
+	after *T) (models.AuditEvent, error) {
+
+	record := after
+	if record == nil {
+		record = before
+	}
+
+	changes, err := diff(before, after)
+	if err != nil {
+		log.Error(consts.AuditError, err)
+		return models.AuditEvent{}, apperrors.Internal(err)
+	}
+
+	return models.AuditEvent{
+		Actor:     actor,
+		Entity:    table.entity(),
+		EntityID:  *table.ID(record),
+		Action:    action,
+		Timestamp: dbNow(),
+		Changes:   changes,
+	}, nil
+}
+
+var jsonNull = json.RawMessage("null")
+
+// diff returns the JSON fields whose value differs between before and
+// after, a field missing on one side is null there. Fields that are null on
+// both sides are left out, so a create only lists the fields that were set
+func diff(before interface{}, after interface{}) (map[string]models.AuditChange, error) {
+	b, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]models.AuditChange{}
+	for name, value := range b {
+		if after := orNull(a[name]); !bytes.Equal(value, after) {
+			changes[name] = models.AuditChange{Before: value, After: after}
+		}
+	}
+	for name, value := range a {
+		if _, ok := b[name]; !ok && !bytes.Equal(value, jsonNull) {
+			changes[name] = models.AuditChange{Before: jsonNull, After: value}
+		}
+	}
+	return changes, nil
+}
+
+// jsonFields returns the fields of the JSON form of v, none when v is nil
+func jsonFields(v interface{}) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(data, &fields)
+	return fields, err
+}
+
+func orNull(value json.RawMessage) json.RawMessage {
+	if value == nil {
+		return jsonNull
+	}
+	return value
+}
@@ -0,0 +1,1803 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/migrations"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/numbering"
+	_ "modernc.org/sqlite"
+)
+
+// missingID is an id no test creates
+const missingID = 1 << 30
+
+// testNumbers are the formats of the student and staff numbers of the tests
+var testNumbers = Numbers{
+	Students: numbering.MustParse("STU-{year}-{seq:6}"),
+	Staff:    numbering.MustParse("STF{seq:4}"),
+}
+
+// The conformance suites run against every storage driver. The MySQL driver
+// needs a database, it runs when TEST_MYSQL_DSN is set, eg.
+// root:root@tcp(localhost:3306)/simpleapitest?clientFoundRows=true&parseTime=true
+func TestConformance_Memory(t *testing.T) {
+	testConformance(t, func(t *testing.T) StudentRepository {
+		return NewMemoryRepositories(testNumbers).Students
+	})
+	testAPIKeyConformance(t, func(t *testing.T) APIKeyRepository {
+		return NewMemoryAPIKeyRepository()
+	})
+	testCourseConformance(t, func(t *testing.T) *Repositories {
+		return NewMemoryRepositories(testNumbers)
+	})
+	testEnrolmentConformance(t, func(t *testing.T) *Repositories {
+		return NewMemoryRepositories(testNumbers)
+	})
+	testGradeConformance(t, func(t *testing.T) *Repositories {
+		return NewMemoryRepositories(testNumbers)
+	})
+	testDepartmentConformance(t, func(t *testing.T) *Repositories {
+		return NewMemoryRepositories(testNumbers)
+	})
+	testStaffConformance(t, func(t *testing.T) *Repositories {
+		return NewMemoryRepositories(testNumbers)
+	})
+	testAuditConformance(t, func(t *testing.T) *Repositories {
+		return NewMemoryRepositories(testNumbers)
+	})
+}
+
+func TestConformance_SQLite(t *testing.T) {
+	open := func(t *testing.T) *sql.DB {
+		return openTestDB(t, "sqlite", "file::memory:?_pragma=foreign_keys(1)", "sqlite")
+	}
+	testConformance(t, func(t *testing.T) StudentRepository {
+		return NewStudentRepository(open(t), SQLite, testNumbers.Students)
+	})
+	testAPIKeyConformance(t, func(t *testing.T) APIKeyRepository {
+		return NewAPIKeyRepository(open(t))
+	})
+	testCourseConformance(t, func(t *testing.T) *Repositories {
+		return NewSQLRepositories(open(t), SQLite, testNumbers)
+	})
+	testEnrolmentConformance(t, func(t *testing.T) *Repositories {
+		return NewSQLRepositories(open(t), SQLite, testNumbers)
+	})
+	testGradeConformance(t, func(t *testing.T) *Repositories {
+		return NewSQLRepositories(open(t), SQLite, testNumbers)
+	})
+	testDepartmentConformance(t, func(t *testing.T) *Repositories {
+		return NewSQLRepositories(open(t), SQLite, testNumbers)
+	})
+	testStaffConformance(t, func(t *testing.T) *Repositories {
+		return NewSQLRepositories(open(t), SQLite, testNumbers)
+	})
+	testAuditConformance(t, func(t *testing.T) *Repositories {
+		return NewSQLRepositories(open(t), SQLite, testNumbers)
+	})
+}
+
+func TestConformance_MySQL(t *testing.T) {
+	dsn := os.Getenv("TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("TEST_MYSQL_DSN is not set")
+	}
+	open := func(t *testing.T) *sql.DB {
+		db := openTestDB(t, "mysql", dsn, "mysql")
+		// the heads are cleared first, a department and its head refer to each other
+		_, err := db.Exec("UPDATE departments SET head_id = NULL;")
+		if err != nil {
+			t.Fatalf("Error clearing the department heads %v", err)
+		}
+		for _, table := range []string{"grades", "enrolments", "courses", "staff", "lecturers", "departments",
+			"students", "api_keys", "number_sequences", "audit_events"} {
+			_, err := db.Exec("DELETE FROM " + table + ";")
+			if err != nil {
+				t.Fatalf("Error clearing %s %v", table, err)
+			}
+		}
+		return db
+	}
+	testConformance(t, func(t *testing.T) StudentRepository {
+		return NewStudentRepository(open(t), MySQL, testNumbers.Students)
+	})
+	testAPIKeyConformance(t, func(t *testing.T) APIKeyRepository {
+		return NewAPIKeyRepository(open(t))
+	})
+	testCourseConformance(t, func(t *testing.T) *Repositories {
+		return NewSQLRepositories(open(t), MySQL, testNumbers)
+	})
+	testEnrolmentConformance(t, func(t *testing.T) *Repositories {
+		return NewSQLRepositories(open(t), MySQL, testNumbers)
+	})
+	testGradeConformance(t, func(t *testing.T) *Repositories {
+		return NewSQLRepositories(open(t), MySQL, testNumbers)
+	})
+	testDepartmentConformance(t, func(t *testing.T) *Repositories {
+		return NewSQLRepositories(open(t), MySQL, testNumbers)
+	})
+	testStaffConformance(t, func(t *testing.T) *Repositories {
+		return NewSQLRepositories(open(t), MySQL, testNumbers)
+	})
+	testAuditConformance(t, func(t *testing.T) *Repositories {
+		return NewSQLRepositories(open(t), MySQL, testNumbers)
+	})
+}
+
+func TestNumberExisting_SQLite(t *testing.T) {
+	db := openTestDB(t, "sqlite", "file::memory:?_pragma=foreign_keys(1)", "sqlite")
+	ctx := context.Background()
+
+	// the records stored before the numbers were added
+	for _, statement := range []string{
+		"INSERT INTO students (id, firstname, lastname, year, status) VALUES (4, 'Lando', 'Norris', 2, 'active');",
+		"INSERT INTO students (id, firstname, lastname, year, status) VALUES (2, 'Charles', 'Leclerc', 3, 'active');",
+		"INSERT INTO staff (id, firstname, lastname, position) VALUES (1, 'Toto', 'Wolff', 'Registrar');",
+	} {
+		_, err := db.ExecContext(ctx, statement)
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+	}
+
+	numbered, err := NumberExisting(ctx, db, SQLite, testNumbers)
+	if err != nil || numbered != 3 {
+		t.Fatalf("Expected 3 records numbered, but got %d, %v", numbered, err)
+	}
+
+	repos := NewSQLRepositories(db, SQLite, testNumbers)
+	created, err := repos.Students.Create(ctx, &models.Student{FirstName: "Oscar", LastName: "Piastri", Year: 1,
+		Status: models.StatusActive})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	now := time.Now()
+	testCases := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "First Student", query: "SELECT registration_number FROM students WHERE id = 2;",
+			expected: testNumbers.Students.Number(now, 1)},
+		{name: "Second Student", query: "SELECT registration_number FROM students WHERE id = 4;",
+			expected: testNumbers.Students.Number(now, 2)},
+		{name: "Created Student", query: fmt.Sprintf("SELECT registration_number FROM students WHERE id = %d;",
+			created.ID), expected: testNumbers.Students.Number(now, 3)},
+		{name: "Staff", query: "SELECT staff_number FROM staff WHERE id = 1;",
+			expected: testNumbers.Staff.Number(now, 1)},
+	}
+
+	for _, test := range testCases {
+		var actual string
+		err := db.QueryRowContext(ctx, test.query).Scan(&actual)
+		if err != nil || actual != test.expected {
+			t.Errorf("Test %s : Expected %s, but got %s, %v", test.name, test.expected, actual, err)
+		}
+	}
+
+	numbered, err = NumberExisting(ctx, db, SQLite, testNumbers)
+	if err != nil || numbered != 0 {
+		t.Errorf("Expected nothing left to number, but got %d, %v", numbered, err)
+	}
+}
+
+// openTestDB opens a database with the schema migrated to the latest version
+func openTestDB(t *testing.T, driverName string, dsn string, driver string) *sql.DB {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		t.Fatalf("Error opening the database %v", err)
+	}
+	// an in memory SQLite database lives as long as its connection
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	migrator, err := migrations.New(db, driver)
+	if err != nil {
+		t.Fatalf("Error loading the migrations %v", err)
+	}
+	_, err = migrator.Up(context.Background())
+	if err != nil {
+		t.Fatalf("Error applying the migrations %v", err)
+	}
+	return db
+}
+
+// testConformance checks the behaviour every StudentRepository shares. Each
+// test gets an empty repository from newRepo
+func testConformance(t *testing.T, newRepo func(t *testing.T) StudentRepository) {
+	ctx := context.Background()
+
+	create := func(t *testing.T, repo StudentRepository, students ...models.Student) []models.Student {
+		var created []models.Student
+		for _, st := range students {
+			st := st
+			c, err := repo.Create(ctx, &st)
+			if err != nil {
+				t.Fatalf("Error creating %v : %v", st, err)
+			}
+			created = append(created, *c)
+		}
+		return created
+	}
+
+	t.Run("Create And Get", func(t *testing.T) {
+		repo := newRepo(t)
+		created := create(t, repo,
+			models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 3},
+			models.Student{FirstName: "Lando", LastName: "Norris", Year: 2})
+
+		if created[0].ID == 0 || created[1].ID <= created[0].ID {
+			t.Errorf("Expected increasing ids, but got %d and %d", created[0].ID, created[1].ID)
+		}
+
+		actual, err := repo.Get(ctx, created[1].ID)
+		if err != nil || !reflect.DeepEqual(*actual, created[1]) {
+			t.Errorf("Expected %v, but got %v, %v", created[1], actual, err)
+		}
+	})
+
+	t.Run("Profile", func(t *testing.T) {
+		repo := newRepo(t)
+		email := "charles@example.com"
+		phone := "+377 93 15 00 00"
+		birthday := models.NewDate(1997, time.October, 16)
+		enrolled := models.NewDate(2016, time.September, 1)
+		created := create(t, repo,
+			models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 3, Email: &email, Phone: &phone,
+				DateOfBirth: &birthday, EnrolmentDate: &enrolled, Status: "graduated"})
+
+		actual, err := repo.Get(ctx, created[0].ID)
+		if err != nil || !reflect.DeepEqual(*actual, created[0]) {
+			t.Errorf("Expected %v, but got %v, %v", created[0], actual, err)
+		}
+
+		sorted, err := repo.Search(ctx, "", nil, models.Pagination{Page: 0, PageSize: 10},
+			models.SortBy{Column: "dateOfBirth", Direction: "desc"})
+		if err != nil || sorted.TotalElements != 1 {
+			t.Errorf("Expected to sort by the date of birth, but got %v, %v", sorted, err)
+		}
+	})
+
+	t.Run("Duplicate Email", func(t *testing.T) {
+		repo := newRepo(t)
+		email := "charles@example.com"
+		create(t, repo,
+			models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 3, Email: &email, Status: "active"},
+			models.Student{FirstName: "Lando", LastName: "Norris", Year: 2, Status: "active"},
+			models.Student{FirstName: "Oscar", LastName: "Piastri", Year: 1, Status: "active"})
+
+		duplicate := models.Student{FirstName: "Arthur", LastName: "Leclerc", Year: 1, Email: &email,
+			Status: "active"}
+		_, err := repo.Create(ctx, &duplicate)
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected a conflict, but got %v", err)
+		}
+	})
+
+	t.Run("Registration Number", func(t *testing.T) {
+		repo := newRepo(t)
+		other := "STU-1999-000042"
+		created := create(t, repo,
+			models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 3, RegistrationNumber: &other},
+			models.Student{FirstName: "Lando", LastName: "Norris", Year: 2})
+
+		year := time.Now().Year()
+		for i, expected := range []string{
+			fmt.Sprintf("STU-%d-000001", year),
+			fmt.Sprintf("STU-%d-000002", year),
+		} {
+			if number := created[i].RegistrationNumber; number == nil || *number != expected {
+				t.Errorf("Expected the registration number %s, but got %v", expected, number)
+			}
+		}
+
+		actual, err := repo.GetByNumber(ctx, *created[1].RegistrationNumber)
+		if err != nil || !reflect.DeepEqual(*actual, created[1]) {
+			t.Errorf("Expected %v, but got %v, %v", created[1], actual, err)
+		}
+
+		changed := created[0]
+		changed.RegistrationNumber = &other
+		changed.Year = 4
+		_, err = repo.Update(ctx, &changed)
+		expected := []apperrors.FieldError{{Field: "registrationNumber", Message: "can not be changed"}}
+		if apperrors.CodeOf(err) != apperrors.CodeValidation || !reflect.DeepEqual(apperrors.FieldsOf(err), expected) {
+			t.Errorf("Expected the changed registration number to be rejected, but got %v", err)
+		}
+
+		for _, number := range []*string{nil, created[0].RegistrationNumber} {
+			changed.RegistrationNumber = number
+			updated, err := repo.Update(ctx, &changed)
+			if err != nil || !reflect.DeepEqual(updated.RegistrationNumber, created[0].RegistrationNumber) ||
+				updated.Year != 4 {
+				t.Errorf("Expected the update to keep the registration number, but got %v, %v", updated, err)
+			}
+		}
+
+		_, err = repo.GetByNumber(ctx, other)
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected a not found error, but got %v", err)
+		}
+	})
+
+	t.Run("Get Missing", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.Get(ctx, missingID)
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected a not found error, but got %v", err)
+		}
+	})
+
+	t.Run("Get All", func(t *testing.T) {
+		repo := newRepo(t)
+
+		empty, err := repo.GetAll(ctx, nil)
+		if err != nil || len(empty) != 0 {
+			t.Errorf("Expected no students, but got %v, %v", empty, err)
+		}
+
+		created := create(t, repo,
+			models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 3},
+			models.Student{FirstName: "Lando", LastName: "Norris", Year: 2})
+
+		actual, err := repo.GetAll(ctx, nil)
+		if err != nil || !reflect.DeepEqual(actual, created) {
+			t.Errorf("Expected %v, but got %v, %v", created, actual, err)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepo(t)
+		created := create(t, repo, models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 3})
+
+		changed := created[0]
+		changed.Year = 4
+		actual, err := repo.Update(ctx, &changed)
+		changed.Version = 2
+		if err != nil || !reflect.DeepEqual(*actual, changed) {
+			t.Errorf("Expected %v, but got %v, %v", changed, actual, err)
+		}
+
+		// an update without changes is still a change of the version
+		unchanged := changed
+		actual, err = repo.Update(ctx, &unchanged)
+		changed.Version = 3
+		if err != nil || !reflect.DeepEqual(*actual, changed) {
+			t.Errorf("Expected an update without changes to return %v, but got %v, %v", changed, actual, err)
+		}
+
+		stored, err := repo.Get(ctx, changed.ID)
+		if err != nil || !reflect.DeepEqual(*stored, changed) {
+			t.Errorf("Expected %v to be stored, but got %v, %v", changed, stored, err)
+		}
+	})
+
+	t.Run("Versions", func(t *testing.T) {
+		repo := newRepo(t)
+		created := create(t, repo, models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 3})
+		if created[0].Version != 1 {
+			t.Errorf("Expected a new student to be version 1, but got %v", created[0])
+		}
+
+		changed := created[0]
+		changed.Year = 4
+		_, err := repo.Update(IfVersion(ctx, 2), &changed)
+		if apperrors.CodeOf(err) != apperrors.CodePrecondition {
+			t.Errorf("Expected a precondition failure, but got %v", err)
+		}
+		_, err = repo.Update(IfVersion(ctx), &changed)
+		if apperrors.CodeOf(err) != apperrors.CodePrecondition {
+			t.Errorf("Expected no version to match, but got %v", err)
+		}
+		stored, err := repo.Get(ctx, created[0].ID)
+		if err != nil || !reflect.DeepEqual(*stored, created[0]) {
+			t.Errorf("Expected the failed updates to change nothing, but got %v, %v", stored, err)
+		}
+
+		updated, err := repo.Update(IfVersion(ctx, 2, 1), &changed)
+		if err != nil || updated.Version != 2 || updated.Year != 4 {
+			t.Errorf("Expected version 2 of the student, but got %v, %v", updated, err)
+		}
+
+		_, err = repo.Delete(IfVersion(ctx, 1), created[0].ID)
+		if apperrors.CodeOf(err) != apperrors.CodePrecondition {
+			t.Errorf("Expected a precondition failure, but got %v", err)
+		}
+		deleted, err := repo.Delete(IfVersion(ctx, 2), created[0].ID)
+		if err != nil || deleted.Version != 3 {
+			t.Errorf("Expected the delete to change the version, but got %v, %v", deleted, err)
+		}
+	})
+
+	t.Run("Patch", func(t *testing.T) {
+		repo := newRepo(t)
+		email := "lando@example.com"
+		created := create(t, repo,
+			models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 3},
+			models.Student{FirstName: "Lando", LastName: "Norris", Year: 2, Email: &email})
+
+		patched, err := repo.Patch(ctx, created[0].ID, func(student *models.Student) error {
+			*student = models.Student{ID: missingID, FirstName: "Charles", LastName: "Leclerc", Year: 4,
+				Version: 7}
+			return nil
+		})
+		expected := created[0]
+		expected.Year = 4
+		expected.Version = 2
+		if err != nil || !reflect.DeepEqual(*patched, expected) {
+			t.Errorf("Expected %v, but got %v, %v", expected, patched, err)
+		}
+		stored, err := repo.Get(ctx, created[0].ID)
+		if err != nil || !reflect.DeepEqual(*stored, expected) {
+			t.Errorf("Expected %v to be stored, but got %v, %v", expected, stored, err)
+		}
+
+		unchanged, err := repo.Patch(ctx, created[0].ID, func(student *models.Student) error { return nil })
+		if err != nil || !reflect.DeepEqual(*unchanged, expected) {
+			t.Errorf("Expected a patch without changes to keep %v, but got %v, %v", expected, unchanged, err)
+		}
+
+		rejected := errors.New("rejected")
+		_, err = repo.Patch(ctx, created[0].ID, func(student *models.Student) error {
+			student.Year = 5
+			return rejected
+		})
+		if err != rejected {
+			t.Errorf("Expected the error of the patch, but got %v", err)
+		}
+		_, err = repo.Patch(IfVersion(ctx, 1), created[0].ID, func(student *models.Student) error {
+			student.Year = 5
+			return nil
+		})
+		if apperrors.CodeOf(err) != apperrors.CodePrecondition {
+			t.Errorf("Expected a precondition failure, but got %v", err)
+		}
+		_, err = repo.Patch(ctx, created[0].ID, func(student *models.Student) error {
+			student.Email = &email
+			return nil
+		})
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected a conflict, but got %v", err)
+		}
+		stored, err = repo.Get(ctx, created[0].ID)
+		if err != nil || !reflect.DeepEqual(*stored, expected) {
+			t.Errorf("Expected the failed patches to change nothing, but got %v, %v", stored, err)
+		}
+
+		_, err = repo.Patch(ctx, missingID, func(student *models.Student) error { return nil })
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected a not found error, but got %v", err)
+		}
+	})
+
+	t.Run("Update Missing", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.Update(ctx, &models.Student{ID: missingID, FirstName: "Charles", LastName: "Leclerc",
+			Year: 3})
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected a not found error, but got %v", err)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		created := create(t, repo, models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 3})
+
+		deleted, err := repo.Delete(ctx, created[0].ID)
+		if err != nil || deleted.DeletedAt == nil {
+			t.Fatalf("Expected %v to be soft deleted, but got %v, %v", created[0], deleted, err)
+		}
+		deleted.DeletedAt = nil
+		deleted.Version--
+		if !reflect.DeepEqual(*deleted, created[0]) {
+			t.Errorf("Expected %v, but got %v", created[0], deleted)
+		}
+
+		_, err = repo.Get(ctx, created[0].ID)
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected the deleted student to be not found, but got %v", err)
+		}
+
+		_, err = repo.Delete(ctx, created[0].ID)
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected deleting twice to be not found, but got %v", err)
+		}
+	})
+
+	t.Run("Include Deleted", func(t *testing.T) {
+		repo := newRepo(t)
+		created := create(t, repo,
+			models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 3},
+			models.Student{FirstName: "Lando", LastName: "Norris", Year: 2})
+		_, err := repo.Delete(ctx, created[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		page := models.Pagination{Page: 0, PageSize: 10}
+
+		all, err := repo.GetAll(ctx, nil)
+		if err != nil || !reflect.DeepEqual(all, created[1:]) {
+			t.Errorf("Expected %v, but got %v, %v", created[1:], all, err)
+		}
+		found, err := repo.Search(ctx, "", nil, page, models.SortBy{})
+		if err != nil || found.TotalElements != 1 {
+			t.Errorf("Expected the search to leave out the deleted student, but got %v, %v", found, err)
+		}
+		_, err = repo.GetByNumber(ctx, *created[0].RegistrationNumber)
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected the deleted number to be not found, but got %v", err)
+		}
+		_, err = repo.Update(ctx, &created[0])
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected updating the deleted student to be not found, but got %v", err)
+		}
+
+		withDeleted := WithDeleted(ctx)
+		all, err = repo.GetAll(withDeleted, nil)
+		if err != nil || len(all) != 2 || all[0].DeletedAt == nil || all[1].DeletedAt != nil {
+			t.Errorf("Expected both students, but got %v, %v", all, err)
+		}
+		found, err = repo.Search(withDeleted, "", nil, page, models.SortBy{})
+		if err != nil || found.TotalElements != 2 {
+			t.Errorf("Expected the search to include the deleted student, but got %v, %v", found, err)
+		}
+		actual, err := repo.Get(withDeleted, created[0].ID)
+		if err != nil || actual.DeletedAt == nil {
+			t.Errorf("Expected the deleted student, but got %v, %v", actual, err)
+		}
+	})
+
+	t.Run("Deleted Email Is Taken", func(t *testing.T) {
+		repo := newRepo(t)
+		email := "charles@example.com"
+		created := create(t, repo, models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 3,
+			Email: &email})
+		_, err := repo.Delete(ctx, created[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		duplicate := models.Student{FirstName: "Arthur", LastName: "Leclerc", Year: 1, Email: &email}
+		_, err = repo.Create(ctx, &duplicate)
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected a conflict, but got %v", err)
+		}
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		repo := newRepo(t)
+		created := create(t, repo, models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 3})
+		_, err := repo.Delete(ctx, created[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		restored, err := repo.Restore(ctx, created[0].ID)
+		created[0].Version = 3
+		if err != nil || !reflect.DeepEqual(*restored, created[0]) {
+			t.Errorf("Expected %v, but got %v, %v", created[0], restored, err)
+		}
+		actual, err := repo.Get(ctx, created[0].ID)
+		if err != nil || !reflect.DeepEqual(*actual, created[0]) {
+			t.Errorf("Expected the restored student, but got %v, %v", actual, err)
+		}
+
+		_, err = repo.Restore(ctx, created[0].ID)
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected restoring a live student to be not found, but got %v", err)
+		}
+
+		_, err = repo.Restore(ctx, missingID)
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected a not found error, but got %v", err)
+		}
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		repo := newRepo(t)
+		created := create(t, repo,
+			models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 3},
+			models.Student{FirstName: "Lando", LastName: "Norris", Year: 2})
+		_, err := repo.Delete(ctx, created[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		purged, err := repo.Purge(ctx, time.Now().Add(-time.Hour))
+		if err != nil || purged != 0 {
+			t.Errorf("Expected the recent delete to be kept, but purged %d, %v", purged, err)
+		}
+
+		purged, err = repo.Purge(ctx, time.Now().Add(time.Hour))
+		if err != nil || purged != 1 {
+			t.Errorf("Expected one student to be purged, but purged %d, %v", purged, err)
+		}
+		_, err = repo.Restore(ctx, created[0].ID)
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected the purged student to be gone, but got %v", err)
+		}
+		all, err := repo.GetAll(WithDeleted(ctx), nil)
+		if err != nil || !reflect.DeepEqual(all, created[1:]) {
+			t.Errorf("Expected %v, but got %v, %v", created[1:], all, err)
+		}
+	})
+
+	t.Run("Purge Keeps A Record Deleted Again", func(t *testing.T) {
+		repo := newRepo(t)
+		created := create(t, repo, models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 3})
+		_, err := repo.Delete(ctx, created[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the deletions are stored to the second, the second delete comes
+		// after the cutoff
+		cutoff := time.Now().Add(time.Millisecond)
+		time.Sleep(time.Until(cutoff.Truncate(time.Second).Add(time.Second + 10*time.Millisecond)))
+		_, err = repo.Restore(ctx, created[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = repo.Delete(ctx, created[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		purged, err := repo.Purge(ctx, cutoff)
+		if err != nil || purged != 0 {
+			t.Errorf("Expected the student deleted again to be kept, but purged %d, %v", purged, err)
+		}
+		_, err = repo.Restore(ctx, created[0].ID)
+		if err != nil {
+			t.Errorf("Expected the student to survive the purge, but got %v", err)
+		}
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		repo := newRepo(t)
+		created := create(t, repo,
+			models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 3},
+			models.Student{FirstName: "Lando", LastName: "Norris", Year: 2},
+			models.Student{FirstName: "Carlos", LastName: "Sainz", Year: 4},
+			models.Student{FirstName: "Max_", LastName: "Verstappen", Year: 1})
+
+		testCases := []struct {
+			name         string
+			searchString string
+			pagination   models.Pagination
+			sortBy       models.SortBy
+			expected     models.SearchData[models.Student]
+		}{
+			{
+				name:         "Case Insensitive Match",
+				searchString: "AR",
+				pagination:   models.Pagination{Page: 0, PageSize: 10},
+				expected: models.SearchData[models.Student]{TotalElements: 2,
+					Data: []models.Student{created[0], created[2]}},
+			},
+			{
+				name:         "Last Name Match",
+				searchString: "norr",
+				pagination:   models.Pagination{Page: 0, PageSize: 10},
+				expected: models.SearchData[models.Student]{TotalElements: 1,
+					Data: []models.Student{created[1]}},
+			},
+			{
+				name:         "Sorted And Paged",
+				searchString: "",
+				pagination:   models.Pagination{Page: 1, PageSize: 2},
+				sortBy:       models.SortBy{Column: "year", Direction: "desc"},
+				expected: models.SearchData[models.Student]{TotalElements: 4,
+					Data: []models.Student{created[0], created[1]}},
+			},
+			{
+				name:         "Wildcards Match Literally",
+				searchString: "_",
+				pagination:   models.Pagination{Page: 0, PageSize: 10},
+				expected: models.SearchData[models.Student]{TotalElements: 1,
+					Data: []models.Student{created[3]}},
+			},
+			{
+				name:         "No Match",
+				searchString: "%",
+				pagination:   models.Pagination{Page: 0, PageSize: 10},
+				expected:     models.SearchData[models.Student]{},
+			},
+		}
+
+		for _, test := range testCases {
+			actual, err := repo.Search(ctx, test.searchString, nil, test.pagination, test.sortBy)
+			if err != nil || !reflect.DeepEqual(*actual, test.expected) {
+				t.Errorf("Test %s : Expected %v, but got %v, %v", test.name, test.expected, actual, err)
+			}
+		}
+	})
+
+	t.Run("Invalid Search", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.Search(ctx, "", nil, models.Pagination{Page: 0, PageSize: 10},
+			models.SortBy{Column: "password"})
+		if apperrors.CodeOf(err) != apperrors.CodeValidation {
+			t.Errorf("Expected a validation error, but got %v", err)
+		}
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		repo := newRepo(t)
+		email := "lando@example.com"
+		born := models.NewDate(1999, time.November, 13)
+		created := create(t, repo,
+			models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 3},
+			models.Student{FirstName: "Lando", LastName: "Norris", Year: 2, Email: &email, DateOfBirth: &born},
+			models.Student{FirstName: "Carlos", LastName: "Sainz", Year: 4},
+			models.Student{FirstName: "Lance", LastName: "Stroll", Year: 3})
+
+		testCases := []struct {
+			name     string
+			filter   string
+			expected []models.Student
+		}{
+			{name: "Number And Prefix", filter: "year:gte:3,lastname:prefix:s",
+				expected: []models.Student{created[2], created[3]}},
+			{name: "Case Insensitive Text", filter: "lastname:eq:NORRIS", expected: []models.Student{created[1]}},
+			{name: "In", filter: "year:in:2|4", expected: []models.Student{created[1], created[2]}},
+			{name: "Contains", filter: "firstname:contains:AN", expected: []models.Student{created[1], created[3]}},
+			{name: "Mixed Case Prefix", filter: "lastname:prefix:sT", expected: []models.Student{created[3]}},
+			{name: "Mixed Case Contains", filter: "lastname:contains:oRR", expected: []models.Student{created[1]}},
+			{name: "Date", filter: "dateOfBirth:lt:2000-01-01", expected: []models.Student{created[1]}},
+			{name: "Nulls Never Match", filter: "email:ne:max@example.com", expected: []models.Student{created[1]}},
+			{name: "Wildcards Match Literally", filter: "lastname:contains:%"},
+		}
+
+		for _, test := range testCases {
+			list, err := repo.GetAll(ctx, filtered(t, test.filter))
+			if err != nil || !reflect.DeepEqual(list, test.expected) {
+				t.Errorf("Test %s : Expected %v, but got %v, %v", test.name, test.expected, list, err)
+			}
+		}
+
+		actual, err := repo.Search(ctx, "L", filtered(t, "year:eq:3"), models.Pagination{Page: 0, PageSize: 1},
+			models.SortBy{Column: "lastname", Direction: "desc"})
+		expected := models.SearchData[models.Student]{TotalElements: 2, Data: []models.Student{created[3]}}
+		if err != nil || !reflect.DeepEqual(*actual, expected) {
+			t.Errorf("Expected %v, but got %v, %v", expected, actual, err)
+		}
+
+		_, err = repo.GetAll(ctx, filtered(t, "position:eq:Registrar"))
+		if apperrors.CodeOf(err) != apperrors.CodeValidation {
+			t.Errorf("Expected a validation error, but got %v", err)
+		}
+		_, err = repo.Search(ctx, "", filtered(t, "year:eq:third"), models.Pagination{Page: 0, PageSize: 10},
+			models.SortBy{})
+		if apperrors.CodeOf(err) != apperrors.CodeValidation {
+			t.Errorf("Expected a validation error, but got %v", err)
+		}
+	})
+}
+
+// testAPIKeyConformance checks the behaviour every APIKeyRepository shares
+func testAPIKeyConformance(t *testing.T, newRepo func(t *testing.T) APIKeyRepository) {
+	ctx := context.Background()
+	createdAt := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	newKey := func(prefix string) *models.APIKey {
+		return &models.APIKey{Name: "batch", Prefix: prefix, Hash: "hash-" + prefix,
+			Scopes: []string{"student:read", "lecturer:write"}, CreatedAt: createdAt}
+	}
+
+	t.Run("API Key Create And Get", func(t *testing.T) {
+		repo := newRepo(t)
+
+		created, err := repo.Create(ctx, newKey("p1"))
+		if err != nil || created.ID == 0 {
+			t.Fatalf("Expected the key to be created, but got %v, %v", created, err)
+		}
+
+		expected := *newKey("p1")
+		expected.ID = created.ID
+		for _, get := range []func() (*models.APIKey, error){
+			func() (*models.APIKey, error) { return repo.Get(ctx, created.ID) },
+			func() (*models.APIKey, error) { return repo.GetByPrefix(ctx, "p1") },
+		} {
+			actual, err := get()
+			if err != nil || !reflect.DeepEqual(*actual, expected) {
+				t.Errorf("Expected %v, but got %v, %v", expected, actual, err)
+			}
+		}
+
+		all, err := repo.GetAll(ctx)
+		if err != nil || len(all) != 1 || !reflect.DeepEqual(all[0], expected) {
+			t.Errorf("Expected [%v], but got %v, %v", expected, all, err)
+		}
+	})
+
+	t.Run("API Key Duplicate Prefix", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.Create(ctx, newKey("p1"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = repo.Create(ctx, newKey("p1"))
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected a conflict, but got %v", err)
+		}
+	})
+
+	t.Run("API Key Missing", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.Get(ctx, missingID)
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected a not found error, but got %v", err)
+		}
+		_, err = repo.GetByPrefix(ctx, "missing")
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected a not found error, but got %v", err)
+		}
+		_, err = repo.Rotate(ctx, missingID, "p2", "hash-p2")
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected a not found error, but got %v", err)
+		}
+		_, err = repo.Revoke(ctx, missingID, createdAt)
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected a not found error, but got %v", err)
+		}
+	})
+
+	t.Run("API Key Rotate And Revoke", func(t *testing.T) {
+		repo := newRepo(t)
+		created, err := repo.Create(ctx, newKey("p1"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rotated, err := repo.Rotate(ctx, created.ID, "p2", "hash-p2")
+		if err != nil || rotated.Prefix != "p2" || rotated.Hash != "hash-p2" {
+			t.Errorf("Expected the rotated key, but got %v, %v", rotated, err)
+		}
+		if _, err := repo.GetByPrefix(ctx, "p1"); apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected the old prefix to be gone, but got %v", err)
+		}
+
+		revokedAt := createdAt.Add(time.Hour)
+		revoked, err := repo.Revoke(ctx, created.ID, revokedAt)
+		if err != nil || revoked.RevokedAt == nil || !revoked.RevokedAt.Equal(revokedAt) {
+			t.Errorf("Expected the key to be revoked at %v, but got %v, %v", revokedAt, revoked, err)
+		}
+
+		again, err := repo.Revoke(ctx, created.ID, revokedAt.Add(time.Hour))
+		if err != nil || again.RevokedAt == nil || !again.RevokedAt.Equal(revokedAt) {
+			t.Errorf("Expected the first revocation time to be kept, but got %v, %v", again, err)
+		}
+	})
+}
+
+// testCourseConformance checks the unique code and the lecturer foreign key
+// of the courses, the CRUD behaviour is covered by testConformance
+func testCourseConformance(t *testing.T, newRepos func(t *testing.T) *Repositories) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*Repositories, models.Lecturer, models.Lecturer) {
+		repos := newRepos(t)
+		var lecturers []models.Lecturer
+		for _, l := range []models.Lecturer{{FirstName: "Adrian", LastName: "Newey", Year: 30},
+			{FirstName: "Rory", LastName: "Byrne", Year: 25}} {
+			l := l
+			created, err := repos.Lecturers.Create(ctx, &l)
+			if err != nil {
+				t.Fatalf("Error creating %v : %v", l, err)
+			}
+			lecturers = append(lecturers, *created)
+		}
+		return repos, lecturers[0], lecturers[1]
+	}
+
+	t.Run("Courses By Lecturer", func(t *testing.T) {
+		repos, newey, byrne := setup(t)
+
+		var created []models.Course
+		for _, c := range []models.Course{
+			{Code: "AE101", Title: "Aerodynamics", Credits: 3, Semester: "2023-S1", LecturerID: newey.ID},
+			{Code: "CH201", Title: "Chassis Design", Credits: 4, Semester: "2023-S2", LecturerID: byrne.ID},
+			{Code: "AE201", Title: "Ground Effect", Credits: 2, Semester: "2023-S2", LecturerID: newey.ID},
+		} {
+			c := c
+			course, err := repos.Courses.Create(ctx, &c)
+			if err != nil {
+				t.Fatalf("Error creating %v : %v", c, err)
+			}
+			created = append(created, *course)
+		}
+
+		actual, err := repos.Courses.GetByLecturer(ctx, newey.ID)
+		expected := []models.Course{created[0], created[2]}
+		if err != nil || !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Expected %v, but got %v, %v", expected, actual, err)
+		}
+
+		none, err := repos.Courses.GetByLecturer(ctx, missingID)
+		if err != nil || len(none) != 0 {
+			t.Errorf("Expected no courses, but got %v, %v", none, err)
+		}
+	})
+
+	t.Run("Duplicate Course Code", func(t *testing.T) {
+		repos, newey, _ := setup(t)
+
+		course := models.Course{Code: "AE101", Title: "Aerodynamics", Credits: 3, Semester: "2023-S1",
+			LecturerID: newey.ID}
+		first := course
+		_, err := repos.Courses.Create(ctx, &first)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		second := course
+		_, err = repos.Courses.Create(ctx, &second)
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected a conflict, but got %v", err)
+		}
+	})
+
+	t.Run("Missing Lecturer", func(t *testing.T) {
+		repos, newey, _ := setup(t)
+
+		course := models.Course{Code: "AE101", Title: "Aerodynamics", Credits: 3, Semester: "2023-S1",
+			LecturerID: missingID}
+		_, err := repos.Courses.Create(ctx, &course)
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected a conflict creating, but got %v", err)
+		}
+
+		course.LecturerID = newey.ID
+		created, err := repos.Courses.Create(ctx, &course)
+		if err != nil {
+			t.Fatal(err)
+		}
+		changed := *created
+		changed.LecturerID = missingID
+		_, err = repos.Courses.Update(ctx, &changed)
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected a conflict updating, but got %v", err)
+		}
+	})
+
+	t.Run("Delete Referenced Lecturer", func(t *testing.T) {
+		repos, newey, byrne := setup(t)
+
+		course := models.Course{Code: "AE101", Title: "Aerodynamics", Credits: 3, Semester: "2023-S1",
+			LecturerID: newey.ID}
+		_, err := repos.Courses.Create(ctx, &course)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = repos.Lecturers.Delete(ctx, newey.ID)
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected a conflict, but got %v", err)
+		}
+
+		_, err = repos.Lecturers.Delete(ctx, byrne.ID)
+		if err != nil {
+			t.Errorf("Expected the lecturer without courses to be deleted, but got %v", err)
+		}
+	})
+
+	t.Run("Purge Referenced Lecturer", func(t *testing.T) {
+		repos, newey, _ := setup(t)
+
+		_, err := repos.Lecturers.Delete(ctx, newey.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the foreign key accepts a soft deleted lecturer
+		course := models.Course{Code: "AE101", Title: "Aerodynamics", Credits: 3, Semester: "2023-S1",
+			LecturerID: newey.ID}
+		_, err = repos.Courses.Create(ctx, &course)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		purged, err := repos.Lecturers.Purge(ctx, time.Now().Add(time.Hour))
+		if err != nil || purged != 0 {
+			t.Errorf("Expected the referenced lecturer to be kept, but purged %d, %v", purged, err)
+		}
+		_, err = repos.Lecturers.Restore(ctx, newey.ID)
+		if err != nil {
+			t.Errorf("Expected the kept lecturer to be restored, but got %v", err)
+		}
+	})
+}
+
+// testEnrolmentConformance checks the enrolments and that the check is given
+// the state of the course
+func testEnrolmentConformance(t *testing.T, newRepos func(t *testing.T) *Repositories) {
+	ctx := context.Background()
+	enrolledAt := time.Date(2023, 9, 1, 9, 0, 0, 0, time.UTC)
+
+	type checked struct {
+		student  models.Student
+		course   models.Course
+		enrolled int
+		already  bool
+	}
+
+	setup := func(t *testing.T) (*Repositories, models.Student, models.Student, models.Course) {
+		repos := newRepos(t)
+		lecturer, err := repos.Lecturers.Create(ctx, &models.Lecturer{FirstName: "Adrian", LastName: "Newey",
+			Year: 30})
+		if err != nil {
+			t.Fatal(err)
+		}
+		course, err := repos.Courses.Create(ctx, &models.Course{Code: "AE101", Title: "Aerodynamics", Credits: 3,
+			Semester: "2023-S1", LecturerID: lecturer.ID, Capacity: 2, MinYear: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		first, err := repos.Students.Create(ctx, &models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, err := repos.Students.Create(ctx, &models.Student{FirstName: "Lando", LastName: "Norris", Year: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return repos, *first, *second, *course
+	}
+
+	record := func(calls *[]checked, err error) EnrolmentCheck {
+		return func(student *models.Student, course *models.Course, enrolled int, already bool) error {
+			*calls = append(*calls, checked{student: *student, course: *course, enrolled: enrolled, already: already})
+			return err
+		}
+	}
+
+	t.Run("Enrol And Withdraw", func(t *testing.T) {
+		repos, first, second, course := setup(t)
+
+		var calls []checked
+		for _, student := range []models.Student{first, second} {
+			_, err := repos.Enrolments.Enrol(ctx, &models.Enrolment{StudentID: student.ID, CourseID: course.ID,
+				EnrolledAt: enrolledAt}, record(&calls, nil))
+			if err != nil {
+				t.Fatalf("Error enrolling %v : %v", student, err)
+			}
+		}
+		_, err := repos.Enrolments.Enrol(ctx, &models.Enrolment{StudentID: first.ID, CourseID: course.ID,
+			EnrolledAt: enrolledAt}, record(&calls, apperrors.Conflict(consts.AlreadyEnrolledError, nil)))
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected the error of the check, but got %v", err)
+		}
+
+		expectedCalls := []checked{
+			{student: first, course: course, enrolled: 0, already: false},
+			{student: second, course: course, enrolled: 1, already: false},
+			{student: first, course: course, enrolled: 2, already: true},
+		}
+		if !reflect.DeepEqual(calls, expectedCalls) {
+			t.Errorf("Expected the checks %v, but got %v", expectedCalls, calls)
+		}
+
+		expected := []models.Enrolment{{StudentID: first.ID, CourseID: course.ID, EnrolledAt: enrolledAt}}
+		actual, err := repos.Enrolments.GetByStudent(ctx, first.ID)
+		if err != nil || !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Expected %v, but got %v, %v", expected, actual, err)
+		}
+
+		withdrawn, err := repos.Enrolments.Withdraw(ctx, first.ID, course.ID)
+		if err != nil || !reflect.DeepEqual(*withdrawn, expected[0]) {
+			t.Errorf("Expected %v, but got %v, %v", expected[0], withdrawn, err)
+		}
+		_, err = repos.Enrolments.Withdraw(ctx, first.ID, course.ID)
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected withdrawing twice to be not found, but got %v", err)
+		}
+
+		actual, err = repos.Enrolments.GetByStudent(ctx, first.ID)
+		if err != nil || len(actual) != 0 {
+			t.Errorf("Expected no enrolments, but got %v, %v", actual, err)
+		}
+	})
+
+	t.Run("Enrol Missing", func(t *testing.T) {
+		repos, first, _, course := setup(t)
+
+		var calls []checked
+		for _, enrolment := range []models.Enrolment{
+			{StudentID: missingID, CourseID: course.ID, EnrolledAt: enrolledAt},
+			{StudentID: first.ID, CourseID: missingID, EnrolledAt: enrolledAt},
+		} {
+			enrolment := enrolment
+			_, err := repos.Enrolments.Enrol(ctx, &enrolment, record(&calls, nil))
+			if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+				t.Errorf("Expected enrolling %v to be not found, but got %v", enrolment, err)
+			}
+		}
+		if len(calls) != 0 {
+			t.Errorf("Expected no checks, but got %v", calls)
+		}
+	})
+
+	t.Run("Delete Enrolled Student", func(t *testing.T) {
+		repos, first, _, course := setup(t)
+
+		_, err := repos.Enrolments.Enrol(ctx, &models.Enrolment{StudentID: first.ID, CourseID: course.ID,
+			EnrolledAt: enrolledAt}, func(*models.Student, *models.Course, int, bool) error { return nil })
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = repos.Students.Delete(ctx, first.ID)
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected deleting the student to be a conflict, but got %v", err)
+		}
+		_, err = repos.Courses.Delete(ctx, course.ID)
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected deleting the course to be a conflict, but got %v", err)
+		}
+	})
+}
+
+// testGradeConformance checks the grades, the transcript and that the check is
+// given the state of the course
+func testGradeConformance(t *testing.T, newRepos func(t *testing.T) *Repositories) {
+	ctx := context.Background()
+	recordedAt := time.Date(2024, 1, 20, 9, 0, 0, 0, time.UTC)
+
+	type checked struct {
+		course   models.Course
+		enrolled bool
+		existing *models.Grade
+	}
+
+	setup := func(t *testing.T) (*Repositories, models.Student, models.Course, models.Course) {
+		repos := newRepos(t)
+		lecturer, err := repos.Lecturers.Create(ctx, &models.Lecturer{FirstName: "Adrian", LastName: "Newey",
+			Year: 30})
+		if err != nil {
+			t.Fatal(err)
+		}
+		later, err := repos.Courses.Create(ctx, &models.Course{Code: "AE101", Title: "Aerodynamics", Credits: 3,
+			Semester: "2023-S1", LecturerID: lecturer.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		earlier, err := repos.Courses.Create(ctx, &models.Course{Code: "PU201", Title: "Power Units", Credits: 4,
+			Semester: "2022-S2", LecturerID: lecturer.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		student, err := repos.Students.Create(ctx, &models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, course := range []models.Course{*later, *earlier} {
+			_, err := repos.Enrolments.Enrol(ctx, &models.Enrolment{StudentID: student.ID, CourseID: course.ID,
+				EnrolledAt: recordedAt}, func(*models.Student, *models.Course, int, bool) error { return nil })
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		return repos, *student, *later, *earlier
+	}
+
+	record := func(calls *[]checked, err error) GradeCheck {
+		return func(course *models.Course, enrolled bool, existing *models.Grade) error {
+			*calls = append(*calls, checked{course: *course, enrolled: enrolled, existing: existing})
+			return err
+		}
+	}
+
+	t.Run("Save And Transcript", func(t *testing.T) {
+		repos, student, later, earlier := setup(t)
+
+		first := models.Grade{StudentID: student.ID, CourseID: later.ID, LecturerID: later.LecturerID, Grade: "B",
+			RecordedAt: recordedAt}
+		changed := first
+		changed.Grade = "A-"
+		changed.RecordedAt = recordedAt.Add(time.Hour)
+		other := models.Grade{StudentID: student.ID, CourseID: earlier.ID, LecturerID: earlier.LecturerID,
+			Grade: "C+", RecordedAt: recordedAt}
+
+		var calls []checked
+		for _, grade := range []models.Grade{first, changed, other} {
+			grade := grade
+			_, err := repos.Grades.Save(ctx, &grade, record(&calls, nil))
+			if err != nil {
+				t.Fatalf("Error saving %v : %v", grade, err)
+			}
+		}
+
+		expectedCalls := []checked{
+			{course: later, enrolled: true},
+			{course: later, enrolled: true, existing: &first},
+			{course: earlier, enrolled: true},
+		}
+		if !reflect.DeepEqual(calls, expectedCalls) {
+			t.Errorf("Expected the checks %v, but got %v", expectedCalls, calls)
+		}
+
+		expected := []models.Grade{changed, other}
+		if earlier.ID < later.ID {
+			expected = []models.Grade{other, changed}
+		}
+		actual, err := repos.Grades.GetByStudent(ctx, student.ID)
+		if err != nil || !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Expected %v, but got %v, %v", expected, actual, err)
+		}
+
+		expectedLines := []models.TranscriptLine{
+			{CourseID: earlier.ID, Code: "PU201", Title: "Power Units", Semester: "2022-S2", Credits: 4,
+				Grade: "C+"},
+			{CourseID: later.ID, Code: "AE101", Title: "Aerodynamics", Semester: "2023-S1", Credits: 3,
+				Grade: "A-"},
+		}
+		lines, err := repos.Grades.GetTranscript(ctx, student.ID)
+		if err != nil || !reflect.DeepEqual(lines, expectedLines) {
+			t.Errorf("Expected the transcript %v, but got %v, %v", expectedLines, lines, err)
+		}
+	})
+
+	t.Run("Check Stops The Save", func(t *testing.T) {
+		repos, student, later, _ := setup(t)
+
+		_, err := repos.Enrolments.Withdraw(ctx, student.ID, later.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var calls []checked
+		_, err = repos.Grades.Save(ctx, &models.Grade{StudentID: student.ID, CourseID: later.ID,
+			LecturerID: later.LecturerID, Grade: "A", RecordedAt: recordedAt},
+			record(&calls, apperrors.Unprocessable(consts.NotEnrolledError)))
+		if apperrors.CodeOf(err) != apperrors.CodeUnprocessable {
+			t.Errorf("Expected the error of the check, but got %v", err)
+		}
+
+		expectedCalls := []checked{{course: later, enrolled: false}}
+		if !reflect.DeepEqual(calls, expectedCalls) {
+			t.Errorf("Expected the checks %v, but got %v", expectedCalls, calls)
+		}
+
+		actual, err := repos.Grades.GetByStudent(ctx, student.ID)
+		if err != nil || len(actual) != 0 {
+			t.Errorf("Expected no grades, but got %v, %v", actual, err)
+		}
+	})
+
+	t.Run("Save Missing", func(t *testing.T) {
+		repos, student, later, _ := setup(t)
+
+		var calls []checked
+		for _, grade := range []models.Grade{
+			{StudentID: missingID, CourseID: later.ID, LecturerID: later.LecturerID, Grade: "A",
+				RecordedAt: recordedAt},
+			{StudentID: student.ID, CourseID: missingID, LecturerID: later.LecturerID, Grade: "A",
+				RecordedAt: recordedAt},
+		} {
+			grade := grade
+			_, err := repos.Grades.Save(ctx, &grade, record(&calls, nil))
+			if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+				t.Errorf("Expected saving %v to be not found, but got %v", grade, err)
+			}
+		}
+		if len(calls) != 0 {
+			t.Errorf("Expected no checks, but got %v", calls)
+		}
+	})
+
+	t.Run("Withdraw Graded", func(t *testing.T) {
+		repos, student, later, _ := setup(t)
+
+		grade := models.Grade{StudentID: student.ID, CourseID: later.ID, LecturerID: later.LecturerID, Grade: "A",
+			RecordedAt: recordedAt}
+		_, err := repos.Grades.Save(ctx, &grade, func(*models.Course, bool, *models.Grade) error { return nil })
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = repos.Enrolments.Withdraw(ctx, student.ID, later.ID)
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected withdrawing the graded enrolment to be a conflict, but got %v", err)
+		}
+
+		enrolments, err := repos.Enrolments.GetByStudent(ctx, student.ID)
+		if err != nil || len(enrolments) != 2 {
+			t.Errorf("Expected the enrolments to be kept, but got %v, %v", enrolments, err)
+		}
+		grades, err := repos.Grades.GetByStudent(ctx, student.ID)
+		if err != nil || !reflect.DeepEqual(grades, []models.Grade{grade}) {
+			t.Errorf("Expected the grade to be kept, but got %v, %v", grades, err)
+		}
+	})
+
+	t.Run("Delete Graded Student", func(t *testing.T) {
+		repos, student, later, earlier := setup(t)
+
+		_, err := repos.Grades.Save(ctx, &models.Grade{StudentID: student.ID, CourseID: later.ID,
+			LecturerID: later.LecturerID, Grade: "A", RecordedAt: recordedAt},
+			func(*models.Course, bool, *models.Grade) error { return nil })
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = repos.Enrolments.Withdraw(ctx, student.ID, earlier.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = repos.Students.Delete(ctx, student.ID)
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected deleting the graded student to be a conflict, but got %v", err)
+		}
+		_, err = repos.Courses.Delete(ctx, later.ID)
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected deleting the graded course to be a conflict, but got %v", err)
+		}
+		_, err = repos.Courses.Delete(ctx, earlier.ID)
+		if err != nil {
+			t.Errorf("Expected the course without grades to be deleted, but got %v", err)
+		}
+	})
+}
+
+// testDepartmentConformance checks the department of the lecturers and staff,
+// the department heads and the department filter of the searches
+func testDepartmentConformance(t *testing.T, newRepos func(t *testing.T) *Repositories) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*Repositories, models.Department, models.Department) {
+		repos := newRepos(t)
+		var departments []models.Department
+		for _, d := range []models.Department{{Name: "Aerodynamics"}, {Name: "Chassis"}} {
+			d := d
+			created, err := repos.Departments.Create(ctx, &d)
+			if err != nil {
+				t.Fatalf("Error creating %v : %v", d, err)
+			}
+			departments = append(departments, *created)
+		}
+		return repos, departments[0], departments[1]
+	}
+
+	createLecturers := func(t *testing.T, repos *Repositories, lecturers ...models.Lecturer) []models.Lecturer {
+		var created []models.Lecturer
+		for _, l := range lecturers {
+			l := l
+			c, err := repos.Lecturers.Create(ctx, &l)
+			if err != nil {
+				t.Fatalf("Error creating %v : %v", l, err)
+			}
+			created = append(created, *c)
+		}
+		return created
+	}
+
+	t.Run("Members Of A Department", func(t *testing.T) {
+		repos, aero, chassis := setup(t)
+
+		lecturers := createLecturers(t, repos,
+			models.Lecturer{FirstName: "Adrian", LastName: "Newey", Year: 30, DepartmentID: &aero.ID},
+			models.Lecturer{FirstName: "Rory", LastName: "Byrne", Year: 25, DepartmentID: &chassis.ID},
+			models.Lecturer{FirstName: "Ross", LastName: "Brawn", Year: 28})
+		staff := models.Staff{FirstName: "Toto", LastName: "Wolff", Position: "Registrar", DepartmentID: &aero.ID}
+		createdStaff, err := repos.Staff.Create(ctx, &staff)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := repos.Departments.GetLecturers(ctx, aero.ID)
+		if err != nil || !reflect.DeepEqual(actual, []models.Lecturer{lecturers[0]}) {
+			t.Errorf("Expected %v, but got %v, %v", lecturers[:1], actual, err)
+		}
+
+		members, err := repos.Departments.GetStaff(ctx, aero.ID)
+		if err != nil || !reflect.DeepEqual(members, []models.Staff{*createdStaff}) {
+			t.Errorf("Expected %v, but got %v, %v", *createdStaff, members, err)
+		}
+
+		none, err := repos.Departments.GetStaff(ctx, chassis.ID)
+		if err != nil || len(none) != 0 {
+			t.Errorf("Expected no staff, but got %v, %v", none, err)
+		}
+	})
+
+	t.Run("Search By Department", func(t *testing.T) {
+		repos, aero, chassis := setup(t)
+
+		lecturers := createLecturers(t, repos,
+			models.Lecturer{FirstName: "Adrian", LastName: "Newey", Year: 30, DepartmentID: &aero.ID},
+			models.Lecturer{FirstName: "Rory", LastName: "Byrne", Year: 25, DepartmentID: &chassis.ID},
+			models.Lecturer{FirstName: "Aldo", LastName: "Costa", Year: 20, DepartmentID: &chassis.ID})
+
+		actual, err := repos.Lecturers.Search(ctx, "", filtered(t, fmt.Sprintf("departmentId:eq:%d", chassis.ID)),
+			models.Pagination{Page: 0, PageSize: 10}, models.SortBy{Column: "year", Direction: "desc"})
+		expected := models.SearchData[models.Lecturer]{TotalElements: 2,
+			Data: []models.Lecturer{lecturers[1], lecturers[2]}}
+		if err != nil || !reflect.DeepEqual(*actual, expected) {
+			t.Errorf("Expected %v, but got %v, %v", expected, actual, err)
+		}
+
+		actual, err = repos.Lecturers.Search(ctx, "a", filtered(t, fmt.Sprintf("departmentId:eq:%d", aero.ID)),
+			models.Pagination{Page: 0, PageSize: 10}, models.SortBy{})
+		expected = models.SearchData[models.Lecturer]{TotalElements: 1, Data: []models.Lecturer{lecturers[0]}}
+		if err != nil || !reflect.DeepEqual(*actual, expected) {
+			t.Errorf("Expected %v, but got %v, %v", expected, actual, err)
+		}
+
+		_, err = repos.Lecturers.Search(ctx, "", filtered(t, "position:eq:Registrar"),
+			models.Pagination{Page: 0, PageSize: 10}, models.SortBy{})
+		if apperrors.CodeOf(err) != apperrors.CodeValidation {
+			t.Errorf("Expected a validation error, but got %v", err)
+		}
+	})
+
+	t.Run("Filter Lecturers", func(t *testing.T) {
+		repos, aero, chassis := setup(t)
+
+		lecturers := createLecturers(t, repos,
+			models.Lecturer{FirstName: "Adrian", LastName: "Newey", Year: 30, DepartmentID: &aero.ID},
+			models.Lecturer{FirstName: "Rory", LastName: "Byrne", Year: 25, DepartmentID: &chassis.ID},
+			models.Lecturer{FirstName: "Aldo", LastName: "Costa", Year: 20, DepartmentID: &chassis.ID})
+
+		list, err := repos.Lecturers.GetAll(ctx, filtered(t, fmt.Sprintf("departmentId:eq:%d,year:lt:25", chassis.ID)))
+		expected := []models.Lecturer{lecturers[2]}
+		if err != nil || !reflect.DeepEqual(list, expected) {
+			t.Errorf("Expected %v, but got %v, %v", expected, list, err)
+		}
+
+		actual, err := repos.Lecturers.Search(ctx, "", filtered(t, "year:gte:25"),
+			models.Pagination{Page: 0, PageSize: 10}, models.SortBy{Column: "year"})
+		expectedData := models.SearchData[models.Lecturer]{TotalElements: 2,
+			Data: []models.Lecturer{lecturers[1], lecturers[0]}}
+		if err != nil || !reflect.DeepEqual(*actual, expectedData) {
+			t.Errorf("Expected %v, but got %v, %v", expectedData, actual, err)
+		}
+	})
+
+	t.Run("Department Head", func(t *testing.T) {
+		repos, aero, _ := setup(t)
+
+		lecturers := createLecturers(t, repos,
+			models.Lecturer{FirstName: "Adrian", LastName: "Newey", Year: 30, DepartmentID: &aero.ID})
+
+		headed := aero
+		headed.HeadID = &lecturers[0].ID
+		_, err := repos.Departments.Update(ctx, &headed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		actual, err := repos.Departments.GetByHead(ctx, lecturers[0].ID)
+		if err != nil || !reflect.DeepEqual(actual, []models.Department{headed}) {
+			t.Errorf("Expected %v, but got %v, %v", headed, actual, err)
+		}
+
+		missing := aero
+		missing.HeadID = new(int)
+		*missing.HeadID = missingID
+		_, err = repos.Departments.Update(ctx, &missing)
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected a not found error for a missing head, but got %v", err)
+		}
+
+		_, err = repos.Lecturers.Delete(ctx, lecturers[0].ID)
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected a conflict deleting the head, but got %v", err)
+		}
+	})
+
+	t.Run("Department Head From Another Department", func(t *testing.T) {
+		repos, aero, chassis := setup(t)
+
+		lecturers := createLecturers(t, repos,
+			models.Lecturer{FirstName: "Rory", LastName: "Byrne", Year: 25, DepartmentID: &chassis.ID})
+
+		headed := aero
+		headed.HeadID = &lecturers[0].ID
+		_, err := repos.Departments.Update(ctx, &headed)
+		if apperrors.CodeOf(err) != apperrors.CodeUnprocessable {
+			t.Errorf("Expected an unprocessable error for an update, but got %v", err)
+		}
+
+		created := models.Department{Name: "Engines", HeadID: &lecturers[0].ID}
+		_, err = repos.Departments.Create(ctx, &created)
+		if apperrors.CodeOf(err) != apperrors.CodeUnprocessable {
+			t.Errorf("Expected an unprocessable error for a create, but got %v", err)
+		}
+
+		created = models.Department{ID: chassis.ID, Name: "Engines", HeadID: &lecturers[0].ID}
+		_, err = repos.Departments.Create(ctx, &created)
+		if apperrors.CodeOf(err) != apperrors.CodeUnprocessable {
+			t.Errorf("Expected an unprocessable error for a create with the id of the department, but got %v", err)
+		}
+	})
+
+	t.Run("Move Department Head", func(t *testing.T) {
+		repos, aero, chassis := setup(t)
+
+		lecturers := createLecturers(t, repos,
+			models.Lecturer{FirstName: "Adrian", LastName: "Newey", Year: 30, DepartmentID: &aero.ID},
+			models.Lecturer{FirstName: "Rory", LastName: "Byrne", Year: 25, DepartmentID: &aero.ID})
+
+		headed := aero
+		headed.HeadID = &lecturers[0].ID
+		_, err := repos.Departments.Update(ctx, &headed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		moved := lecturers[0]
+		moved.DepartmentID = &chassis.ID
+		_, err = repos.Lecturers.Update(ctx, &moved)
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected a conflict moving the head, but got %v", err)
+		}
+
+		_, err = repos.Lecturers.Patch(ctx, lecturers[0].ID, func(lecturer *models.Lecturer) error {
+			lecturer.DepartmentID = nil
+			return nil
+		})
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected a conflict patching the head out, but got %v", err)
+		}
+
+		other := lecturers[1]
+		other.DepartmentID = &chassis.ID
+		_, err = repos.Lecturers.Update(ctx, &other)
+		if err != nil {
+			t.Errorf("Expected to move a lecturer who is not the head, but got %v", err)
+		}
+
+		renamed, err := repos.Lecturers.Patch(ctx, lecturers[0].ID, func(lecturer *models.Lecturer) error {
+			lecturer.Year = 31
+			return nil
+		})
+		if err != nil || renamed.Year != 31 {
+			t.Errorf("Expected the head to stay in the department, but got %v, %v", renamed, err)
+		}
+	})
+
+	t.Run("Missing Department", func(t *testing.T) {
+		repos, _, _ := setup(t)
+
+		department := missingID
+		lecturer := models.Lecturer{FirstName: "Adrian", LastName: "Newey", Year: 30, DepartmentID: &department}
+		_, err := repos.Lecturers.Create(ctx, &lecturer)
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected a conflict for the lecturer, but got %v", err)
+		}
+
+		staff := models.Staff{FirstName: "Toto", LastName: "Wolff", Position: "Registrar", DepartmentID: &department}
+		_, err = repos.Staff.Create(ctx, &staff)
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected a conflict for the staff, but got %v", err)
+		}
+
+		created := createLecturers(t, repos, models.Lecturer{FirstName: "Adrian", LastName: "Newey", Year: 30})
+		_, err = repos.Lecturers.Patch(ctx, created[0].ID, func(lecturer *models.Lecturer) error {
+			lecturer.DepartmentID = &department
+			return nil
+		})
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected a conflict for the patched lecturer, but got %v", err)
+		}
+	})
+
+	t.Run("Delete Referenced Department", func(t *testing.T) {
+		repos, aero, chassis := setup(t)
+
+		createLecturers(t, repos,
+			models.Lecturer{FirstName: "Adrian", LastName: "Newey", Year: 30, DepartmentID: &aero.ID})
+
+		_, err := repos.Departments.Delete(ctx, aero.ID)
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected a conflict, but got %v", err)
+		}
+
+		_, err = repos.Departments.Delete(ctx, chassis.ID)
+		if err != nil {
+			t.Errorf("Expected the department without members to be deleted, but got %v", err)
+		}
+	})
+
+	t.Run("Duplicate Department Name", func(t *testing.T) {
+		repos, _, _ := setup(t)
+
+		_, err := repos.Departments.Create(ctx, &models.Department{Name: "Aerodynamics"})
+		if apperrors.CodeOf(err) != apperrors.CodeConflict {
+			t.Errorf("Expected a conflict, but got %v", err)
+		}
+	})
+}
+
+// testStaffConformance checks the numbering of the staff with the staff
+// repository of the repositories newRepos returns
+func testStaffConformance(t *testing.T, newRepos func(t *testing.T) *Repositories) {
+	ctx := context.Background()
+
+	t.Run("Staff Number", func(t *testing.T) {
+		repos := newRepos(t)
+
+		var created []models.Staff
+		for _, name := range []string{"Wolff", "Horner"} {
+			staff := models.Staff{FirstName: "Toto", LastName: name, Position: "Principal"}
+			c, err := repos.Staff.Create(ctx, &staff)
+			if err != nil {
+				t.Fatalf("Error creating %v : %v", staff, err)
+			}
+			created = append(created, *c)
+		}
+
+		for i, expected := range []string{"STF0001", "STF0002"} {
+			if number := created[i].StaffNumber; number == nil || *number != expected {
+				t.Errorf("Expected the staff number %s, but got %v", expected, number)
+			}
+		}
+
+		actual, err := repos.Staff.GetByNumber(ctx, "STF0002")
+		if err != nil || !reflect.DeepEqual(*actual, created[1]) {
+			t.Errorf("Expected %v, but got %v, %v", created[1], actual, err)
+		}
+
+		_, err = repos.Staff.GetByNumber(ctx, "STF0003")
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Errorf("Expected a not found error, but got %v", err)
+		}
+	})
+}
+
+// testAuditConformance checks the changes of the CRUD entities are audited
+func testAuditConformance(t *testing.T, newRepos func(t *testing.T) *Repositories) {
+	ctx := auth.WithClaims(context.Background(), &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "admin-1"},
+	})
+
+	// changes returns the changes of the event as before and after strings
+	changes := func(event models.AuditEvent) map[string][2]string {
+		list := map[string][2]string{}
+		for name, change := range event.Changes {
+			list[name] = [2]string{string(change.Before), string(change.After)}
+		}
+		return list
+	}
+
+	actions := func(events []models.AuditEvent) []models.AuditAction {
+		var list []models.AuditAction
+		for _, event := range events {
+			list = append(list, event.Action)
+		}
+		return list
+	}
+
+	t.Run("Audit Trail", func(t *testing.T) {
+		repos := newRepos(t)
+		st := models.Student{FirstName: "Charles", LastName: "Leclerc", Year: 2}
+		created, err := repos.Students.Create(ctx, &st)
+		if err != nil {
+			t.Fatal(err)
+		}
+		update := *created
+		update.Year = 3
+		_, err = repos.Students.Update(ctx, &update)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = repos.Students.Delete(ctx, created.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = repos.Students.Restore(ctx, created.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = repos.Lecturers.Create(ctx, &models.Lecturer{FirstName: "Adrian", LastName: "Newey", Year: 30})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		events, err := repos.Audit.List(ctx, "student", created.ID, models.Pagination{PageSize: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []models.AuditAction{models.AuditRestore, models.AuditDelete, models.AuditUpdate,
+			models.AuditCreate}
+		if events.TotalElements != 4 || !reflect.DeepEqual(actions(events.Data), expected) {
+			t.Fatalf("Expected the events %v, but got %d %v", expected, events.TotalElements, actions(events.Data))
+		}
+		for _, event := range events.Data {
+			if event.Actor != "admin-1" || event.Entity != "student" || event.EntityID != created.ID ||
+				event.Timestamp.IsZero() {
+				t.Errorf("Expected an event of student %d by admin-1, but got %+v", created.ID, event)
+			}
+		}
+
+		c := changes(events.Data[3])
+		if _, email := c["email"]; email || c["firstname"] != [2]string{"null", `"Charles"`} ||
+			c["year"] != [2]string{"null", "2"} {
+			t.Errorf("Expected the create to list the fields set, but got %v", c)
+		}
+		if c := changes(events.Data[2]); !reflect.DeepEqual(c, map[string][2]string{"year": {"2", "3"}}) {
+			t.Errorf("Expected the update to change the year, but got %v", c)
+		}
+		if c := changes(events.Data[1]); len(c) != 1 || c["deletedAt"][0] != "null" || c["deletedAt"][1] == "null" {
+			t.Errorf("Expected the delete to set deletedAt, but got %v", c)
+		}
+		if c := changes(events.Data[0]); len(c) != 1 || c["deletedAt"][1] != "null" {
+			t.Errorf("Expected the restore to clear deletedAt, but got %v", c)
+		}
+
+		page, err := repos.Audit.List(ctx, "student", created.ID, models.Pagination{Page: 1, PageSize: 2})
+		if err != nil || page.TotalElements != 4 ||
+			!reflect.DeepEqual(actions(page.Data), []models.AuditAction{models.AuditDelete, models.AuditUpdate}) {
+			t.Errorf("Expected the second and third events, but got %v, %v", page, err)
+		}
+
+		all, err := repos.Audit.List(ctx, "", 0, models.Pagination{PageSize: 10})
+		if err != nil || all.TotalElements != 5 || all.Data[0].Entity != "lecturer" {
+			t.Errorf("Expected the events of every entity, but got %v, %v", all, err)
+		}
+	})
+
+	t.Run("Failed Change Is Not Audited", func(t *testing.T) {
+		repos := newRepos(t)
+		_, err := repos.Students.Update(ctx, &models.Student{ID: missingID, FirstName: "Lando", LastName: "Norris"})
+		if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+			t.Fatalf("Expected a not found error, but got %v", err)
+		}
+
+		events, err := repos.Audit.List(ctx, "", 0, models.Pagination{PageSize: 10})
+		if err != nil || len(events.Data) != 0 {
+			t.Errorf("Expected no events, but got %v, %v", events, err)
+		}
+	})
+
+	t.Run("Purge Is Audited", func(t *testing.T) {
+		repos := newRepos(t)
+		// the changes made without a caller are anonymous
+		created, err := repos.Lecturers.Create(context.Background(),
+			&models.Lecturer{FirstName: "Rory", LastName: "Byrne", Year: 25})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = repos.Lecturers.Delete(ctx, created.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = repos.Lecturers.Purge(context.Background(), time.Now().Add(time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		events, err := repos.Audit.List(ctx, "lecturer", created.ID, models.Pagination{PageSize: 10})
+		if err != nil || len(events.Data) != 3 {
+			t.Fatalf("Expected three events, but got %v, %v", events, err)
+		}
+		if purge := events.Data[0]; purge.Action != models.AuditPurge || purge.Actor != "system" ||
+			changes(purge)["lastname"] != [2]string{`"Byrne"`, "null"} {
+			t.Errorf("Expected the purge by the system, but got %+v", purge)
+		}
+		if create := events.Data[2]; create.Actor != "anonymous" {
+			t.Errorf("Expected an anonymous create, but got %+v", create)
+		}
+	})
+}
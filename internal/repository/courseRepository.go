@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+)
+
+type CourseRepository interface {
+	Repository[models.Course]
+	// GetByLecturer returns the courses the lecturer teaches
+	GetByLecturer(ctx context.Context, lecturerID int) ([]models.Course, error)
+}
+
+var courseTable = Table[models.Course]{
+	Name:          "courses",
+	Resource:      models.CourseResource,
+	Columns:       []string{"code", "title", "credits", "semester", "lecturer_id", "capacity", "min_year"},
+	SearchColumns: []string{"code", "title"},
+	SortColumns: map[string]string{
+		"id":         "id",
+		"code":       "code",
+		"title":      "title",
+		"credits":    "credits",
+		"semester":   "semester",
+		"lecturerid": "lecturer_id",
+		"capacity":   "capacity",
+		"minyear":    "min_year",
+	},
+	Unique: []string{"code"},
+	ID: func(course *models.Course) *int {
+		return &course.ID
+	},
+	Fields: func(course *models.Course) []interface{} {
+		return []interface{}{&course.Code, &course.Title, &course.Credits, &course.Semester, &course.LecturerID,
+			&course.Capacity, &course.MinYear}
+	},
+}
+
+// listRepository is a repository that can list the records by a column,
+// both the SQL and the memory repositories are
+type listRepository[T any] interface {
+	Repository[T]
+	listBy(ctx context.Context, column string, value interface{}) ([]T, error)
+}
+
+type courseRepository struct {
+	listRepository[models.Course]
+}
+
+func NewCourseRepository(db *sql.DB, dialect Dialect) CourseRepository {
+	return courseRepository{NewRepository(db, dialect, courseTable)}
+}
+
+// NewMemoryCourseRepository returns a memory repository whose courses
+// reference the lecturers of lecturers
+func NewMemoryCourseRepository(lecturers *memoryRepository[models.Lecturer]) CourseRepository {
+	return courseRepository{newMemoryCourses(lecturers)}
+}
+
+// newMemoryCourses returns the memory repository of the courses, which the
+// memory repositories referencing the courses need
+func newMemoryCourses(lecturers *memoryRepository[models.Lecturer]) *memoryRepository[models.Course] {
+	courses := NewMemoryRepository(courseTable)
+	reference(courses, "lecturer_id", lecturers)
+	return courses
+}
+
+func (s courseRepository) GetByLecturer(ctx context.Context, lecturerID int) ([]models.Course, error) {
+	return s.listBy(ctx, "lecturer_id", lecturerID)
+}
@@ -0,0 +1,1062 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/filter"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/numbering"
+	"github.com/tryfix/log"
+)
+
+// Repository is the set of CRUD operations every entity supports
+type Repository[T any] interface {
+	// GetAll returns the records kept by the filter expression, every
+	// record when it is empty
+	GetAll(ctx context.Context, expr filter.Expr) ([]T, error)
+	Get(ctx context.Context, id int) (*T, error)
+	Create(ctx context.Context, entity *T) (*T, error)
+	Update(ctx context.Context, entity *T) (*T, error)
+	// Search returns the page of the records matching the search string
+	// that are kept by the filter expression
+	Search(ctx context.Context, searchString string, expr filter.Expr, pagination models.Pagination,
+		sortBy models.SortBy) (*models.SearchData[T], error)
+	Delete(ctx context.Context, id int) (*T, error)
+}
+
+// SoftDeleteRepository is a Repository whose Delete only marks the record
+// deleted. The deleted records are left out of every read unless the context
+// comes from WithDeleted, and they are removed for good by Purge
+type SoftDeleteRepository[T any] interface {
+	Repository[T]
+	// Restore undeletes the record and returns it, a record that is not
+	// deleted is not found
+	Restore(ctx context.Context, id int) (*T, error)
+	// Purge removes for good the records deleted before the time and returns
+	// how many were removed, the records still referenced are kept
+	Purge(ctx context.Context, before time.Time) (int, error)
+}
+
+// Patcher changes a record in place, storing only the columns the change
+// touched
+type Patcher[T any] interface {
+	// Patch reads the record, changes it with patch and stores it, in one
+	// transaction, and returns it as stored. patch must not use the database
+	// nor write through the pointers of the record, it replaces the fields.
+	// The id, number, deletion and version of the record are kept whatever
+	// patch does, and a record whose version ctx does not expect is a
+	// precondition failure like with Update
+	Patch(ctx context.Context, id int, patch func(entity *T) error) (*T, error)
+}
+
+type deletedKey struct{}
+
+// WithDeleted returns a context whose reads include the soft deleted records
+func WithDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, deletedKey{}, true)
+}
+
+// includesDeleted tells whether the reads of ctx include the soft deleted records
+func includesDeleted(ctx context.Context) bool {
+	included, _ := ctx.Value(deletedKey{}).(bool)
+	return included
+}
+
+type versionKey struct{}
+
+// IfVersion returns a context whose updates and deletes only change a record
+// whose version is one of versions, the change of a record with any other
+// version is a precondition failure. No versions match no record
+func IfVersion(ctx context.Context, versions ...int) context.Context {
+	if versions == nil {
+		versions = []int{}
+	}
+	return context.WithValue(ctx, versionKey{}, versions)
+}
+
+// Table describes how an entity is stored
+type Table[T any] struct {
+	Name     string
+	Resource models.Resource
+	// Columns lists every column except id, in the order returned by Fields
+	Columns []string
+	// SearchColumns are matched against the search string
+	SearchColumns []string
+	// SortColumns is the whitelist of columns the search results can be
+	// ordered by, keyed by the lower case JSON name as the requested column
+	// is lowered
+	SortColumns map[string]string
+	// FilterFields are the fields the filter expressions of the lists and
+	// searches can compare, keyed like SortColumns
+	FilterFields map[string]FilterField
+	// Unique lists the columns with a unique index, the memory repository
+	// checks them
+	Unique []string
+	// Number is the column holding the registration number of the entity,
+	// if it has one, a *string field that is generated on create and never
+	// updated. NumberField is its JSON name, an update sending another
+	// number is rejected on it
+	Number      string
+	NumberField string
+	ID          func(entity *T) *int
+	Fields      func(entity *T) []interface{}
+	// DeletedAt returns the field of the deleted_at column, the records of
+	// a table with DeletedAt are soft deleted. The column is not one of the
+	// Columns as it is only set by Delete and Restore
+	DeletedAt func(entity *T) **time.Time
+	// Version returns the field of the version column counting the changes,
+	// it is set to 1 on create and incremented by every change
+	Version func(entity *T) *int
+	// ReferencedBy lists the columns of other tables holding the id of a
+	// record, a soft deleted record must not be referenced like one deleted
+	// for good
+	ReferencedBy []Reference
+	// Check, when set, checks a record about to be created, updated or
+	// patched in the transaction storing it, a non nil error stops the
+	// change. It may read and lock the records of other tables, so the rule
+	// it checks holds until the change is stored
+	Check func(ctx context.Context, tx *sql.Tx, dialect Dialect, entity *T) error
+}
+
+// Reference is a column of another table holding the id of a record
+type Reference struct {
+	Table  string
+	Column string
+}
+
+// entity returns the name of the entity in the audit events, eg. student
+func (t Table[T]) entity() string {
+	return strings.ToLower(t.Resource.Name)
+}
+
+// selectColumns returns the column list used in SELECT statements
+func (t Table[T]) selectColumns() string {
+	columns := "id, " + strings.Join(t.Columns, ", ")
+	if t.DeletedAt != nil {
+		columns += ", deleted_at"
+	}
+	if t.Version != nil {
+		columns += ", version"
+	}
+	return columns
+}
+
+// nextVersion returns the assignment incrementing the version, added to the
+// SET clause of every change. It is empty when the table has no Version
+func (t Table[T]) nextVersion() string {
+	if t.Version == nil {
+		return ""
+	}
+	return ", version = version + 1"
+}
+
+// checkVersion returns a precondition failure when ctx comes from IfVersion
+// and the version of the entity is not one it gives
+func (t Table[T]) checkVersion(ctx context.Context, entity *T) error {
+	versions, ok := ctx.Value(versionKey{}).([]int)
+	if !ok || t.Version == nil {
+		return nil
+	}
+	for _, version := range versions {
+		if version == *t.Version(entity) {
+			return nil
+		}
+	}
+	return apperrors.PreconditionFailed(consts.VersionMismatchError)
+}
+
+// checkNumber rejects the update of stored to entity when entity has a
+// number other than the stored one, an update without a number keeps it
+func (t Table[T]) checkNumber(stored *T, entity *T) error {
+	number := t.number(entity)
+	if number == nil || *number == nil {
+		return nil
+	}
+	if current := *t.number(stored); current != nil && *current == **number {
+		return nil
+	}
+	return apperrors.InvalidFields(consts.InvalidRequestBody, []apperrors.FieldError{
+		{Field: t.NumberField, Message: "can not be changed"},
+	})
+}
+
+// updateColumns returns the columns set by an update, every column but the
+// number
+func (t Table[T]) updateColumns() []string {
+	var columns []string
+	for _, column := range t.Columns {
+		if column != t.Number {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// updateFields returns the fields of the updateColumns
+func (t Table[T]) updateFields(entity *T) []interface{} {
+	var fields []interface{}
+	for i, field := range t.Fields(entity) {
+		if t.Columns[i] != t.Number {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// changedColumns returns the updateColumns whose value differs between
+// before and after, with the fields of after holding them
+func (t Table[T]) changedColumns(before *T, after *T) ([]string, []interface{}) {
+	var columns []string
+	var fields []interface{}
+	old := t.updateFields(before)
+	for i, field := range t.updateFields(after) {
+		if !reflect.DeepEqual(old[i], field) {
+			columns = append(columns, t.updateColumns()[i])
+			fields = append(fields, field)
+		}
+	}
+	return columns, fields
+}
+
+// keep copies to the patched entity the fields of the stored one a patch can
+// not change, the id, the number, the deletion and the version
+func (t Table[T]) keep(stored *T, patched *T) {
+	*t.ID(patched) = *t.ID(stored)
+	if number := t.number(patched); number != nil {
+		*number = *t.number(stored)
+	}
+	if t.DeletedAt != nil {
+		*t.DeletedAt(patched) = *t.DeletedAt(stored)
+	}
+	if t.Version != nil {
+		*t.Version(patched) = *t.Version(stored)
+	}
+}
+
+// number returns the field holding the registration number, nil when the
+// table has no number
+func (t Table[T]) number(entity *T) **string {
+	for i, column := range t.Columns {
+		if column == t.Number {
+			return t.Fields(entity)[i].(**string)
+		}
+	}
+	return nil
+}
+
+// scanFields returns the destinations a row read with selectColumns is scanned into
+func (t Table[T]) scanFields(entity *T) []interface{} {
+	fields := append([]interface{}{t.ID(entity)}, t.Fields(entity)...)
+	if t.DeletedAt != nil {
+		fields = append(fields, t.DeletedAt(entity))
+	}
+	if t.Version != nil {
+		fields = append(fields, t.Version(entity))
+	}
+	return fields
+}
+
+// live adds the condition matching the records that are not soft deleted to
+// condition, which may be empty
+func (t Table[T]) live(condition string) string {
+	if t.DeletedAt == nil {
+		return condition
+	}
+	if condition == "" {
+		return "deleted_at IS NULL"
+	}
+	return condition + " AND deleted_at IS NULL"
+}
+
+// where returns the WHERE clause of a read matching condition, the soft
+// deleted records are left out unless ctx includes them. It is empty when
+// there is nothing to match
+func (t Table[T]) where(ctx context.Context, condition string) string {
+	if !includesDeleted(ctx) {
+		condition = t.live(condition)
+	}
+	if condition == "" {
+		return ""
+	}
+	return " WHERE " + condition
+}
+
+func (t Table[T]) search(ctx context.Context, expr filter.Expr, dialect Dialect) (searchQuery, error) {
+	condition, args, err := t.filter(expr, dialect)
+	if err != nil {
+		return searchQuery{}, err
+	}
+
+	var conditions []string
+	if condition != "" {
+		conditions = append(conditions, condition)
+	}
+	if live := t.live(""); live != "" && !includesDeleted(ctx) {
+		conditions = append(conditions, live)
+	}
+	return searchQuery{
+		table:         t.Name,
+		columns:       t.selectColumns(),
+		searchColumns: t.SearchColumns,
+		sortColumns:   t.SortColumns,
+		conditions:    conditions,
+		conditionArgs: args,
+		likeEscape:    dialect.LikeEscape,
+	}, nil
+}
+
+type crudRepository[T any] struct {
+	db      *sql.DB
+	dialect Dialect
+	table   Table[T]
+	numbers numbering.Format
+}
+
+// NewRepository returns a repository that stores the entity in a SQL
+// database, dialect holds the SQL specific to the database
+func NewRepository[T any](db *sql.DB, dialect Dialect, table Table[T]) *crudRepository[T] {
+	return &crudRepository[T]{
+		db:      db,
+		dialect: dialect,
+		table:   table,
+	}
+}
+
+// NewNumberedRepository is like NewRepository for a table with a Number
+// column, the new records are numbered with format
+func NewNumberedRepository[T any](db *sql.DB, dialect Dialect, table Table[T],
+	format numbering.Format) *crudRepository[T] {
+	repo := NewRepository(db, dialect, table)
+	repo.numbers = format
+	return repo
+}
+
+func (s *crudRepository[T]) GetAll(ctx context.Context, expr filter.Expr) ([]T, error) {
+	condition, args, err := s.table.filter(expr, s.dialect)
+	if err != nil {
+		log.Error(consts.InvalidSearchError, err)
+		return nil, err
+	}
+
+	list, err := s.query(ctx, "SELECT "+s.table.selectColumns()+" FROM "+s.table.Name+s.table.where(ctx, condition),
+		args...)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("getAll "+s.table.Name+" response : ", list)
+	return list, nil
+}
+
+// listBy returns the records whose column holds value, ordered by id
+func (s *crudRepository[T]) listBy(ctx context.Context, column string, value interface{}) ([]T, error) {
+	list, err := s.query(ctx, "SELECT "+s.table.selectColumns()+" FROM "+s.table.Name+
+		s.table.where(ctx, column+" = ?")+" ORDER BY id;", value)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("list "+s.table.Name+" by "+column+" response : ", list)
+	return list, nil
+}
+
+// query returns the records read by a query selecting selectColumns
+func (s *crudRepository[T]) query(ctx context.Context, query string, args ...interface{}) ([]T, error) {
+	stmt, err := s.db.PrepareContext(ctx, query)
+	if err != nil {
+		log.Error(consts.QueryPrepareError, err)
+		return nil, dbError(ctx, err)
+	}
+	defer closeStmt(stmt)
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		log.Error(consts.DBResultsError, err)
+		return nil, dbError(ctx, err)
+	}
+	defer closeRows(rows)
+
+	var list []T
+
+	for rows.Next() {
+		var entity T
+
+		err := rows.Scan(s.table.scanFields(&entity)...)
+		if err != nil {
+			log.Error(consts.DBScanRowError, err)
+			return nil, dbError(ctx, err)
+		}
+
+		list = append(list, entity)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Error(consts.DBRowsError, err)
+		return nil, dbError(ctx, err)
+	}
+	return list, nil
+}
+
+func (s *crudRepository[T]) Get(ctx context.Context, id int) (*T, error) {
+	var entity T
+
+	stmt, err := s.db.PrepareContext(ctx, "SELECT "+s.table.selectColumns()+" FROM "+s.table.Name+
+		s.table.where(ctx, "id = ?")+";")
+	if err != nil {
+		log.Error(consts.QueryPrepareError, err)
+		return &entity, dbError(ctx, err)
+	}
+	defer closeStmt(stmt)
+
+	err = stmt.QueryRowContext(ctx, id).Scan(s.table.scanFields(&entity)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return new(T), apperrors.NotFound(s.table.Resource.NotFound)
+		}
+		log.Error(consts.DBResultsError, err)
+		return new(T), dbError(ctx, err)
+	}
+
+	log.Debug(s.table.Resource.Name+" : ", entity)
+	return &entity, nil
+}
+
+// getBy returns the record whose unique column holds value, a missing
+// record is a not found error
+func (s *crudRepository[T]) getBy(ctx context.Context, column string, value interface{}) (*T, error) {
+	list, err := s.query(ctx, "SELECT "+s.table.selectColumns()+" FROM "+s.table.Name+
+		s.table.where(ctx, column+" = ?")+";", value)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, apperrors.NotFound(s.table.Resource.NotFound)
+	}
+
+	log.Debug(s.table.Resource.Name+" : ", list[0])
+	return &list[0], nil
+}
+
+// Create inserts the record, numbering it first when the repository has a
+// number format. The number, the record and its audit event are written in
+// one transaction. The id of entity is ignored, the record is given a new one
+func (s *crudRepository[T]) Create(ctx context.Context, entity *T) (*T, error) {
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(s.table.Columns)), ",")
+
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		*s.table.ID(entity) = 0
+		if !s.numbers.IsZero() {
+			number, err := s.nextNumber(ctx, tx)
+			if err != nil {
+				return err
+			}
+			*s.table.number(entity) = &number
+		}
+		if s.table.DeletedAt != nil {
+			*s.table.DeletedAt(entity) = nil
+		}
+
+		err := s.check(ctx, tx, entity)
+		if err != nil {
+			return err
+		}
+
+		stmt, err := tx.PrepareContext(ctx, "INSERT INTO "+s.table.Name+" ("+
+			strings.Join(s.table.Columns, ",")+") VALUES ("+placeholders+");")
+		if err != nil {
+			log.Error(consts.QueryPrepareError, err)
+			return dbError(ctx, err)
+		}
+		defer closeStmt(stmt)
+
+		result, err := stmt.ExecContext(ctx, s.table.Fields(entity)...)
+		if err != nil {
+			log.Error(consts.DBResultsError, err)
+			return dbError(ctx, err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			log.Error(consts.DBResultIDError, err)
+			return dbError(ctx, err)
+		}
+
+		*s.table.ID(entity) = int(id)
+		if s.table.Version != nil {
+			*s.table.Version(entity) = 1
+		}
+		return s.audit(ctx, tx, actor(ctx), models.AuditCreate, nil, entity)
+	})
+	if err != nil {
+		return new(T), err
+	}
+
+	log.Debug(s.table.Resource.Name+" : ", *entity)
+	return entity, nil
+}
+
+// nextNumber returns the number of a record created now. The sequence of
+// the series stays locked until the transaction ends, so concurrent creates
+// never get the same number
+func (s *crudRepository[T]) nextNumber(ctx context.Context, tx *sql.Tx) (string, error) {
+	now := time.Now()
+	series := s.numbers.Series(now)
+
+	_, err := tx.ExecContext(ctx, s.dialect.NextSequence, series)
+	if err != nil {
+		log.Error(consts.DBResultsError, err)
+		return "", dbError(ctx, err)
+	}
+
+	var seq int
+	err = tx.QueryRowContext(ctx, "SELECT value FROM number_sequences WHERE name = ?;", series).Scan(&seq)
+	if err != nil {
+		log.Error(consts.DBResultsError, err)
+		return "", dbError(ctx, err)
+	}
+	return s.numbers.Number(now, seq), nil
+}
+
+// numberMissing numbers the records that have no number, in id order and
+// in the series of the records created now, and returns how many it
+// numbered. Soft deleted records are numbered too, they may be restored
+func (s *crudRepository[T]) numberMissing(ctx context.Context) (int, error) {
+	count := 0
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		ids, err := s.unnumbered(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			number, err := s.nextNumber(ctx, tx)
+			if err != nil {
+				return err
+			}
+			_, err = tx.ExecContext(ctx, "UPDATE "+s.table.Name+" SET "+s.table.Number+" = ? WHERE id = ?;",
+				number, id)
+			if err != nil {
+				log.Error(consts.DBResultsError, err)
+				return dbError(ctx, err)
+			}
+		}
+		count = len(ids)
+		return nil
+	})
+	return count, err
+}
+
+// unnumbered returns the ids of the records without a number, in id order
+func (s *crudRepository[T]) unnumbered(ctx context.Context, tx *sql.Tx) ([]int, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT id FROM "+s.table.Name+" WHERE "+s.table.Number+
+		" IS NULL ORDER BY id;")
+	if err != nil {
+		log.Error(consts.DBResultsError, err)
+		return nil, dbError(ctx, err)
+	}
+	defer closeRows(rows)
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		err := rows.Scan(&id)
+		if err != nil {
+			log.Error(consts.DBScanRowError, err)
+			return nil, dbError(ctx, err)
+		}
+		ids = append(ids, id)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Error(consts.DBRowsError, err)
+		return nil, dbError(ctx, err)
+	}
+	return ids, nil
+}
+
+// Update updates the record and returns it as stored. The record is read
+// before and after the update in the same transaction, to audit the
+// change, a missing record is a not found error and one whose version ctx
+// does not expect a precondition failure. The number is never updated and
+// a soft deleted record is missing
+func (s *crudRepository[T]) Update(ctx context.Context, entity *T) (*T, error) {
+	var updated *T
+
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		before, err := s.getTx(ctx, tx, *s.table.ID(entity))
+		if err != nil {
+			return err
+		}
+
+		err = s.table.checkVersion(ctx, before)
+		if err != nil {
+			return err
+		}
+
+		err = s.table.checkNumber(before, entity)
+		if err != nil {
+			return err
+		}
+
+		err = s.check(ctx, tx, entity)
+		if err != nil {
+			return err
+		}
+
+		stmt, err := tx.PrepareContext(ctx, "UPDATE "+s.table.Name+" SET "+
+			strings.Join(s.table.updateColumns(), " = ?, ")+" = ?"+s.table.nextVersion()+
+			" WHERE "+s.table.live("id = ?")+";")
+		if err != nil {
+			log.Error(consts.QueryPrepareError, err)
+			return dbError(ctx, err)
+		}
+		defer closeStmt(stmt)
+
+		result, err := stmt.ExecContext(ctx, append(s.table.updateFields(entity), *s.table.ID(entity))...)
+		if err != nil {
+			log.Error(consts.DBResultsError, err)
+			return dbError(ctx, err)
+		}
+
+		err = s.checkAffected(ctx, result)
+		if err != nil {
+			return err
+		}
+
+		updated, err = s.getTx(ctx, tx, *s.table.ID(entity))
+		if err != nil {
+			return err
+		}
+		return s.audit(ctx, tx, actor(ctx), models.AuditUpdate, before, updated)
+	})
+	if err != nil {
+		return new(T), err
+	}
+
+	log.Debug(s.table.Resource.Name+" : ", *updated)
+	return updated, nil
+}
+
+// Patch changes the record with patch and updates the columns it changed,
+// along with the version, in the transaction reading it. A patch that
+// changes nothing is not stored. A missing or soft deleted record is a not
+// found error
+func (s *crudRepository[T]) Patch(ctx context.Context, id int, patch func(entity *T) error) (*T, error) {
+	var patched *T
+
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		before, err := s.getTx(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.table.checkVersion(ctx, before)
+		if err != nil {
+			return err
+		}
+
+		changed := *before
+		err = patch(&changed)
+		if err != nil {
+			return err
+		}
+		s.table.keep(before, &changed)
+
+		columns, fields := s.table.changedColumns(before, &changed)
+		if len(columns) == 0 {
+			patched = before
+			return nil
+		}
+
+		err = s.check(ctx, tx, &changed)
+		if err != nil {
+			return err
+		}
+
+		stmt, err := tx.PrepareContext(ctx, "UPDATE "+s.table.Name+" SET "+
+			strings.Join(columns, " = ?, ")+" = ?"+s.table.nextVersion()+" WHERE "+s.table.live("id = ?")+";")
+		if err != nil {
+			log.Error(consts.QueryPrepareError, err)
+			return dbError(ctx, err)
+		}
+		defer closeStmt(stmt)
+
+		result, err := stmt.ExecContext(ctx, append(fields, id)...)
+		if err != nil {
+			log.Error(consts.DBResultsError, err)
+			return dbError(ctx, err)
+		}
+
+		err = s.checkAffected(ctx, result)
+		if err != nil {
+			return err
+		}
+
+		patched, err = s.getTx(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		return s.audit(ctx, tx, actor(ctx), models.AuditUpdate, before, patched)
+	})
+	if err != nil {
+		return new(T), err
+	}
+
+	log.Debug(s.table.Resource.Name+" : ", *patched)
+	return patched, nil
+}
+
+func (s *crudRepository[T]) Search(ctx context.Context, searchString string, expr filter.Expr,
+	pagination models.Pagination, sortBy models.SortBy) (*models.SearchData[T], error) {
+
+	search, err := s.table.search(ctx, expr, s.dialect)
+	if err != nil {
+		log.Error(consts.InvalidSearchError, err)
+		return nil, err
+	}
+
+	query, args, err := search.build(searchString, pagination, sortBy)
+	if err != nil {
+		log.Error(consts.InvalidSearchError, err)
+		return nil, err
+	}
+
+	stmt, err := s.db.PrepareContext(ctx, query)
+	if err != nil {
+		log.Error(consts.QueryPrepareError, err)
+		return nil, dbError(ctx, err)
+	}
+	defer closeStmt(stmt)
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		log.Error(consts.DBResultsError, err)
+		return nil, dbError(ctx, err)
+	}
+	defer closeRows(rows)
+
+	var list []T
+	var totalCount int
+	var resp models.SearchData[T]
+
+	for rows.Next() {
+		var entity T
+
+		err := rows.Scan(append(s.table.scanFields(&entity), &totalCount)...)
+		if err != nil {
+			log.Error(consts.DBScanRowError, err)
+			return nil, dbError(ctx, err)
+		}
+
+		list = append(list, entity)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Error(consts.DBRowsError, err)
+		return nil, dbError(ctx, err)
+	}
+
+	resp.TotalElements = totalCount
+	resp.Data = list
+
+	log.Debug("search "+s.table.Name+" response : ", resp)
+	return &resp, nil
+}
+
+// Delete deletes the record and returns it as it was before the delete,
+// a missing record is a not found error and one whose version ctx does not
+// expect a precondition failure. The record of a table with
+// DeletedAt is only marked deleted, after checking that nothing references
+// it, and is returned marked. The delete is audited in the same transaction
+func (s *crudRepository[T]) Delete(ctx context.Context, id int) (*T, error) {
+	var deleted *T
+
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		deleted, err = s.getTx(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.table.checkVersion(ctx, deleted)
+		if err != nil {
+			return err
+		}
+
+		if s.table.DeletedAt != nil {
+			before := *deleted
+			err = s.softDelete(ctx, tx, deleted)
+			if err != nil {
+				return err
+			}
+			return s.audit(ctx, tx, actor(ctx), models.AuditDelete, &before, deleted)
+		}
+
+		stmt, err := tx.PrepareContext(ctx, "DELETE FROM "+s.table.Name+" WHERE id = ?;")
+		if err != nil {
+			log.Error(consts.QueryPrepareError, err)
+			return dbError(ctx, err)
+		}
+		defer closeStmt(stmt)
+
+		result, err := stmt.ExecContext(ctx, id)
+		if err != nil {
+			log.Error(consts.DBResultsError, err)
+			return dbError(ctx, err)
+		}
+
+		err = s.checkAffected(ctx, result)
+		if err != nil {
+			return err
+		}
+		return s.audit(ctx, tx, actor(ctx), models.AuditDelete, deleted, nil)
+	})
+	if err != nil {
+		return new(T), err
+	}
+
+	log.Debug(s.table.Resource.Name+" id : ", id)
+	return deleted, nil
+}
+
+// softDelete marks the record read by Delete deleted now. The record is
+// locked, so nothing can reference it between the check and the update
+func (s *crudRepository[T]) softDelete(ctx context.Context, tx *sql.Tx, entity *T) error {
+	id := *s.table.ID(entity)
+	for _, ref := range s.table.ReferencedBy {
+		var referenced int
+		err := tx.QueryRowContext(ctx, "SELECT 1 FROM "+ref.Table+" WHERE "+ref.Column+" = ? LIMIT 1;",
+			id).Scan(&referenced)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			log.Error(consts.DBResultsError, err)
+			return dbError(ctx, err)
+		}
+		return apperrors.Conflict(consts.ForeignKeyError, nil)
+	}
+
+	now := dbNow()
+	result, err := tx.ExecContext(ctx, "UPDATE "+s.table.Name+" SET deleted_at = ?"+s.table.nextVersion()+
+		" WHERE id = ?;", now, id)
+	if err != nil {
+		log.Error(consts.DBResultsError, err)
+		return dbError(ctx, err)
+	}
+
+	err = s.checkAffected(ctx, result)
+	if err != nil {
+		return err
+	}
+	*s.table.DeletedAt(entity) = &now
+	if s.table.Version != nil {
+		*s.table.Version(entity)++
+	}
+	return nil
+}
+
+// Restore undeletes a soft deleted record and returns it, a missing or live
+// record is a not found error
+func (s *crudRepository[T]) Restore(ctx context.Context, id int) (*T, error) {
+	var restored *T
+
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		before, err := s.lockTx(ctx, tx, "id = ? AND deleted_at IS NOT NULL", id)
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, "UPDATE "+s.table.Name+
+			" SET deleted_at = NULL"+s.table.nextVersion()+" WHERE id = ? AND deleted_at IS NOT NULL;", id)
+		if err != nil {
+			log.Error(consts.DBResultsError, err)
+			return dbError(ctx, err)
+		}
+
+		err = s.checkAffected(ctx, result)
+		if err != nil {
+			return err
+		}
+
+		restored, err = s.getTx(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		return s.audit(ctx, tx, actor(ctx), models.AuditRestore, before, restored)
+	})
+	if err != nil {
+		return new(T), err
+	}
+
+	log.Debug(s.table.Resource.Name+" : ", *restored)
+	return restored, nil
+}
+
+// Purge removes for good the records soft deleted before the time. Each
+// record is removed and audited in its own transaction, one that is still
+// referenced is a foreign key conflict and is kept, and one restored or
+// deleted again since it was listed is kept when it is not expired anymore
+func (s *crudRepository[T]) Purge(ctx context.Context, before time.Time) (int, error) {
+	list, err := s.query(ctx, "SELECT "+s.table.selectColumns()+" FROM "+s.table.Name+
+		" WHERE deleted_at < ? ORDER BY id;", before.UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for i := range list {
+		id := *s.table.ID(&list[i])
+		var affected int64
+		err := s.withTx(ctx, func(tx *sql.Tx) error {
+			result, err := tx.ExecContext(ctx, "DELETE FROM "+s.table.Name+
+				" WHERE id = ? AND deleted_at < ?;", id, before.UTC())
+			if err != nil {
+				return dbError(ctx, err)
+			}
+
+			affected, err = result.RowsAffected()
+			if err != nil {
+				log.Error(consts.DBRowsAffectedError, err)
+				return dbError(ctx, err)
+			}
+			if affected == 0 {
+				return nil
+			}
+			return s.audit(ctx, tx, systemActor, models.AuditPurge, &list[i], nil)
+		})
+		if apperrors.CodeOf(err) == apperrors.CodeConflict {
+			log.Info(fmt.Sprintf("%s %d is still referenced, it is not purged", s.table.Resource.Name, id))
+			continue
+		}
+		if err != nil {
+			log.Error(consts.DBResultsError, err)
+			return purged, err
+		}
+		purged += int(affected)
+	}
+
+	log.Debug(fmt.Sprintf("purged %d %s", purged, s.table.Name))
+	return purged, nil
+}
+
+// dbNow is the time a record is soft deleted or audited at. It is stored in
+// UTC to the second, the precision of a DATETIME, so the times are compared
+// the same way by every database
+func dbNow() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+// getTx reads a record inside a transaction, locking it until the
+// transaction ends when the database supports row locks. A soft deleted
+// record is missing
+func (s *crudRepository[T]) getTx(ctx context.Context, tx *sql.Tx, id int) (*T, error) {
+	return s.lockTx(ctx, tx, s.table.live("id = ?"), id)
+}
+
+// lockTx reads the record with the id matching condition like getTx
+func (s *crudRepository[T]) lockTx(ctx context.Context, tx *sql.Tx, condition string, id int) (*T, error) {
+	var entity T
+
+	stmt, err := tx.PrepareContext(ctx, "SELECT "+s.table.selectColumns()+" FROM "+s.table.Name+
+		" WHERE "+condition+s.dialect.Lock+";")
+	if err != nil {
+		log.Error(consts.QueryPrepareError, err)
+		return nil, dbError(ctx, err)
+	}
+	defer closeStmt(stmt)
+
+	err = stmt.QueryRowContext(ctx, id).Scan(s.table.scanFields(&entity)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NotFound(s.table.Resource.NotFound)
+		}
+		log.Error(consts.DBResultsError, err)
+		return nil, dbError(ctx, err)
+	}
+	return &entity, nil
+}
+
+// check runs the Check of the table, if any, on the record about to be stored
+func (s *crudRepository[T]) check(ctx context.Context, tx *sql.Tx, entity *T) error {
+	if s.table.Check == nil {
+		return nil
+	}
+	return s.table.Check(ctx, tx, s.dialect, entity)
+}
+
+// checkAffected returns a not found error when the statement matched no rows.
+// The connection is opened with clientFoundRows so an update that does not
+// change any value still counts the matched row
+func (s *crudRepository[T]) checkAffected(ctx context.Context, result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		log.Error(consts.DBRowsAffectedError, err)
+		return dbError(ctx, err)
+	}
+	if affected == 0 {
+		return apperrors.NotFound(s.table.Resource.NotFound)
+	}
+	return nil
+}
+
+// audit writes the event of a change of a record in the transaction making
+// it, before is nil for a create and after for a delete for good
+func (s *crudRepository[T]) audit(ctx context.Context, tx *sql.Tx, actor string, action models.AuditAction,
+	before *T, after *T) error {
+
+	event, err := newAuditEvent(s.table, actor, action, before, after)
+	if err != nil {
+		return err
+	}
+	return insertAuditEvent(ctx, tx, event)
+}
+
+// withTx runs fn in a transaction, committing when fn succeeds
+func (s *crudRepository[T]) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error(consts.DBTransactionError, err)
+		return dbError(ctx, err)
+	}
+
+	err = fn(tx)
+	if err != nil {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			log.Error(consts.DBTransactionError, rollbackErr)
+		}
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		log.Error(consts.DBTransactionError, err)
+		return dbError(ctx, err)
+	}
+	return nil
+}
+
+func closeStmt(stmt *sql.Stmt) {
+	err := stmt.Close()
+	if err != nil {
+		log.Error(consts.DBStatementCloseError, err)
+	}
+}
+
+func closeRows(rows *sql.Rows) {
+	err := rows.Close()
+	if err != nil {
+		log.Error(consts.DBRowCloseError, err)
+	}
+}
@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+)
+
+var (
+	student1 = models.Student{
+		ID:        1,
+		FirstName: "Charles",
+		LastName:  "Leclerc",
+		Year:      3,
+		Status:    "active",
+	}
+	studentColumns = []string{"id", "registration_number", "firstname", "lastname", "year", "email", "phone",
+		"date_of_birth", "enrolment_date", "status", "deleted_at", "version"}
+)
+
+func newMockRepository(t *testing.T) (*crudRepository[models.Student], sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating the mock database %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	return NewRepository(db, MySQL, studentTable), mock
+}
+
+func TestCrudRepository_Update_HappyPath(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(regexp.QuoteMeta("SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version FROM students WHERE id = ? AND deleted_at IS NULL FOR UPDATE;")).
+		ExpectQuery().WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(studentColumns).AddRow(1, nil, "Charles", "Leclerc", 2, nil, nil, nil, nil, "active", nil, 1))
+	mock.ExpectPrepare(regexp.QuoteMeta("UPDATE students SET firstname = ?, lastname = ?, year = ?, email = ?, phone = ?, "+
+		"date_of_birth = ?, enrolment_date = ?, status = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL;")).
+		ExpectExec().WithArgs("Charles", "Leclerc", 3, nil, nil, nil, nil, "active", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(regexp.QuoteMeta("SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version FROM students WHERE id = ? AND deleted_at IS NULL FOR UPDATE;")).
+		ExpectQuery().WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(studentColumns).AddRow(1, nil, "Charles", "Leclerc", 3, nil, nil, nil, nil, "active", nil, 2))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO audit_events (actor, entity, entity_id, action, created_at, changes) "+
+		"VALUES (?, ?, ?, ?, ?, ?);")).
+		WithArgs("anonymous", "student", 1, models.AuditUpdate, sqlmock.AnyArg(), `{"year":{"before":2,"after":3}}`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	st := student1
+	expected := student1
+	expected.Version = 2
+	actual, err := repo.Update(context.Background(), &st)
+	if err != nil || *actual != expected {
+		t.Errorf("Expected %v, but got %v, %v", expected, actual, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrudRepository_Update_NotFound(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(regexp.QuoteMeta("SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version FROM students WHERE id = ? AND deleted_at IS NULL FOR UPDATE;")).
+		ExpectQuery().WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(studentColumns))
+	mock.ExpectRollback()
+
+	st := student1
+	_, err := repo.Update(context.Background(), &st)
+	if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+		t.Errorf("Expected a not found error, but got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrudRepository_Update_VersionMismatch(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(regexp.QuoteMeta("SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version FROM students WHERE id = ? AND deleted_at IS NULL FOR UPDATE;")).
+		ExpectQuery().WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(studentColumns).AddRow(1, nil, "Charles", "Leclerc", 2, nil, nil, nil, nil, "active", nil, 2))
+	mock.ExpectRollback()
+
+	st := student1
+	_, err := repo.Update(IfVersion(context.Background(), 1), &st)
+	if apperrors.CodeOf(err) != apperrors.CodePrecondition {
+		t.Errorf("Expected a precondition failure, but got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrudRepository_Update_AuditError(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(regexp.QuoteMeta("SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version FROM students WHERE id = ? AND deleted_at IS NULL FOR UPDATE;")).
+		ExpectQuery().WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(studentColumns).AddRow(1, nil, "Charles", "Leclerc", 2, nil, nil, nil, nil, "active", nil, 1))
+	mock.ExpectPrepare(regexp.QuoteMeta("UPDATE students SET")).
+		ExpectExec().WithArgs("Charles", "Leclerc", 3, nil, nil, nil, nil, "active", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(regexp.QuoteMeta("SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version FROM students WHERE id = ? AND deleted_at IS NULL FOR UPDATE;")).
+		ExpectQuery().WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(studentColumns).AddRow(1, nil, "Charles", "Leclerc", 3, nil, nil, nil, nil, "active", nil, 2))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO audit_events")).
+		WillReturnError(errors.New("disk full"))
+	mock.ExpectRollback()
+
+	st := student1
+	_, err := repo.Update(context.Background(), &st)
+	if apperrors.CodeOf(err) != apperrors.CodeInternal {
+		t.Errorf("Expected the update to fail with its audit event, but got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrudRepository_Patch_HappyPath(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(regexp.QuoteMeta("SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version FROM students WHERE id = ? AND deleted_at IS NULL FOR UPDATE;")).
+		ExpectQuery().WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(studentColumns).AddRow(1, nil, "Charles", "Leclerc", 2, nil, nil, nil, nil, "active", nil, 1))
+	mock.ExpectPrepare(regexp.QuoteMeta("UPDATE students SET year = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL;")).
+		ExpectExec().WithArgs(3, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(regexp.QuoteMeta("SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version FROM students WHERE id = ? AND deleted_at IS NULL FOR UPDATE;")).
+		ExpectQuery().WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(studentColumns).AddRow(1, nil, "Charles", "Leclerc", 3, nil, nil, nil, nil, "active", nil, 2))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO audit_events")).
+		WithArgs("anonymous", "student", 1, models.AuditUpdate, sqlmock.AnyArg(), `{"year":{"before":2,"after":3}}`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	expected := student1
+	expected.Version = 2
+	actual, err := repo.Patch(context.Background(), 1, func(student *models.Student) error {
+		student.Year = 3
+		return nil
+	})
+	if err != nil || *actual != expected {
+		t.Errorf("Expected %v, but got %v, %v", expected, actual, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrudRepository_Delete_HappyPath(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(regexp.QuoteMeta("SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version FROM students WHERE id = ? AND deleted_at IS NULL FOR UPDATE;")).
+		ExpectQuery().WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(studentColumns).AddRow(1, nil, "Charles", "Leclerc", 3, nil, nil, nil, nil, "active", nil, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT 1 FROM enrolments WHERE student_id = ? LIMIT 1;")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT 1 FROM grades WHERE student_id = ? LIMIT 1;")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE students SET deleted_at = ?, version = version + 1 WHERE id = ?;")).
+		WithArgs(sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO audit_events")).
+		WithArgs("anonymous", "student", 1, models.AuditDelete, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	actual, err := repo.Delete(context.Background(), 1)
+	if err != nil || actual.DeletedAt == nil || actual.Version != 2 {
+		t.Errorf("Expected %v to be soft deleted, but got %v, %v", student1, actual, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrudRepository_Delete_Referenced(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(regexp.QuoteMeta("SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version FROM students WHERE id = ? AND deleted_at IS NULL FOR UPDATE;")).
+		ExpectQuery().WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(studentColumns).AddRow(1, nil, "Charles", "Leclerc", 3, nil, nil, nil, nil, "active", nil, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT 1 FROM enrolments WHERE student_id = ? LIMIT 1;")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectRollback()
+
+	_, err := repo.Delete(context.Background(), 1)
+	if apperrors.CodeOf(err) != apperrors.CodeConflict {
+		t.Errorf("Expected a conflict, but got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrudRepository_Delete_NotFound(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare(regexp.QuoteMeta("SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version FROM students WHERE id = ? AND deleted_at IS NULL FOR UPDATE;")).
+		ExpectQuery().WithArgs(7).
+		WillReturnRows(sqlmock.NewRows(studentColumns))
+	mock.ExpectRollback()
+
+	_, err := repo.Delete(context.Background(), 7)
+	if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+		t.Errorf("Expected a not found error, but got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrudRepository_Purge_DeletedAgain(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	before := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	deletedAt := before.Add(-time.Hour)
+
+	// the student is restored and deleted again between the listing and the delete
+	mock.ExpectPrepare(regexp.QuoteMeta("SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version FROM students WHERE deleted_at < ? ORDER BY id;")).
+		ExpectQuery().WithArgs(before).
+		WillReturnRows(sqlmock.NewRows(studentColumns).AddRow(1, nil, "Charles", "Leclerc", 3, nil, nil, nil, nil, "active", deletedAt, 2))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM students WHERE id = ? AND deleted_at < ?;")).
+		WithArgs(1, before).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	purged, err := repo.Purge(context.Background(), before)
+	if err != nil || purged != 0 {
+		t.Errorf("Expected the student to be kept, but purged %d, %v", purged, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrudRepository_Get_NotFound(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectPrepare(regexp.QuoteMeta("SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version FROM students WHERE id = ? AND deleted_at IS NULL;")).
+		ExpectQuery().WithArgs(7).
+		WillReturnRows(sqlmock.NewRows(studentColumns))
+
+	_, err := repo.Get(context.Background(), 7)
+	if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+		t.Errorf("Expected a not found error, but got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrudRepository_Get_Timeout(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	mock.ExpectPrepare(regexp.QuoteMeta("SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version FROM students WHERE id = ? AND deleted_at IS NULL;")).
+		ExpectQuery().WithArgs(1).
+		WillDelayFor(time.Second).
+		WillReturnRows(sqlmock.NewRows(studentColumns).AddRow(1, nil, "Charles", "Leclerc", 3, nil, nil, nil, nil, "active", nil, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := repo.Get(ctx, 1)
+	if apperrors.CodeOf(err) != apperrors.CodeTimeout {
+		t.Errorf("Expected a timeout error, but got %v", err)
+	}
+}
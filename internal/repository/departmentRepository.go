@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+type DepartmentRepository interface {
+	Repository[models.Department]
+	// GetByHead returns the departments the lecturer heads
+	GetByHead(ctx context.Context, lecturerID int) ([]models.Department, error)
+	// GetLecturers returns the lecturers of the department
+	GetLecturers(ctx context.Context, departmentID int) ([]models.Lecturer, error)
+	// GetStaff returns the staff of the department
+	GetStaff(ctx context.Context, departmentID int) ([]models.Staff, error)
+}
+
+var departmentTable = Table[models.Department]{
+	Name:          "departments",
+	Resource:      models.DepartmentResource,
+	Columns:       []string{"name", "head_id"},
+	SearchColumns: []string{"name"},
+	SortColumns: map[string]string{
+		"id":     "id",
+		"name":   "name",
+		"headid": "head_id",
+	},
+	FilterFields: map[string]FilterField{
+		"id":     {Column: "id", Kind: NumberField},
+		"name":   {Column: "name", Kind: TextField},
+		"headid": {Column: "head_id", Kind: NumberField},
+	},
+	Unique: []string{"name"},
+	ID: func(department *models.Department) *int {
+		return &department.ID
+	},
+	Fields: func(department *models.Department) []interface{} {
+		return []interface{}{&department.Name, &department.HeadID}
+	},
+	Check: checkDepartmentHead,
+}
+
+type departmentRepository struct {
+	listRepository[models.Department]
+	lecturers listRepository[models.Lecturer]
+	staff     listRepository[models.Staff]
+}
+
+func NewDepartmentRepository(db *sql.DB, dialect Dialect) DepartmentRepository {
+	return departmentRepository{
+		listRepository: NewRepository(db, dialect, departmentTable),
+		lecturers:      NewRepository(db, dialect, lecturerTable),
+		staff:          NewRepository(db, dialect, staffTable),
+	}
+}
+
+// NewMemoryDepartmentRepository returns a memory repository whose
+// departments are referenced by the given lecturers and staff and headed by
+// one of the lecturers
+func NewMemoryDepartmentRepository(lecturers *memoryRepository[models.Lecturer],
+	staff *memoryRepository[models.Staff]) DepartmentRepository {
+	return departmentRepository{
+		listRepository: newMemoryDepartments(lecturers, staff),
+		lecturers:      lecturers,
+		staff:          staff,
+	}
+}
+
+// newMemoryDepartments returns the memory repository of the departments,
+// like newMemoryCourses
+func newMemoryDepartments(lecturers *memoryRepository[models.Lecturer],
+	staff *memoryRepository[models.Staff]) *memoryRepository[models.Department] {
+	departments := NewMemoryRepository(departmentTable)
+	reference(lecturers, "department_id", departments)
+	reference(staff, "department_id", departments)
+	reference(departments, "head_id", lecturers)
+
+	departments.checks = append(departments.checks, func(ctx context.Context, department *models.Department) error {
+		if department.HeadID == nil {
+			return nil
+		}
+		head, err := lecturers.Get(ctx, *department.HeadID)
+		if err != nil {
+			return err
+		}
+		return headIn(department, head.DepartmentID)
+	})
+	lecturers.checks = append(lecturers.checks, func(ctx context.Context, lecturer *models.Lecturer) error {
+		headed, err := departments.listBy(ctx, "head_id", lecturer.ID)
+		if err != nil {
+			return err
+		}
+		for _, department := range headed {
+			err := headedIn(lecturer, department.ID)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return departments
+}
+
+func (s departmentRepository) GetByHead(ctx context.Context, lecturerID int) ([]models.Department, error) {
+	return s.listBy(ctx, "head_id", lecturerID)
+}
+
+func (s departmentRepository) GetLecturers(ctx context.Context, departmentID int) ([]models.Lecturer, error) {
+	return s.lecturers.listBy(ctx, "department_id", departmentID)
+}
+
+func (s departmentRepository) GetStaff(ctx context.Context, departmentID int) ([]models.Staff, error) {
+	return s.staff.listBy(ctx, "department_id", departmentID)
+}
+
+// checkDepartmentHead is the Check of the departments, the head must be a
+// lecturer of the department. The head is locked so that they are not moved
+// to another department until the department is stored
+func checkDepartmentHead(ctx context.Context, tx *sql.Tx, dialect Dialect, department *models.Department) error {
+	if department.HeadID == nil {
+		return nil
+	}
+
+	var departmentID *int
+	err := tx.QueryRowContext(ctx, "SELECT department_id FROM lecturers WHERE "+lecturerTable.live("id = ?")+
+		dialect.Lock+";", *department.HeadID).Scan(&departmentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return apperrors.NotFound(consts.LecturerNotFound)
+		}
+		log.Error(consts.DBResultsError, err)
+		return dbError(ctx, err)
+	}
+	return headIn(department, departmentID)
+}
+
+// checkHeadedDepartments is the Check of the lecturers, a lecturer must stay
+// in the departments they head. The departments are locked so that they do
+// not appoint the lecturer until the lecturer is stored
+func checkHeadedDepartments(ctx context.Context, tx *sql.Tx, dialect Dialect, lecturer *models.Lecturer) error {
+	rows, err := tx.QueryContext(ctx, "SELECT id FROM departments WHERE head_id = ?"+dialect.Lock+";",
+		lecturer.ID)
+	if err != nil {
+		log.Error(consts.DBResultsError, err)
+		return dbError(ctx, err)
+	}
+	defer closeRows(rows)
+
+	for rows.Next() {
+		var departmentID int
+		err := rows.Scan(&departmentID)
+		if err != nil {
+			log.Error(consts.DBScanRowError, err)
+			return dbError(ctx, err)
+		}
+		err = headedIn(lecturer, departmentID)
+		if err != nil {
+			return err
+		}
+	}
+	err = rows.Err()
+	if err != nil {
+		log.Error(consts.DBRowsError, err)
+		return dbError(ctx, err)
+	}
+	return nil
+}
+
+// headIn returns an unprocessable error when the head of the department, in
+// the department with headDepartmentID, is not one of its lecturers
+func headIn(department *models.Department, headDepartmentID *int) error {
+	if headDepartmentID == nil || *headDepartmentID != department.ID {
+		return apperrors.Unprocessable(consts.HeadNotInDepartmentError)
+	}
+	return nil
+}
+
+// headedIn returns a conflict when the lecturer heading the department is
+// not in it
+func headedIn(lecturer *models.Lecturer, departmentID int) error {
+	if lecturer.DepartmentID == nil || *lecturer.DepartmentID != departmentID {
+		return apperrors.Conflict(consts.DepartmentHeadMoveError, nil)
+	}
+	return nil
+}
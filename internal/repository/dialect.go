@@ -0,0 +1,31 @@
+package repository
+
+// Dialect holds the SQL that differs between the supported databases
+type Dialect struct {
+	// Lock is appended to the SELECT that reads a record inside a
+	// transaction so it stays locked until the transaction ends
+	Lock string
+	// LikeEscape is appended to every LIKE comparison so the escaped
+	// wildcards of the search string are matched literally
+	LikeEscape string
+	// NextSequence adds one to the named row of number_sequences, creating
+	// it at 1, and locks the row until the transaction ends
+	NextSequence string
+}
+
+var (
+	// MySQL locks the rows it reads for update, a backslash is already the
+	// default LIKE escape character
+	MySQL = Dialect{
+		Lock: " FOR UPDATE",
+		NextSequence: "INSERT INTO number_sequences (name, value) VALUES (?, 1) " +
+			"ON DUPLICATE KEY UPDATE value = value + 1;",
+	}
+	// SQLite has no row locks, it serializes the writers instead, and has no
+	// default LIKE escape character
+	SQLite = Dialect{
+		LikeEscape: ` ESCAPE '\'`,
+		NextSequence: "INSERT INTO number_sequences (name, value) VALUES (?, 1) " +
+			"ON CONFLICT (name) DO UPDATE SET value = value + 1;",
+	}
+)
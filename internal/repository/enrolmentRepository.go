@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+// EnrolmentCheck decides whether the student may enrol in the course. It is
+// given the number of students enrolled in the course and whether the
+// student is one of them, a non nil error stops the enrolment
+type EnrolmentCheck func(student *models.Student, course *models.Course, enrolled int, alreadyEnrolled bool) error
+
+// EnrolmentRepository stores the courses the students are enrolled in
+type EnrolmentRepository interface {
+	GetByStudent(ctx context.Context, studentID int) ([]models.Enrolment, error)
+	// Enrol adds the enrolment when check allows it. A missing student or
+	// course is a not found error. The check and the insert run in one
+	// transaction with the course locked, so concurrent enrolments can not
+	// overfill a course
+	Enrol(ctx context.Context, enrolment *models.Enrolment, check EnrolmentCheck) (*models.Enrolment, error)
+	// Withdraw removes the enrolment and returns it, a missing enrolment is a
+	// not found error
+	Withdraw(ctx context.Context, studentID int, courseID int) (*models.Enrolment, error)
+}
+
+const enrolmentColumns = "student_id, course_id, enrolled_at"
+
+type enrolmentRepository struct {
+	db       *sql.DB
+	dialect  Dialect
+	students *crudRepository[models.Student]
+	courses  *crudRepository[models.Course]
+}
+
+func NewEnrolmentRepository(db *sql.DB, dialect Dialect) *enrolmentRepository {
+	return &enrolmentRepository{
+		db:       db,
+		dialect:  dialect,
+		students: NewRepository(db, dialect, studentTable),
+		courses:  NewRepository(db, dialect, courseTable),
+	}
+}
+
+func (s *enrolmentRepository) GetByStudent(ctx context.Context, studentID int) ([]models.Enrolment, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+enrolmentColumns+" FROM enrolments WHERE student_id = ? "+
+		"ORDER BY course_id;", studentID)
+	if err != nil {
+		log.Error(consts.DBResultsError, err)
+		return nil, dbError(ctx, err)
+	}
+	defer closeRows(rows)
+
+	var list []models.Enrolment
+	for rows.Next() {
+		var enrolment models.Enrolment
+		err := rows.Scan(&enrolment.StudentID, &enrolment.CourseID, &enrolment.EnrolledAt)
+		if err != nil {
+			log.Error(consts.DBScanRowError, err)
+			return nil, dbError(ctx, err)
+		}
+		list = append(list, enrolment)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Error(consts.DBRowsError, err)
+		return nil, dbError(ctx, err)
+	}
+
+	log.Debug("enrolments of student ", studentID, " : ", list)
+	return list, nil
+}
+
+func (s *enrolmentRepository) Enrol(ctx context.Context, enrolment *models.Enrolment,
+	check EnrolmentCheck) (*models.Enrolment, error) {
+
+	err := s.courses.withTx(ctx, func(tx *sql.Tx) error {
+		// the course is locked first, every enrolment in it waits here
+		course, err := s.courses.getTx(ctx, tx, enrolment.CourseID)
+		if err != nil {
+			return err
+		}
+		student, err := s.students.getTx(ctx, tx, enrolment.StudentID)
+		if err != nil {
+			return err
+		}
+
+		var enrolled, already int
+		err = tx.QueryRowContext(ctx, "SELECT COUNT(*), COALESCE(SUM(CASE WHEN student_id = ? THEN 1 ELSE 0 END), 0) "+
+			"FROM enrolments WHERE course_id = ?;", enrolment.StudentID, enrolment.CourseID).Scan(&enrolled, &already)
+		if err != nil {
+			log.Error(consts.DBResultsError, err)
+			return dbError(ctx, err)
+		}
+
+		err = check(student, course, enrolled, already > 0)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO enrolments ("+enrolmentColumns+") VALUES (?, ?, ?);",
+			enrolment.StudentID, enrolment.CourseID, enrolment.EnrolledAt)
+		if err != nil {
+			log.Error(consts.DBResultsError, err)
+			return dbError(ctx, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("enrolment : ", *enrolment)
+	return enrolment, nil
+}
+
+func (s *enrolmentRepository) Withdraw(ctx context.Context, studentID int, courseID int) (*models.Enrolment, error) {
+	var enrolment models.Enrolment
+
+	err := s.courses.withTx(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, "SELECT "+enrolmentColumns+" FROM enrolments WHERE student_id = ? "+
+			"AND course_id = ?"+s.dialect.Lock+";", studentID, courseID).
+			Scan(&enrolment.StudentID, &enrolment.CourseID, &enrolment.EnrolledAt)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return apperrors.NotFound(consts.EnrolmentNotFound)
+			}
+			log.Error(consts.DBResultsError, err)
+			return dbError(ctx, err)
+		}
+
+		// the grade keeps the enrolment, Save locks it before grading
+		var graded int
+		err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM grades WHERE student_id = ? AND course_id = ?;",
+			studentID, courseID).Scan(&graded)
+		if err != nil {
+			log.Error(consts.DBResultsError, err)
+			return dbError(ctx, err)
+		}
+		if graded > 0 {
+			return apperrors.Conflict(consts.GradedWithdrawError, nil)
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM enrolments WHERE student_id = ? AND course_id = ?;",
+			studentID, courseID)
+		if err != nil {
+			log.Error(consts.DBResultsError, err)
+			return dbError(ctx, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("withdrawn enrolment : ", enrolment)
+	return &enrolment, nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// MySQL server error numbers that are caused by the client's data
+const (
+	mysqlDuplicateEntry   = 1062
+	mysqlRowIsReferenced  = 1451
+	mysqlNoReferencedRow  = 1452
+	mysqlRowIsReferenced2 = 1217
+	mysqlNoReferencedRow2 = 1216
+)
+
+// dbError converts a database error into a typed error. Constraint
+// violations are conflicts, an expired request deadline is a timeout and
+// everything else is internal. The context is checked as well because not
+// every driver returns the context error when it cancels a query
+func dbError(ctx context.Context, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return apperrors.Timeout(err)
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case mysqlDuplicateEntry:
+			return apperrors.Conflict(consts.DuplicateEntryError, err)
+		case mysqlRowIsReferenced, mysqlNoReferencedRow, mysqlRowIsReferenced2, mysqlNoReferencedRow2:
+			return apperrors.Conflict(consts.ForeignKeyError, err)
+		}
+	}
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+			return apperrors.Conflict(consts.DuplicateEntryError, err)
+		case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+			return apperrors.Conflict(consts.ForeignKeyError, err)
+		}
+	}
+	return apperrors.Internal(err)
+}
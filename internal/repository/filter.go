@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/filter"
+)
+
+// Kind is the kind of value held by a filterable field, which decides the
+// operators that can compare it
+type Kind int
+
+const (
+	// NumberField is compared with every operator but prefix and contains
+	NumberField Kind = iota
+	// TextField is compared case insensitively with eq, ne, in, prefix and
+	// contains
+	TextField
+	// DateField holds a models.Date and is compared like a number
+	DateField
+)
+
+// FilterField is a field the filter expressions can compare, the column
+// holding it and the kind of its value
+type FilterField struct {
+	Column string
+	Kind   Kind
+}
+
+var kindOps = map[Kind]map[filter.Op]bool{
+	NumberField: {filter.Eq: true, filter.Ne: true, filter.Lt: true, filter.Lte: true, filter.Gt: true,
+		filter.Gte: true, filter.In: true},
+	TextField: {filter.Eq: true, filter.Ne: true, filter.In: true, filter.Prefix: true, filter.Contains: true},
+	DateField: {filter.Eq: true, filter.Ne: true, filter.Lt: true, filter.Lte: true, filter.Gt: true,
+		filter.Gte: true, filter.In: true},
+}
+
+var comparisons = map[filter.Op]string{
+	filter.Eq:  "=",
+	filter.Ne:  "<>",
+	filter.Lt:  "<",
+	filter.Lte: "<=",
+	filter.Gt:  ">",
+	filter.Gte: ">=",
+}
+
+// condition is a condition of a filter expression checked against the
+// filterable fields, with its values converted to the kind of the field
+type condition struct {
+	column string
+	kind   Kind
+	op     filter.Op
+	values []interface{}
+}
+
+// conditions checks the filter expression against the filterable fields,
+// keyed by the lower case JSON name, and converts its values
+func conditions(expr filter.Expr, fields map[string]FilterField) ([]condition, error) {
+	var list []condition
+	for _, c := range expr {
+		field, ok := fields[strings.ToLower(c.Field)]
+		if !ok {
+			return nil, invalidSearch(fmt.Sprintf("field %q can not be filtered", c.Field))
+		}
+		if !kindOps[field.Kind][c.Op] {
+			return nil, invalidSearch(fmt.Sprintf("field %q can not be filtered with %s", c.Field, c.Op))
+		}
+
+		values := make([]interface{}, len(c.Values))
+		for i, v := range c.Values {
+			value, err := field.Kind.parse(v)
+			if err != nil {
+				return nil, invalidSearch(fmt.Sprintf("field %q can not be compared with %q", c.Field, v))
+			}
+			values[i] = value
+		}
+		list = append(list, condition{column: field.Column, kind: field.Kind, op: c.Op, values: values})
+	}
+	return list, nil
+}
+
+func (k Kind) parse(value string) (interface{}, error) {
+	switch k {
+	case NumberField:
+		return strconv.Atoi(value)
+	case DateField:
+		return models.ParseDate(value)
+	}
+	return value, nil
+}
+
+// sql returns the condition as parameterized SQL, the column comes from the
+// filterable fields and every value is bound. A text is lowered on both
+// sides, so it is compared ignoring case whatever the collation
+func (c condition) sql(likeEscape string) (string, []interface{}) {
+	column := c.column
+	placeholder := "?"
+	if c.kind == TextField {
+		column = "LOWER(" + column + ")"
+		placeholder = "LOWER(?)"
+	}
+
+	switch c.op {
+	case filter.Prefix:
+		return column + " LIKE " + placeholder + likeEscape, []interface{}{escapeLike(c.values[0].(string)) + "%"}
+	case filter.Contains:
+		return column + " LIKE " + placeholder + likeEscape,
+			[]interface{}{"%" + escapeLike(c.values[0].(string)) + "%"}
+	case filter.In:
+		placeholders := strings.TrimSuffix(strings.Repeat(placeholder+", ", len(c.values)), ", ")
+		return column + " IN (" + placeholders + ")", c.values
+	}
+	return column + " " + comparisons[c.op] + " " + placeholder, c.values
+}
+
+// match tells whether the column value, as returned by the memory
+// repository, holds the condition. A null never does, like in SQL
+func (c condition) match(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	if c.op == filter.In {
+		for _, v := range c.values {
+			if compare(value, v) == 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	if c.kind == TextField {
+		text, pattern := strings.ToLower(value.(string)), strings.ToLower(c.values[0].(string))
+		switch c.op {
+		case filter.Prefix:
+			return strings.HasPrefix(text, pattern)
+		case filter.Contains:
+			return strings.Contains(text, pattern)
+		}
+	}
+
+	n := compare(value, c.values[0])
+	switch c.op {
+	case filter.Eq:
+		return n == 0
+	case filter.Ne:
+		return n != 0
+	case filter.Lt:
+		return n < 0
+	case filter.Lte:
+		return n <= 0
+	case filter.Gt:
+		return n > 0
+	}
+	return n >= 0
+}
+
+// filter returns the conditions of the filter expression as parameterized
+// SQL, joined with AND, and their arguments
+func (t Table[T]) filter(expr filter.Expr, dialect Dialect) (string, []interface{}, error) {
+	list, err := conditions(expr, t.FilterFields)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var sqlConditions []string
+	var args []interface{}
+	for _, c := range list {
+		condition, values := c.sql(dialect.LikeEscape)
+		sqlConditions = append(sqlConditions, condition)
+		args = append(args, values...)
+	}
+	return strings.Join(sqlConditions, " AND "), args, nil
+}
+
+// compare compares two column values of the same kind like less
+func compare(a, b interface{}) int {
+	if less(a, b) {
+		return -1
+	}
+	if less(b, a) {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/filter"
+)
+
+// filtered returns the parsed filter expression
+func filtered(t *testing.T, s string) filter.Expr {
+	expr, err := filter.Parse(s)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	return expr
+}
+
+func TestTable_Filter(t *testing.T) {
+	testCases := []struct {
+		name              string
+		filter            string
+		dialect           Dialect
+		expectedCondition string
+		expectedArgs      []interface{}
+	}{
+		{name: "No Filter", dialect: MySQL},
+		{name: "Number And Prefix", filter: "year:eq:3,lastname:prefix:L", dialect: MySQL,
+			expectedCondition: "year = ? AND LOWER(lastname) LIKE LOWER(?)",
+			expectedArgs:      []interface{}{3, "L%"}},
+		{name: "SQLite Escape", filter: "lastname:contains:50%", dialect: SQLite,
+			expectedCondition: `LOWER(lastname) LIKE LOWER(?) ESCAPE '\'`,
+			expectedArgs:      []interface{}{`%50\%%`}},
+		{name: "Text Is Case Insensitive", filter: "status:ne:Active,email:in:a@b.c|d@e.f", dialect: MySQL,
+			expectedCondition: "LOWER(status) <> LOWER(?) AND LOWER(email) IN (LOWER(?), LOWER(?))",
+			expectedArgs:      []interface{}{"Active", "a@b.c", "d@e.f"}},
+		{name: "Dates And Camel Case", filter: "dateOfBirth:lt:2000-01-01,enrolmentDate:gte:2020-09-01",
+			dialect:           MySQL,
+			expectedCondition: "date_of_birth < ? AND enrolment_date >= ?",
+			expectedArgs:      []interface{}{models.NewDate(2000, 1, 1), models.NewDate(2020, 9, 1)}},
+		{name: "Value Is Bound", filter: "lastname:eq:x' OR '1'='1", dialect: MySQL,
+			expectedCondition: "LOWER(lastname) = LOWER(?)",
+			expectedArgs:      []interface{}{"x' OR '1'='1"}},
+	}
+
+	for _, test := range testCases {
+		condition, args, err := studentTable.filter(filtered(t, test.filter), test.dialect)
+		if err != nil {
+			t.Errorf("Test %s : Unexpected error %v", test.name, err)
+		}
+		if condition != test.expectedCondition || !reflect.DeepEqual(args, test.expectedArgs) {
+			t.Errorf("Test %s : Expected %s with %v, but got %s with %v", test.name, test.expectedCondition,
+				test.expectedArgs, condition, args)
+		}
+	}
+}
+
+func TestTable_Filter_ErrorPath(t *testing.T) {
+	testCases := []struct {
+		name   string
+		filter string
+	}{
+		{name: "Unknown Field", filter: "position:eq:Registrar"},
+		{name: "Field That Is Not Served", filter: "version:eq:1"},
+		{name: "Not A Number", filter: "year:eq:third"},
+		{name: "Not A Date", filter: "dateOfBirth:gt:yesterday"},
+		{name: "Range Of Text", filter: "lastname:gt:L"},
+		{name: "Prefix Of A Number", filter: "year:prefix:3"},
+		{name: "One Bad Value Of In", filter: "year:in:1|two"},
+	}
+
+	for _, test := range testCases {
+		_, _, err := studentTable.filter(filtered(t, test.filter), MySQL)
+		if apperrors.CodeOf(err) != apperrors.CodeValidation {
+			t.Errorf("Test %s : Expected a validation error, but got %v", test.name, err)
+		}
+	}
+
+	_, _, err := courseTable.filter(filtered(t, "credits:eq:3"), MySQL)
+	if apperrors.CodeOf(err) != apperrors.CodeValidation {
+		t.Errorf("Expected the courses not to be filtered, but got %v", err)
+	}
+}
+
+func TestSearchQuery_Build_Filter(t *testing.T) {
+	query, args, err := searchOf(t, lecturerTable, context.Background(),
+		filtered(t, "departmentId:eq:2,year:gte:2,lastname:prefix:L"), MySQL).
+		build("new", models.Pagination{Page: 0, PageSize: 2}, models.SortBy{})
+	expectedQuery := "SELECT id, firstname, lastname, year, department_id, email, phone, date_of_birth, status, deleted_at, version, Count(*) Over () AS TotalCount FROM lecturers WHERE (firstname LIKE ? OR lastname LIKE ?) AND department_id = ? AND year >= ? AND LOWER(lastname) LIKE LOWER(?) AND deleted_at IS NULL ORDER BY id ASC LIMIT ?,?;"
+	expectedArgs := []interface{}{"%new%", "%new%", 2, 2, "L%", 0, 2}
+	if err != nil || query != expectedQuery || !reflect.DeepEqual(args, expectedArgs) {
+		t.Errorf("Expected query %s with %v, but got %s with %v, %v", expectedQuery, expectedArgs, query, args, err)
+	}
+}
+
+func TestCondition_Match(t *testing.T) {
+	testCases := []struct {
+		name     string
+		filter   string
+		value    interface{}
+		expected bool
+	}{
+		{name: "Equal Number", filter: "year:eq:3", value: 3, expected: true},
+		{name: "Other Number", filter: "year:eq:3", value: 2, expected: false},
+		{name: "Less Than", filter: "year:lt:3", value: 2, expected: true},
+		{name: "At Most", filter: "year:lte:3", value: 3, expected: true},
+		{name: "Greater Than", filter: "year:gt:3", value: 3, expected: false},
+		{name: "At Least", filter: "year:gte:3", value: 4, expected: true},
+		{name: "In", filter: "year:in:1|3", value: 3, expected: true},
+		{name: "Not In", filter: "year:in:1|3", value: 2, expected: false},
+		{name: "Text Ignores Case", filter: "lastname:eq:leclerc", value: "Leclerc", expected: true},
+		{name: "Not Equal Text", filter: "lastname:ne:leclerc", value: "Sainz", expected: true},
+		{name: "Prefix", filter: "lastname:prefix:l", value: "Leclerc", expected: true},
+		{name: "Contains", filter: "lastname:contains:CLE", value: "Leclerc", expected: true},
+		{name: "Not Contained", filter: "lastname:contains:z", value: "Leclerc", expected: false},
+		{name: "Before", filter: "dateOfBirth:lt:2000-01-01", value: models.NewDate(1997, 10, 16), expected: true},
+		{name: "Null Never Matches", filter: "email:ne:a@b.c", value: nil, expected: false},
+	}
+
+	for _, test := range testCases {
+		list, err := conditions(filtered(t, test.filter), studentTable.FilterFields)
+		if err != nil {
+			t.Fatalf("Test %s : Unexpected error %v", test.name, err)
+		}
+		if actual := list[0].match(test.value); actual != test.expected {
+			t.Errorf("Test %s : Expected %v, but got %v", test.name, test.expected, actual)
+		}
+	}
+}
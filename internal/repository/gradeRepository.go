@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+// GradeCheck decides whether the grade may be saved. It is given the course,
+// whether the student is enrolled in it and the grade already recorded for
+// the course, or nil, a non nil error stops the save
+type GradeCheck func(course *models.Course, enrolled bool, existing *models.Grade) error
+
+// GradeRepository stores the grades the students got for their courses
+type GradeRepository interface {
+	GetByStudent(ctx context.Context, studentID int) ([]models.Grade, error)
+	// Save records the grade when check allows it, replacing the grade
+	// already recorded for the course. A missing student or course is a not
+	// found error
+	Save(ctx context.Context, grade *models.Grade, check GradeCheck) (*models.Grade, error)
+	// GetTranscript returns the graded courses of the student ordered by
+	// semester and course code, the points are left for the caller
+	GetTranscript(ctx context.Context, studentID int) ([]models.TranscriptLine, error)
+}
+
+const gradeColumns = "student_id, course_id, lecturer_id, grade, recorded_at"
+
+type gradeRepository struct {
+	db       *sql.DB
+	dialect  Dialect
+	students *crudRepository[models.Student]
+	courses  *crudRepository[models.Course]
+}
+
+func NewGradeRepository(db *sql.DB, dialect Dialect) *gradeRepository {
+	return &gradeRepository{
+		db:       db,
+		dialect:  dialect,
+		students: NewRepository(db, dialect, studentTable),
+		courses:  NewRepository(db, dialect, courseTable),
+	}
+}
+
+func (s *gradeRepository) GetByStudent(ctx context.Context, studentID int) ([]models.Grade, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+gradeColumns+" FROM grades WHERE student_id = ? "+
+		"ORDER BY course_id;", studentID)
+	if err != nil {
+		log.Error(consts.DBResultsError, err)
+		return nil, dbError(ctx, err)
+	}
+	defer closeRows(rows)
+
+	var list []models.Grade
+	for rows.Next() {
+		var grade models.Grade
+		err := rows.Scan(&grade.StudentID, &grade.CourseID, &grade.LecturerID, &grade.Grade, &grade.RecordedAt)
+		if err != nil {
+			log.Error(consts.DBScanRowError, err)
+			return nil, dbError(ctx, err)
+		}
+		list = append(list, grade)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Error(consts.DBRowsError, err)
+		return nil, dbError(ctx, err)
+	}
+
+	log.Debug("grades of student ", studentID, " : ", list)
+	return list, nil
+}
+
+func (s *gradeRepository) Save(ctx context.Context, grade *models.Grade, check GradeCheck) (*models.Grade, error) {
+	err := s.courses.withTx(ctx, func(tx *sql.Tx) error {
+		course, err := s.courses.getTx(ctx, tx, grade.CourseID)
+		if err != nil {
+			return err
+		}
+		_, err = s.students.getTx(ctx, tx, grade.StudentID)
+		if err != nil {
+			return err
+		}
+
+		var enrolled int
+		err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM enrolments WHERE student_id = ? AND course_id = ?"+
+			s.dialect.Lock+";",
+			grade.StudentID, grade.CourseID).Scan(&enrolled)
+		if err != nil {
+			log.Error(consts.DBResultsError, err)
+			return dbError(ctx, err)
+		}
+
+		var existing *models.Grade
+		var stored models.Grade
+		err = tx.QueryRowContext(ctx, "SELECT "+gradeColumns+" FROM grades WHERE student_id = ? AND course_id = ?"+
+			s.dialect.Lock+";", grade.StudentID, grade.CourseID).
+			Scan(&stored.StudentID, &stored.CourseID, &stored.LecturerID, &stored.Grade, &stored.RecordedAt)
+		switch {
+		case err == nil:
+			existing = &stored
+		case err != sql.ErrNoRows:
+			log.Error(consts.DBResultsError, err)
+			return dbError(ctx, err)
+		}
+
+		err = check(course, enrolled > 0, existing)
+		if err != nil {
+			return err
+		}
+
+		if existing == nil {
+			_, err = tx.ExecContext(ctx, "INSERT INTO grades ("+gradeColumns+") VALUES (?, ?, ?, ?, ?);",
+				grade.StudentID, grade.CourseID, grade.LecturerID, grade.Grade, grade.RecordedAt)
+		} else {
+			_, err = tx.ExecContext(ctx, "UPDATE grades SET lecturer_id = ?, grade = ?, recorded_at = ? "+
+				"WHERE student_id = ? AND course_id = ?;",
+				grade.LecturerID, grade.Grade, grade.RecordedAt, grade.StudentID, grade.CourseID)
+		}
+		if err != nil {
+			log.Error(consts.DBResultsError, err)
+			return dbError(ctx, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("grade : ", *grade)
+	return grade, nil
+}
+
+func (s *gradeRepository) GetTranscript(ctx context.Context, studentID int) ([]models.TranscriptLine, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT c.id, c.code, c.title, c.semester, c.credits, g.grade "+
+		"FROM grades g JOIN courses c ON c.id = g.course_id WHERE g.student_id = ? "+
+		"ORDER BY c.semester, c.code;", studentID)
+	if err != nil {
+		log.Error(consts.DBResultsError, err)
+		return nil, dbError(ctx, err)
+	}
+	defer closeRows(rows)
+
+	var lines []models.TranscriptLine
+	for rows.Next() {
+		var line models.TranscriptLine
+		err := rows.Scan(&line.CourseID, &line.Code, &line.Title, &line.Semester, &line.Credits, &line.Grade)
+		if err != nil {
+			log.Error(consts.DBScanRowError, err)
+			return nil, dbError(ctx, err)
+		}
+		lines = append(lines, line)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Error(consts.DBRowsError, err)
+		return nil, dbError(ctx, err)
+	}
+
+	log.Debug("transcript of student ", studentID, " : ", lines)
+	return lines, nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+)
+
+// memoryAPIKeyRepository keeps the API keys in memory, see memoryRepository
+type memoryAPIKeyRepository struct {
+	mu     sync.RWMutex
+	keys   map[int]models.APIKey
+	lastID int
+}
+
+func NewMemoryAPIKeyRepository() *memoryAPIKeyRepository {
+	return &memoryAPIKeyRepository{
+		keys: map[int]models.APIKey{},
+	}
+}
+
+func (s *memoryAPIKeyRepository) GetAll(ctx context.Context) ([]models.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var list []models.APIKey
+	for _, key := range s.keys {
+		list = append(list, copyAPIKey(key))
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list, nil
+}
+
+func (s *memoryAPIKeyRepository) Get(ctx context.Context, id int) (*models.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key, ok := s.keys[id]
+	if !ok {
+		return nil, apperrors.NotFound(consts.APIKeyNotFound)
+	}
+	key = copyAPIKey(key)
+	return &key, nil
+}
+
+func (s *memoryAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range s.keys {
+		if key.Prefix == prefix {
+			key = copyAPIKey(key)
+			return &key, nil
+		}
+	}
+	return nil, apperrors.NotFound(consts.APIKeyNotFound)
+}
+
+func (s *memoryAPIKeyRepository) Create(ctx context.Context, key *models.APIKey) (*models.APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, k := range s.keys {
+		if k.Prefix == key.Prefix {
+			return nil, apperrors.Conflict(consts.DuplicateEntryError, nil)
+		}
+	}
+
+	s.lastID++
+	key.ID = s.lastID
+	s.keys[key.ID] = copyAPIKey(*key)
+	return key, nil
+}
+
+func (s *memoryAPIKeyRepository) Rotate(ctx context.Context, id int, prefix string,
+	hash string) (*models.APIKey, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.keys[id]
+	if !ok {
+		return nil, apperrors.NotFound(consts.APIKeyNotFound)
+	}
+	key.Prefix = prefix
+	key.Hash = hash
+	s.keys[id] = key
+
+	key = copyAPIKey(key)
+	return &key, nil
+}
+
+func (s *memoryAPIKeyRepository) Revoke(ctx context.Context, id int, at time.Time) (*models.APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.keys[id]
+	if !ok {
+		return nil, apperrors.NotFound(consts.APIKeyNotFound)
+	}
+	if key.RevokedAt == nil {
+		key.RevokedAt = &at
+	}
+	s.keys[id] = key
+
+	key = copyAPIKey(key)
+	return &key, nil
+}
+
+// copyAPIKey copies the slice and pointer fields so the stored key can not
+// be changed through a returned one
+func copyAPIKey(key models.APIKey) models.APIKey {
+	key.Scopes = append([]string(nil), key.Scopes...)
+	if key.RevokedAt != nil {
+		revokedAt := *key.RevokedAt
+		key.RevokedAt = &revokedAt
+	}
+	return key
+}
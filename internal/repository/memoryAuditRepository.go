@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+// memoryAuditRepository keeps the audit events in memory, see
+// memoryRepository. The memory repositories append an event while they hold
+// the lock of the change it records
+type memoryAuditRepository struct {
+	mu     sync.RWMutex
+	events []models.AuditEvent
+}
+
+func NewMemoryAuditRepository() *memoryAuditRepository {
+	return &memoryAuditRepository{}
+}
+
+func (s *memoryAuditRepository) List(ctx context.Context, entity string, entityID int,
+	pagination models.Pagination) (*models.SearchData[models.AuditEvent], error) {
+
+	err := checkPagination(pagination)
+	if err != nil {
+		log.Error(consts.InvalidSearchError, err)
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var list []models.AuditEvent
+	for i := len(s.events) - 1; i >= 0; i-- {
+		event := s.events[i]
+		if (entity == "" || event.Entity == entity) && (entityID == 0 || event.EntityID == entityID) {
+			list = append(list, event)
+		}
+	}
+
+	var resp models.SearchData[models.AuditEvent]
+
+	// paged like the search of memoryRepository
+	if pagination.Page < len(list) {
+		end := pagination.Page + pagination.PageSize
+		if end > len(list) {
+			end = len(list)
+		}
+		if page := list[pagination.Page:end]; len(page) != 0 {
+			resp.TotalElements = len(list)
+			resp.Data = page
+		}
+	}
+
+	log.Debug("list audit_events response : ", resp)
+	return &resp, nil
+}
+
+// append records the event, numbering it
+func (s *memoryAuditRepository) append(event models.AuditEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event.ID = len(s.events) + 1
+	s.events = append(s.events, event)
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+// studentCourse keys the records of a student for a course
+type studentCourse struct {
+	studentID int
+	courseID  int
+}
+
+// memoryEnrolmentRepository keeps the enrolments in memory, the students and
+// courses they reference can not be deleted. The graded enrolments, told by
+// the grade repository, can not be withdrawn
+type memoryEnrolmentRepository struct {
+	mu         sync.RWMutex
+	students   *memoryRepository[models.Student]
+	courses    *memoryRepository[models.Course]
+	grades     *memoryGradeRepository
+	enrolments map[studentCourse]models.Enrolment
+}
+
+func NewMemoryEnrolmentRepository(students *memoryRepository[models.Student],
+	courses *memoryRepository[models.Course]) *memoryEnrolmentRepository {
+	s := &memoryEnrolmentRepository{
+		students:   students,
+		courses:    courses,
+		enrolments: map[studentCourse]models.Enrolment{},
+	}
+	students.referencedBy = append(students.referencedBy, func(id int) bool {
+		return s.any(func(key studentCourse) bool { return key.studentID == id })
+	})
+	courses.referencedBy = append(courses.referencedBy, func(id int) bool {
+		return s.any(func(key studentCourse) bool { return key.courseID == id })
+	})
+	return s
+}
+
+func (s *memoryEnrolmentRepository) GetByStudent(ctx context.Context, studentID int) ([]models.Enrolment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var list []models.Enrolment
+	for key, enrolment := range s.enrolments {
+		if key.studentID == studentID {
+			list = append(list, enrolment)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CourseID < list[j].CourseID
+	})
+
+	log.Debug("enrolments of student ", studentID, " : ", list)
+	return list, nil
+}
+
+func (s *memoryEnrolmentRepository) Enrol(ctx context.Context, enrolment *models.Enrolment,
+	check EnrolmentCheck) (*models.Enrolment, error) {
+
+	// the whole repository is locked while checking, like the course is in
+	// the database
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	course, err := s.courses.Get(ctx, enrolment.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	student, err := s.students.Get(ctx, enrolment.StudentID)
+	if err != nil {
+		return nil, err
+	}
+
+	enrolled := 0
+	for key := range s.enrolments {
+		if key.courseID == enrolment.CourseID {
+			enrolled++
+		}
+	}
+	key := studentCourse{studentID: enrolment.StudentID, courseID: enrolment.CourseID}
+	_, already := s.enrolments[key]
+
+	err = check(student, course, enrolled, already)
+	if err != nil {
+		return nil, err
+	}
+	if already {
+		return nil, apperrors.Conflict(consts.DuplicateEntryError, nil)
+	}
+	s.enrolments[key] = *enrolment
+
+	log.Debug("enrolment : ", *enrolment)
+	return enrolment, nil
+}
+
+func (s *memoryEnrolmentRepository) Withdraw(ctx context.Context, studentID int,
+	courseID int) (*models.Enrolment, error) {
+
+	// the grades are locked first, like the grade repository does when it
+	// reads the enrolments
+	if s.grades != nil {
+		s.grades.mu.RLock()
+		defer s.grades.mu.RUnlock()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := studentCourse{studentID: studentID, courseID: courseID}
+	enrolment, ok := s.enrolments[key]
+	if !ok {
+		return nil, apperrors.NotFound(consts.EnrolmentNotFound)
+	}
+	if s.grades != nil {
+		if _, graded := s.grades.grades[key]; graded {
+			return nil, apperrors.Conflict(consts.GradedWithdrawError, nil)
+		}
+	}
+	delete(s.enrolments, key)
+
+	log.Debug("withdrawn enrolment : ", enrolment)
+	return &enrolment, nil
+}
+
+// enrolled tells whether the student is enrolled in the course
+func (s *memoryEnrolmentRepository) enrolled(studentID int, courseID int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.enrolments[studentCourse{studentID: studentID, courseID: courseID}]
+	return ok
+}
+
+// any tells whether an enrolment matches
+func (s *memoryEnrolmentRepository) any(match func(key studentCourse) bool) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for key := range s.enrolments {
+		if match(key) {
+			return true
+		}
+	}
+	return false
+}
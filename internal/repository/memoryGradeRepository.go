@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+// memoryGradeRepository keeps the grades in memory, the students, courses
+// and lecturers they reference can not be deleted
+type memoryGradeRepository struct {
+	mu         sync.RWMutex
+	students   *memoryRepository[models.Student]
+	courses    *memoryRepository[models.Course]
+	lecturers  *memoryRepository[models.Lecturer]
+	enrolments *memoryEnrolmentRepository
+	grades     map[studentCourse]models.Grade
+}
+
+func NewMemoryGradeRepository(students *memoryRepository[models.Student],
+	courses *memoryRepository[models.Course], lecturers *memoryRepository[models.Lecturer],
+	enrolments *memoryEnrolmentRepository) *memoryGradeRepository {
+	s := &memoryGradeRepository{
+		students:   students,
+		courses:    courses,
+		lecturers:  lecturers,
+		enrolments: enrolments,
+		grades:     map[studentCourse]models.Grade{},
+	}
+	enrolments.grades = s
+	students.referencedBy = append(students.referencedBy, func(id int) bool {
+		return s.any(func(grade models.Grade) bool { return grade.StudentID == id })
+	})
+	courses.referencedBy = append(courses.referencedBy, func(id int) bool {
+		return s.any(func(grade models.Grade) bool { return grade.CourseID == id })
+	})
+	lecturers.referencedBy = append(lecturers.referencedBy, func(id int) bool {
+		return s.any(func(grade models.Grade) bool { return grade.LecturerID == id })
+	})
+	return s
+}
+
+func (s *memoryGradeRepository) GetByStudent(ctx context.Context, studentID int) ([]models.Grade, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var list []models.Grade
+	for key, grade := range s.grades {
+		if key.studentID == studentID {
+			list = append(list, grade)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CourseID < list[j].CourseID
+	})
+
+	log.Debug("grades of student ", studentID, " : ", list)
+	return list, nil
+}
+
+func (s *memoryGradeRepository) Save(ctx context.Context, grade *models.Grade,
+	check GradeCheck) (*models.Grade, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	course, err := s.courses.Get(ctx, grade.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.students.Get(ctx, grade.StudentID)
+	if err != nil {
+		return nil, err
+	}
+
+	key := studentCourse{studentID: grade.StudentID, courseID: grade.CourseID}
+	var existing *models.Grade
+	if stored, ok := s.grades[key]; ok {
+		existing = &stored
+	}
+
+	err = check(course, s.enrolments.enrolled(grade.StudentID, grade.CourseID), existing)
+	if err != nil {
+		return nil, err
+	}
+	if !s.lecturers.has(grade.LecturerID) {
+		return nil, apperrors.Conflict(consts.ForeignKeyError, nil)
+	}
+	s.grades[key] = *grade
+
+	log.Debug("grade : ", *grade)
+	return grade, nil
+}
+
+func (s *memoryGradeRepository) GetTranscript(ctx context.Context, studentID int) ([]models.TranscriptLine, error) {
+	grades, err := s.GetByStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []models.TranscriptLine
+	for _, grade := range grades {
+		course, err := s.courses.Get(ctx, grade.CourseID)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, models.TranscriptLine{
+			CourseID: course.ID,
+			Code:     course.Code,
+			Title:    course.Title,
+			Semester: course.Semester,
+			Credits:  course.Credits,
+			Grade:    grade.Grade,
+		})
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].Semester != lines[j].Semester {
+			return lines[i].Semester < lines[j].Semester
+		}
+		return lines[i].Code < lines[j].Code
+	})
+
+	log.Debug("transcript of student ", studentID, " : ", lines)
+	return lines, nil
+}
+
+// any tells whether a grade matches
+func (s *memoryGradeRepository) any(match func(grade models.Grade) bool) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, grade := range s.grades {
+		if match(grade) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,634 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/filter"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/numbering"
+	"github.com/tryfix/log"
+)
+
+// memoryRepository keeps the entities in memory. It behaves like the SQL
+// repositories, searches match the search columns case insensitively and the
+// unique columns and foreign keys are checked, and is meant for tests and
+// demos, nothing is persisted. The changes are audited to audit, when it is
+// set, the event is built before the change is made so that it is never
+// made without its event. The checks stand for the Check of the table,
+// which reads the database, like the foreign keys stand for its constraints
+type memoryRepository[T any] struct {
+	mu           sync.RWMutex
+	table        Table[T]
+	records      map[int]T
+	lastID       int
+	foreignKeys  []foreignKey
+	referencedBy []func(id int) bool
+	checks       []func(ctx context.Context, entity *T) error
+	numbers      numbering.Format
+	sequences    map[string]int
+	audit        *memoryAuditRepository
+}
+
+// foreignKey is a column holding the id of a record in another repository
+type foreignKey struct {
+	column string
+	exists func(id int) bool
+}
+
+// reference makes column of child a foreign key to the records of parent.
+// Like in the database a child must reference an existing parent and a
+// referenced parent can not be deleted. The repositories are never locked
+// together, so the check is not atomic with the change that follows it
+func reference[C any, P any](child *memoryRepository[C], column string, parent *memoryRepository[P]) {
+	child.foreignKeys = append(child.foreignKeys, foreignKey{column: column, exists: parent.has})
+	parent.referencedBy = append(parent.referencedBy, func(id int) bool {
+		return child.holds(column, id)
+	})
+}
+
+func NewMemoryRepository[T any](table Table[T]) *memoryRepository[T] {
+	return &memoryRepository[T]{
+		table:   table,
+		records: map[int]T{},
+	}
+}
+
+// NewNumberedMemoryRepository is like NewMemoryRepository for a table with
+// a Number column, the new records are numbered with format
+func NewNumberedMemoryRepository[T any](table Table[T], format numbering.Format) *memoryRepository[T] {
+	repo := NewMemoryRepository(table)
+	repo.numbers = format
+	repo.sequences = map[string]int{}
+	return repo
+}
+
+func (s *memoryRepository[T]) GetAll(ctx context.Context, expr filter.Expr) ([]T, error) {
+	kept, err := conditions(expr, s.table.FilterFields)
+	if err != nil {
+		log.Error(consts.InvalidSearchError, err)
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := s.sorted(func(entity *T) bool { return s.visible(ctx, entity) && s.matches(entity, kept) })
+
+	log.Debug("getAll "+s.table.Name+" response : ", list)
+	return list, nil
+}
+
+func (s *memoryRepository[T]) Get(ctx context.Context, id int) (*T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entity, ok := s.records[id]
+	if !ok || !s.visible(ctx, &entity) {
+		return new(T), apperrors.NotFound(s.table.Resource.NotFound)
+	}
+
+	log.Debug(s.table.Resource.Name+" : ", entity)
+	return &entity, nil
+}
+
+func (s *memoryRepository[T]) Create(ctx context.Context, entity *T) (*T, error) {
+	*s.table.ID(entity) = 0
+	err := s.check(ctx, entity)
+	if err != nil {
+		return new(T), err
+	}
+	err = s.checkReferences(entity)
+	if err != nil {
+		return new(T), err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var series string
+	if !s.numbers.IsZero() {
+		now := time.Now()
+		series = s.numbers.Series(now)
+		number := s.numbers.Number(now, s.sequences[series]+1)
+		*s.table.number(entity) = &number
+	}
+	if s.table.DeletedAt != nil {
+		*s.table.DeletedAt(entity) = nil
+	}
+	if s.table.Version != nil {
+		*s.table.Version(entity) = 1
+	}
+
+	id := s.lastID + 1
+	*s.table.ID(entity) = id
+
+	err = s.checkUnique(entity)
+	if err != nil {
+		return new(T), err
+	}
+
+	event, err := newAuditEvent(s.table, actor(ctx), models.AuditCreate, nil, entity)
+	if err != nil {
+		return new(T), err
+	}
+
+	if series != "" {
+		s.sequences[series]++
+	}
+	s.lastID = id
+	s.records[id] = *entity
+	s.record(event)
+
+	log.Debug(s.table.Resource.Name+" : ", *entity)
+	return entity, nil
+}
+
+func (s *memoryRepository[T]) Update(ctx context.Context, entity *T) (*T, error) {
+	err := s.check(ctx, entity)
+	if err != nil {
+		return new(T), err
+	}
+	err = s.checkReferences(entity)
+	if err != nil {
+		return new(T), err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := *s.table.ID(entity)
+	stored, ok := s.records[id]
+	if !ok || s.deleted(&stored) {
+		return new(T), apperrors.NotFound(s.table.Resource.NotFound)
+	}
+	err = s.table.checkVersion(ctx, &stored)
+	if err != nil {
+		return new(T), err
+	}
+	err = s.table.checkNumber(&stored, entity)
+	if err != nil {
+		return new(T), err
+	}
+
+	// the number is never updated and only Delete marks a record deleted
+	updated := *entity
+	if number := s.table.number(&updated); number != nil {
+		*number = *s.table.number(&stored)
+	}
+	if s.table.DeletedAt != nil {
+		*s.table.DeletedAt(&updated) = nil
+	}
+	s.nextVersion(&stored, &updated)
+
+	err = s.checkUnique(&updated)
+	if err != nil {
+		return new(T), err
+	}
+
+	event, err := newAuditEvent(s.table, actor(ctx), models.AuditUpdate, &stored, &updated)
+	if err != nil {
+		return new(T), err
+	}
+	s.records[id] = updated
+	s.record(event)
+
+	log.Debug(s.table.Resource.Name+" : ", updated)
+	return &updated, nil
+}
+
+// Patch changes the record with patch, like the Patch of the SQL
+// repositories
+func (s *memoryRepository[T]) Patch(ctx context.Context, id int, patch func(entity *T) error) (*T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.records[id]
+	if !ok || s.deleted(&stored) {
+		return new(T), apperrors.NotFound(s.table.Resource.NotFound)
+	}
+	err := s.table.checkVersion(ctx, &stored)
+	if err != nil {
+		return new(T), err
+	}
+
+	patched := stored
+	err = patch(&patched)
+	if err != nil {
+		return new(T), err
+	}
+	s.table.keep(&stored, &patched)
+
+	if columns, _ := s.table.changedColumns(&stored, &patched); len(columns) == 0 {
+		return &stored, nil
+	}
+	s.nextVersion(&stored, &patched)
+
+	// the parents are read under their own locks, which never wait on this one
+	err = s.check(ctx, &patched)
+	if err != nil {
+		return new(T), err
+	}
+	err = s.checkReferences(&patched)
+	if err != nil {
+		return new(T), err
+	}
+	err = s.checkUnique(&patched)
+	if err != nil {
+		return new(T), err
+	}
+
+	event, err := newAuditEvent(s.table, actor(ctx), models.AuditUpdate, &stored, &patched)
+	if err != nil {
+		return new(T), err
+	}
+	s.records[id] = patched
+	s.record(event)
+
+	log.Debug(s.table.Resource.Name+" : ", patched)
+	return &patched, nil
+}
+
+func (s *memoryRepository[T]) Search(ctx context.Context, searchString string, expr filter.Expr,
+	pagination models.Pagination, sortBy models.SortBy) (*models.SearchData[T], error) {
+
+	column, direction, err := sortOrder(s.table.SortColumns, sortBy)
+	if err != nil {
+		log.Error(consts.InvalidSearchError, err)
+		return nil, err
+	}
+
+	err = checkPagination(pagination)
+	if err != nil {
+		log.Error(consts.InvalidSearchError, err)
+		return nil, err
+	}
+
+	kept, err := conditions(expr, s.table.FilterFields)
+	if err != nil {
+		log.Error(consts.InvalidSearchError, err)
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	searchString = strings.ToLower(searchString)
+	list := s.sorted(func(entity *T) bool {
+		if !s.visible(ctx, entity) || !s.matches(entity, kept) {
+			return false
+		}
+		for _, c := range s.table.SearchColumns {
+			value, ok := s.value(entity, c).(string)
+			if ok && strings.Contains(strings.ToLower(value), searchString) {
+				return true
+			}
+		}
+		return false
+	})
+
+	sort.SliceStable(list, func(i, j int) bool {
+		a, b := s.value(&list[i], column), s.value(&list[j], column)
+		if direction == "DESC" {
+			return less(b, a)
+		}
+		return less(a, b)
+	})
+
+	var resp models.SearchData[T]
+
+	// the page is selected like LIMIT page,pageSize and, as with the window
+	// count of the SQL query, an empty page has no total
+	if pagination.Page < len(list) {
+		end := pagination.Page + pagination.PageSize
+		if end > len(list) {
+			end = len(list)
+		}
+		if page := list[pagination.Page:end]; len(page) != 0 {
+			resp.TotalElements = len(list)
+			resp.Data = page
+		}
+	}
+
+	log.Debug("search "+s.table.Name+" response : ", resp)
+	return &resp, nil
+}
+
+func (s *memoryRepository[T]) Delete(ctx context.Context, id int) (*T, error) {
+	if s.referenced(id) {
+		return new(T), apperrors.Conflict(consts.ForeignKeyError, nil)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted, ok := s.records[id]
+	if !ok || s.deleted(&deleted) {
+		return new(T), apperrors.NotFound(s.table.Resource.NotFound)
+	}
+	err := s.table.checkVersion(ctx, &deleted)
+	if err != nil {
+		return new(T), err
+	}
+	if s.table.DeletedAt != nil {
+		before := deleted
+		now := dbNow()
+		*s.table.DeletedAt(&deleted) = &now
+		s.nextVersion(&before, &deleted)
+
+		event, err := newAuditEvent(s.table, actor(ctx), models.AuditDelete, &before, &deleted)
+		if err != nil {
+			return new(T), err
+		}
+		s.records[id] = deleted
+		s.record(event)
+	} else {
+		event, err := newAuditEvent(s.table, actor(ctx), models.AuditDelete, &deleted, nil)
+		if err != nil {
+			return new(T), err
+		}
+		delete(s.records, id)
+		s.record(event)
+	}
+
+	log.Debug(s.table.Resource.Name+" id : ", id)
+	return &deleted, nil
+}
+
+// Restore undeletes a soft deleted record and returns it, a missing or live
+// record is a not found error
+func (s *memoryRepository[T]) Restore(ctx context.Context, id int) (*T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before, ok := s.records[id]
+	if !ok || !s.deleted(&before) {
+		return new(T), apperrors.NotFound(s.table.Resource.NotFound)
+	}
+	restored := before
+	*s.table.DeletedAt(&restored) = nil
+	s.nextVersion(&before, &restored)
+
+	event, err := newAuditEvent(s.table, actor(ctx), models.AuditRestore, &before, &restored)
+	if err != nil {
+		return new(T), err
+	}
+	s.records[id] = restored
+	s.record(event)
+
+	log.Debug(s.table.Resource.Name+" : ", restored)
+	return &restored, nil
+}
+
+// Purge removes for good the records soft deleted before the time, the
+// records still referenced are kept
+func (s *memoryRepository[T]) Purge(ctx context.Context, before time.Time) (int, error) {
+	s.mu.RLock()
+	expired := s.sorted(func(entity *T) bool {
+		return s.deleted(entity) && (*s.table.DeletedAt(entity)).Before(before)
+	})
+	s.mu.RUnlock()
+
+	purged := 0
+	for i := range expired {
+		id := *s.table.ID(&expired[i])
+		if s.referenced(id) {
+			log.Info(fmt.Sprintf("%s %d is still referenced, it is not purged", s.table.Resource.Name, id))
+			continue
+		}
+
+		removed, err := s.purge(id, before)
+		if err != nil {
+			return purged, err
+		}
+		if removed {
+			purged++
+		}
+	}
+
+	log.Debug(fmt.Sprintf("purged %d %s", purged, s.table.Name))
+	return purged, nil
+}
+
+// purge removes for good the record with the id if it is still soft
+// deleted before the time and tells whether it did
+func (s *memoryRepository[T]) purge(id int, before time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entity, ok := s.records[id]
+	if !ok || !s.deleted(&entity) || !(*s.table.DeletedAt(&entity)).Before(before) {
+		return false, nil
+	}
+
+	event, err := newAuditEvent(s.table, systemActor, models.AuditPurge, &entity, nil)
+	if err != nil {
+		return false, err
+	}
+	delete(s.records, id)
+	s.record(event)
+	return true, nil
+}
+
+// nextVersion sets the version of the changed record to the one after that
+// of the stored record, when the table has a Version
+func (s *memoryRepository[T]) nextVersion(stored *T, changed *T) {
+	if s.table.Version != nil {
+		*s.table.Version(changed) = *s.table.Version(stored) + 1
+	}
+}
+
+// record appends the event of a change when the repository is audited. The
+// caller must hold the lock
+func (s *memoryRepository[T]) record(event models.AuditEvent) {
+	if s.audit != nil {
+		s.audit.append(event)
+	}
+}
+
+// getBy returns the record whose unique column holds value, a missing
+// record is a not found error
+func (s *memoryRepository[T]) getBy(ctx context.Context, column string, value interface{}) (*T, error) {
+	list, err := s.listBy(ctx, column, value)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, apperrors.NotFound(s.table.Resource.NotFound)
+	}
+	return &list[0], nil
+}
+
+// listBy returns the records whose column holds value, ordered by id
+func (s *memoryRepository[T]) listBy(ctx context.Context, column string, value interface{}) ([]T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	list := s.sorted(func(entity *T) bool {
+		return s.visible(ctx, entity) && s.value(entity, column) == value
+	})
+
+	log.Debug("list "+s.table.Name+" by "+column+" response : ", list)
+	return list, nil
+}
+
+// has tells whether a record with the id exists, a soft deleted record
+// exists until it is purged like in the databases
+func (s *memoryRepository[T]) has(id int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.records[id]
+	return ok
+}
+
+// holds tells whether the column of any record holds value
+func (s *memoryRepository[T]) holds(column string, value interface{}) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, entity := range s.records {
+		if s.value(&entity, column) == value {
+			return true
+		}
+	}
+	return false
+}
+
+// referenced tells whether a record of another repository references the
+// record with the id
+func (s *memoryRepository[T]) referenced(id int) bool {
+	for _, referenced := range s.referencedBy {
+		if referenced(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// deleted tells whether the record is soft deleted
+func (s *memoryRepository[T]) deleted(entity *T) bool {
+	return s.table.DeletedAt != nil && *s.table.DeletedAt(entity) != nil
+}
+
+// visible tells whether the reads of ctx see the record, the soft deleted
+// records are seen only when ctx includes them
+func (s *memoryRepository[T]) visible(ctx context.Context, entity *T) bool {
+	return !s.deleted(entity) || includesDeleted(ctx)
+}
+
+// matches tells whether the record holds every condition of the filter
+func (s *memoryRepository[T]) matches(entity *T, filter []condition) bool {
+	for _, c := range filter {
+		if !c.match(s.value(entity, c.column)) {
+			return false
+		}
+	}
+	return true
+}
+
+// check returns the error of the first check of the entity that fails
+func (s *memoryRepository[T]) check(ctx context.Context, entity *T) error {
+	for _, check := range s.checks {
+		err := check(ctx, entity)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkReferences returns a conflict when a foreign key of the entity
+// references a missing record
+func (s *memoryRepository[T]) checkReferences(entity *T) error {
+	for _, fk := range s.foreignKeys {
+		id, ok := s.value(entity, fk.column).(int)
+		if ok && !fk.exists(id) {
+			return apperrors.Conflict(consts.ForeignKeyError, nil)
+		}
+	}
+	return nil
+}
+
+// checkUnique returns a conflict when another record has the same value in
+// a unique column, nulls are never the same as in the databases. The caller
+// must hold the lock
+func (s *memoryRepository[T]) checkUnique(entity *T) error {
+	id := *s.table.ID(entity)
+	for _, column := range s.table.Unique {
+		value := s.value(entity, column)
+		if value == nil {
+			continue
+		}
+		for otherID, other := range s.records {
+			if otherID != id && s.value(&other, column) == value {
+				return apperrors.Conflict(consts.DuplicateEntryError, nil)
+			}
+		}
+	}
+	return nil
+}
+
+// sorted returns the records that match keep, ordered by id. The caller must
+// hold the lock
+func (s *memoryRepository[T]) sorted(keep func(entity *T) bool) []T {
+	var list []T
+	for _, entity := range s.records {
+		if keep(&entity) {
+			list = append(list, entity)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return *s.table.ID(&list[i]) < *s.table.ID(&list[j])
+	})
+	return list
+}
+
+// value returns the value of the named column of the entity, a column that
+// may be null holds nil or the value pointed to
+func (s *memoryRepository[T]) value(entity *T, column string) interface{} {
+	if column == "id" {
+		return *s.table.ID(entity)
+	}
+	for i, c := range s.table.Columns {
+		if c != column {
+			continue
+		}
+		value := reflect.ValueOf(s.table.Fields(entity)[i]).Elem()
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				return nil
+			}
+			value = value.Elem()
+		}
+		return value.Interface()
+	}
+	return nil
+}
+
+// less compares two column values, strings are compared case insensitively
+// and nulls come first like in the databases
+func less(a, b interface{}) bool {
+	if a == nil {
+		return b != nil
+	}
+	switch a := a.(type) {
+	case int:
+		b, _ := b.(int)
+		return a < b
+	case string:
+		b, _ := b.(string)
+		return strings.ToLower(a) < strings.ToLower(b)
+	case models.Date:
+		b, _ := b.(models.Date)
+		return a.Before(b.Time)
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/numbering"
+)
+
+// Repositories holds the repository of every entity for one storage driver
+type Repositories struct {
+	Students    StudentRepository
+	Lecturers   LecturerRepository
+	Staff       StaffRepository
+	Departments DepartmentRepository
+	Courses     CourseRepository
+	Enrolments  EnrolmentRepository
+	Grades      GradeRepository
+	APIKeys     APIKeyRepository
+	Audit       AuditRepository
+}
+
+// Numbers holds the formats of the generated student and staff numbers
+type Numbers struct {
+	Students numbering.Format
+	Staff    numbering.Format
+}
+
+// NewSQLRepositories returns the repositories that store the entities in db
+func NewSQLRepositories(db *sql.DB, dialect Dialect, numbers Numbers) *Repositories {
+	return &Repositories{
+		Students:    NewStudentRepository(db, dialect, numbers.Students),
+		Lecturers:   NewLecturerRepository(db, dialect),
+		Staff:       NewStaffRepository(db, dialect, numbers.Staff),
+		Departments: NewDepartmentRepository(db, dialect),
+		Courses:     NewCourseRepository(db, dialect),
+		Enrolments:  NewEnrolmentRepository(db, dialect),
+		Grades:      NewGradeRepository(db, dialect),
+		APIKeys:     NewAPIKeyRepository(db),
+		Audit:       NewAuditRepository(db),
+	}
+}
+
+// NumberExisting numbers the students and staff stored without a number,
+// eg. the ones stored before the numbers were added, with numbers. They are
+// numbered in the series of the new records, so the two share a sequence,
+// and it returns how many were numbered
+func NumberExisting(ctx context.Context, db *sql.DB, dialect Dialect, numbers Numbers) (int, error) {
+	students, err := NewNumberedRepository(db, dialect, studentTable, numbers.Students).numberMissing(ctx)
+	if err != nil {
+		return students, err
+	}
+	staff, err := NewNumberedRepository(db, dialect, staffTable, numbers.Staff).numberMissing(ctx)
+	return students + staff, err
+}
+
+// NewMemoryRepositories returns repositories that keep the entities in
+// memory, the changes of the CRUD entities are audited like in the databases
+func NewMemoryRepositories(numbers Numbers) *Repositories {
+	audit := NewMemoryAuditRepository()
+	students := NewMemoryStudentRepository(numbers.Students)
+	students.audit = audit
+	lecturers := NewMemoryLecturerRepository()
+	lecturers.audit = audit
+	staff := NewMemoryStaffRepository(numbers.Staff)
+	staff.audit = audit
+	courses := newMemoryCourses(lecturers)
+	courses.audit = audit
+	departments := newMemoryDepartments(lecturers, staff)
+	departments.audit = audit
+	enrolments := NewMemoryEnrolmentRepository(students, courses)
+	return &Repositories{
+		Students:    studentRepository{students},
+		Lecturers:   lecturers,
+		Staff:       staffRepository{staff},
+		Departments: departmentRepository{departments, lecturers, staff},
+		Courses:     courseRepository{courses},
+		Enrolments:  enrolments,
+		Grades:      NewMemoryGradeRepository(students, courses, lecturers, enrolments),
+		APIKeys:     NewMemoryAPIKeyRepository(),
+		Audit:       audit,
+	}
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+)
+
+var sortDirections = map[string]string{
+	"ASC":  "ASC",
+	"DESC": "DESC",
+}
+
+// searchQuery holds the metadata needed to build a search query for a table.
+// sortColumns is the whitelist of columns the results can be ordered by,
+// conditions are matched by every result and conditionArgs are the arguments
+// of their placeholders
+type searchQuery struct {
+	table         string
+	columns       string
+	searchColumns []string
+	sortColumns   map[string]string
+	conditions    []string
+	conditionArgs []interface{}
+	likeEscape    string
+}
+
+// build returns the search query together with its arguments. Only values
+// taken from the whitelists are written into the query text, everything the
+// user sends is bound through placeholders
+func (q searchQuery) build(searchString string, pagination models.Pagination,
+	sortBy models.SortBy) (string, []interface{}, error) {
+
+	column, direction, err := sortOrder(q.sortColumns, sortBy)
+	if err != nil {
+		return "", nil, err
+	}
+
+	err = checkPagination(pagination)
+	if err != nil {
+		return "", nil, err
+	}
+
+	pattern := "%" + escapeLike(searchString) + "%"
+
+	var conditions []string
+	var args []interface{}
+	for _, c := range q.searchColumns {
+		conditions = append(conditions, c+" LIKE ?"+q.likeEscape)
+		args = append(args, pattern)
+	}
+	where := strings.Join(conditions, " OR ")
+
+	if len(q.conditions) != 0 {
+		where = "(" + where + ")"
+		for _, c := range q.conditions {
+			where += " AND " + c
+		}
+		args = append(args, q.conditionArgs...)
+	}
+	args = append(args, pagination.Page, pagination.PageSize)
+
+	query := "SELECT " + q.columns + ", Count(*) Over () AS TotalCount FROM " + q.table +
+		" WHERE " + where + " ORDER BY " + column + " " + direction + " LIMIT ?,?;"
+
+	return query, args, nil
+}
+
+// sortOrder returns the column and direction the search results are ordered
+// by, taken from the whitelists. The results are ordered by id by default
+func sortOrder(sortColumns map[string]string, sortBy models.SortBy) (string, string, error) {
+	column := "id"
+	if sortBy.Column != "" {
+		c, ok := sortColumns[strings.ToLower(sortBy.Column)]
+		if !ok {
+			return "", "", invalidSearch(fmt.Sprintf("sort column %q is not allowed", sortBy.Column))
+		}
+		column = c
+	}
+
+	direction := "ASC"
+	if sortBy.Direction != "" {
+		d, ok := sortDirections[strings.ToUpper(sortBy.Direction)]
+		if !ok {
+			return "", "", invalidSearch(fmt.Sprintf("sort direction %q is not allowed",
+				sortBy.Direction))
+		}
+		direction = d
+	}
+	return column, direction, nil
+}
+
+func checkPagination(pagination models.Pagination) error {
+	if pagination.Page < 0 || pagination.PageSize < 0 {
+		return invalidSearch("pagination values can not be negative")
+	}
+	return nil
+}
+
+// invalidSearch returns the validation error for a rejected search request
+func invalidSearch(reason string) error {
+	return apperrors.Validation(consts.InvalidSearchError+" : "+reason, nil)
+}
+
+// escapeLike escapes the LIKE wildcards so the search string is matched literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/filter"
+)
+
+func TestSearchQuery_Build_HappyPath(t *testing.T) {
+	testCases := []struct {
+		name          string
+		searchString  string
+		pagination    models.Pagination
+		sortBy        models.SortBy
+		expectedQuery string
+		expectedArgs  []interface{}
+	}{
+		{
+			name:          "Sort By Firstname",
+			searchString:  "charl",
+			pagination:    models.Pagination{Page: 0, PageSize: 2},
+			sortBy:        models.SortBy{Column: "firstname", Direction: "asc"},
+			expectedQuery: "SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version, Count(*) Over () AS TotalCount FROM students WHERE (firstname LIKE ? OR lastname LIKE ?) AND deleted_at IS NULL ORDER BY firstname ASC LIMIT ?,?;",
+			expectedArgs:  []interface{}{"%charl%", "%charl%", 0, 2},
+		},
+		{
+			name:          "Default Sort",
+			searchString:  "",
+			pagination:    models.Pagination{Page: 0, PageSize: 10},
+			sortBy:        models.SortBy{},
+			expectedQuery: "SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version, Count(*) Over () AS TotalCount FROM students WHERE (firstname LIKE ? OR lastname LIKE ?) AND deleted_at IS NULL ORDER BY id ASC LIMIT ?,?;",
+			expectedArgs:  []interface{}{"%%", "%%", 0, 10},
+		},
+		{
+			name:          "Injection Attempt Is Bound",
+			searchString:  "x' OR '1'='1",
+			pagination:    models.Pagination{Page: 0, PageSize: 2},
+			sortBy:        models.SortBy{Column: "YEAR", Direction: "DESC"},
+			expectedQuery: "SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version, Count(*) Over () AS TotalCount FROM students WHERE (firstname LIKE ? OR lastname LIKE ?) AND deleted_at IS NULL ORDER BY year DESC LIMIT ?,?;",
+			expectedArgs:  []interface{}{"%x' OR '1'='1%", "%x' OR '1'='1%", 0, 2},
+		},
+		{
+			name:          "Wildcards Are Escaped",
+			searchString:  "50%_",
+			pagination:    models.Pagination{Page: 0, PageSize: 2},
+			sortBy:        models.SortBy{Column: "lastname", Direction: "ASC"},
+			expectedQuery: "SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version, Count(*) Over () AS TotalCount FROM students WHERE (firstname LIKE ? OR lastname LIKE ?) AND deleted_at IS NULL ORDER BY lastname ASC LIMIT ?,?;",
+			expectedArgs:  []interface{}{`%50\%\_%`, `%50\%\_%`, 0, 2},
+		},
+	}
+
+	for _, test := range testCases {
+		query, args, err := searchOf(t, studentTable, context.Background(), nil, MySQL).build(test.searchString, test.pagination, test.sortBy)
+		if err != nil {
+			t.Errorf("Test %s : Unexpected error %v", test.name, err)
+		}
+		if query != test.expectedQuery {
+			t.Errorf("Test %s : Expected query %s, but got %s", test.name, test.expectedQuery, query)
+		}
+		if !reflect.DeepEqual(args, test.expectedArgs) {
+			t.Errorf("Test %s : Expected args %v, but got %v", test.name, test.expectedArgs, args)
+		}
+	}
+}
+
+func TestSearchQuery_Build_SQLite(t *testing.T) {
+	query, _, err := searchOf(t, studentTable, context.Background(), nil, SQLite).build("a", models.Pagination{Page: 0, PageSize: 2},
+		models.SortBy{})
+	expected := `SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version, Count(*) Over () AS TotalCount FROM students WHERE (firstname LIKE ? ESCAPE '\' OR lastname LIKE ? ESCAPE '\') AND deleted_at IS NULL ORDER BY id ASC LIMIT ?,?;`
+	if err != nil || query != expected {
+		t.Errorf("Expected query %s, but got %s, %v", expected, query, err)
+	}
+}
+
+func TestSearchQuery_Build_Filters(t *testing.T) {
+	query, args, err := searchOf(t, lecturerTable, context.Background(), filtered(t, "departmentId:eq:2"), MySQL).
+		build("new", models.Pagination{Page: 0, PageSize: 2}, models.SortBy{})
+	expectedQuery := "SELECT id, firstname, lastname, year, department_id, email, phone, date_of_birth, status, deleted_at, version, Count(*) Over () AS TotalCount FROM lecturers WHERE (firstname LIKE ? OR lastname LIKE ?) AND department_id = ? AND deleted_at IS NULL ORDER BY id ASC LIMIT ?,?;"
+	expectedArgs := []interface{}{"%new%", "%new%", 2, 0, 2}
+	if err != nil || query != expectedQuery || !reflect.DeepEqual(args, expectedArgs) {
+		t.Errorf("Expected query %s with %v, but got %s with %v, %v", expectedQuery, expectedArgs, query, args, err)
+	}
+}
+
+func TestSearchQuery_Build_IncludeDeleted(t *testing.T) {
+	query, _, err := searchOf(t, studentTable, WithDeleted(context.Background()), nil, MySQL).build("a",
+		models.Pagination{Page: 0, PageSize: 2}, models.SortBy{})
+	expected := "SELECT id, registration_number, firstname, lastname, year, email, phone, date_of_birth, enrolment_date, status, deleted_at, version, Count(*) Over () AS TotalCount FROM students WHERE firstname LIKE ? OR lastname LIKE ? ORDER BY id ASC LIMIT ?,?;"
+	if err != nil || query != expected {
+		t.Errorf("Expected query %s, but got %s, %v", expected, query, err)
+	}
+}
+
+func TestSearchQuery_Build_ErrorPath(t *testing.T) {
+	testCases := []struct {
+		name       string
+		pagination models.Pagination
+		sortBy     models.SortBy
+	}{
+		{
+			name:       "Unknown Column",
+			pagination: models.Pagination{Page: 0, PageSize: 2},
+			sortBy:     models.SortBy{Column: "firstname; DROP TABLE students", Direction: "ASC"},
+		},
+		{
+			name:       "Column Of Another Entity",
+			pagination: models.Pagination{Page: 0, PageSize: 2},
+			sortBy:     models.SortBy{Column: "position", Direction: "ASC"},
+		},
+		{
+			name:       "Unknown Direction",
+			pagination: models.Pagination{Page: 0, PageSize: 2},
+			sortBy:     models.SortBy{Column: "firstname", Direction: "ASC, id"},
+		},
+		{
+			name:       "Negative Pagination",
+			pagination: models.Pagination{Page: -1, PageSize: 2},
+			sortBy:     models.SortBy{Column: "firstname", Direction: "ASC"},
+		},
+	}
+
+	for _, test := range testCases {
+		_, _, err := searchOf(t, studentTable, context.Background(), nil, MySQL).build("a", test.pagination, test.sortBy)
+		if apperrors.CodeOf(err) != apperrors.CodeValidation {
+			t.Errorf("Test %s : Expected a validation error, but got %v", test.name, err)
+		}
+	}
+}
+
+func TestSortOrder_CamelCaseColumn(t *testing.T) {
+	for _, requested := range []string{"lecturerId", "minYear"} {
+		_, _, err := sortOrder(courseTable.SortColumns, models.SortBy{Column: requested})
+		if err != nil {
+			t.Errorf("Test %s : Expected the course sort column to be allowed, but got %v", requested, err)
+		}
+	}
+}
+
+func TestDBError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected apperrors.Code
+	}{
+		{name: "Duplicate Entry", err: &mysql.MySQLError{Number: 1062}, expected: apperrors.CodeConflict},
+		{name: "Foreign Key", err: &mysql.MySQLError{Number: 1452}, expected: apperrors.CodeConflict},
+		{name: "Other MySQL Error", err: &mysql.MySQLError{Number: 1146}, expected: apperrors.CodeInternal},
+		{name: "Deadline Exceeded", err: context.DeadlineExceeded, expected: apperrors.CodeTimeout},
+		{name: "Connection Error", err: sql.ErrConnDone, expected: apperrors.CodeInternal},
+	}
+
+	for _, test := range testCases {
+		if actual := apperrors.CodeOf(dbError(context.Background(), test.err)); actual != test.expected {
+			t.Errorf("Test %s : Expected %s, but got %s", test.name, test.expected, actual)
+		}
+	}
+}
+
+// searchOf returns the search query of the table, failing the test when the
+// filter expression is rejected
+func searchOf[T any](t *testing.T, table Table[T], ctx context.Context, expr filter.Expr,
+	dialect Dialect) searchQuery {
+	search, err := table.search(ctx, expr, dialect)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	return search
+}
@@ -1 +1,66 @@
 package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/numbering"
+)
+
+type StaffRepository interface {
+	Repository[models.Staff]
+	// GetByNumber returns the staff member with the staff number
+	GetByNumber(ctx context.Context, number string) (*models.Staff, error)
+}
+
+var staffTable = Table[models.Staff]{
+	Name:          "staff",
+	Resource:      models.StaffResource,
+	Columns:       []string{"staff_number", "firstname", "lastname", "position", "department_id"},
+	SearchColumns: []string{"firstname", "lastname"},
+	SortColumns: map[string]string{
+		"id":           "id",
+		"staffnumber":  "staff_number",
+		"firstname":    "firstname",
+		"lastname":     "lastname",
+		"position":     "position",
+		"departmentid": "department_id",
+	},
+	FilterFields: map[string]FilterField{
+		"id":           {Column: "id", Kind: NumberField},
+		"staffnumber":  {Column: "staff_number", Kind: TextField},
+		"firstname":    {Column: "firstname", Kind: TextField},
+		"lastname":     {Column: "lastname", Kind: TextField},
+		"position":     {Column: "position", Kind: TextField},
+		"departmentid": {Column: "department_id", Kind: NumberField},
+	},
+	Unique:      []string{"staff_number"},
+	Number:      "staff_number",
+	NumberField: "staffNumber",
+	ID: func(staff *models.Staff) *int {
+		return &staff.ID
+	},
+	Fields: func(staff *models.Staff) []interface{} {
+		return []interface{}{&staff.StaffNumber, &staff.FirstName, &staff.LastName, &staff.Position,
+			&staff.DepartmentID}
+	},
+}
+
+type staffRepository struct {
+	getRepository[models.Staff]
+}
+
+// NewStaffRepository returns a repository that numbers the new staff with
+// format
+func NewStaffRepository(db *sql.DB, dialect Dialect, format numbering.Format) StaffRepository {
+	return staffRepository{NewNumberedRepository(db, dialect, staffTable, format)}
+}
+
+func NewMemoryStaffRepository(format numbering.Format) *memoryRepository[models.Staff] {
+	return NewNumberedMemoryRepository(staffTable, format)
+}
+
+func (s staffRepository) GetByNumber(ctx context.Context, number string) (*models.Staff, error) {
+	return s.getBy(ctx, "staff_number", number)
+}
@@ -1,264 +1,93 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
-	"errors"
-	"fmt"
+	"time"
+
 	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
-	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
-	"github.com/tryfix/log"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/numbering"
 )
 
 type StudentRepository interface {
-	GetAllStudents() ([]models.Student, error)
-	GetStudent(id int) (*models.Student, error)
-	CreateStudent(student *models.Student) (*models.Student, error)
-	UpdateStudent(student *models.Student) (*models.Student, error)
-	SearchStudent(searchString string, pagination models.Pagination,
-		sortBy models.SortBy) (*models.StudentSearchData, error)
-	DeleteStudent(id int) (*models.Student, error)
+	SoftDeleteRepository[models.Student]
+	Patcher[models.Student]
+	// GetByNumber returns the student with the registration number
+	GetByNumber(ctx context.Context, number string) (*models.Student, error)
 }
 
-type studentRepository struct {
-	db *sql.DB
+var studentTable = Table[models.Student]{
+	Name:     "students",
+	Resource: models.StudentResource,
+	Columns: []string{"registration_number", "firstname", "lastname", "year", "email", "phone", "date_of_birth",
+		"enrolment_date", "status"},
+	SearchColumns: []string{"firstname", "lastname"},
+	SortColumns: map[string]string{
+		"id":                 "id",
+		"registrationnumber": "registration_number",
+		"firstname":          "firstname",
+		"lastname":           "lastname",
+		"year":               "year",
+		"email":              "email",
+		"dateofbirth":        "date_of_birth",
+		"enrolmentdate":      "enrolment_date",
+		"status":             "status",
+	},
+	FilterFields: map[string]FilterField{
+		"id":                 {Column: "id", Kind: NumberField},
+		"registrationnumber": {Column: "registration_number", Kind: TextField},
+		"firstname":          {Column: "firstname", Kind: TextField},
+		"lastname":           {Column: "lastname", Kind: TextField},
+		"year":               {Column: "year", Kind: NumberField},
+		"email":              {Column: "email", Kind: TextField},
+		"dateofbirth":        {Column: "date_of_birth", Kind: DateField},
+		"enrolmentdate":      {Column: "enrolment_date", Kind: DateField},
+		"status":             {Column: "status", Kind: TextField},
+	},
+	Unique:      []string{"registration_number", "email"},
+	Number:      "registration_number",
+	NumberField: "registrationNumber",
+	ID: func(student *models.Student) *int {
+		return &student.ID
+	},
+	Fields: func(student *models.Student) []interface{} {
+		return []interface{}{&student.RegistrationNumber, &student.FirstName, &student.LastName, &student.Year,
+			&student.Email, &student.Phone, &student.DateOfBirth, &student.EnrolmentDate, &student.Status}
+	},
+	DeletedAt: func(student *models.Student) **time.Time {
+		return &student.DeletedAt
+	},
+	Version: func(student *models.Student) *int {
+		return &student.Version
+	},
+	ReferencedBy: []Reference{
+		{Table: "enrolments", Column: "student_id"},
+		{Table: "grades", Column: "student_id"},
+	},
 }
 
-func NewStudentRepository(db *sql.DB) *studentRepository {
-	return &studentRepository{
-		db: db,
-	}
+// getRepository is a soft deleting and patching repository that can get a
+// record by a unique column, both the SQL and the memory repositories are
+type getRepository[T any] interface {
+	SoftDeleteRepository[T]
+	Patcher[T]
+	getBy(ctx context.Context, column string, value interface{}) (*T, error)
 }
 
-func (s *studentRepository) GetAllStudents() ([]models.Student, error) {
-
-	stmt, err := s.db.Prepare("SELECT * FROM students")
-	if err != nil {
-		log.Error(consts.QueryPrepareError, err)
-		return nil, err
-	}
-	defer func(stmt *sql.Stmt) {
-		err := stmt.Close()
-		if err != nil {
-			log.Error(consts.DBStatementCloseError, err)
-		}
-	}(stmt)
-
-	rows, err := stmt.Query()
-	if err != nil {
-		log.Error(consts.DBResultsError, err)
-		return nil, err
-	}
-	defer func(rows *sql.Rows) {
-		err := rows.Close()
-		if err != nil {
-			log.Error(consts.DBRowCloseError, err)
-		}
-	}(rows)
-
-	var studentList []models.Student
-
-	for rows.Next() {
-		var st models.Student
-
-		err := rows.Scan(&st.ID, &st.FirstName, &st.LastName, &st.Year)
-		if err != nil {
-			log.Error(consts.DBScanRowError, err)
-			return nil, err
-		}
-
-		err = rows.Err()
-		if err != nil {
-			log.Error(consts.DBRowsError, err)
-			return nil, err
-		}
-
-		studentList = append(studentList, st)
-
-	}
-
-	log.Debug("getAllStudents response : ", studentList)
-	return studentList, nil
-}
-
-func (s *studentRepository) GetStudent(id int) (*models.Student, error) {
-	var student models.Student
-
-	stmt, err := s.db.Prepare("SELECT * FROM students WHERE id = ?;")
-	if err != nil {
-		log.Error(consts.QueryPrepareError, err)
-		return &student, err
-	}
-	defer func(stmt *sql.Stmt) {
-		err := stmt.Close()
-		if err != nil {
-			log.Error(consts.DBStatementCloseError, err)
-		}
-	}(stmt)
-
-	err = stmt.QueryRow(id).Scan(&student.ID, &student.FirstName, &student.LastName, &student.Year)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return &models.Student{}, errors.New(consts.StudentNotFound)
-		}
-		log.Error(consts.DBResultsError, err)
-		return &models.Student{}, err
-	}
-
-	log.Debug("Student : ", student)
-	return &student, err
-}
-
-func (s *studentRepository) CreateStudent(student *models.Student) (*models.Student, error) {
-	var st models.Student
-
-	stmt, err := s.db.Prepare("INSERT INTO students (firstname,lastname,year)" +
-		" VALUES (?,?,?);")
-	if err != nil {
-		log.Error(consts.QueryPrepareError, err)
-		return &st, err
-	}
-	defer func(stmt *sql.Stmt) {
-		err := stmt.Close()
-		if err != nil {
-			log.Error(consts.DBStatementCloseError, err)
-		}
-	}(stmt)
-
-	result, err := stmt.Exec(student.FirstName, student.LastName, student.Year)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return &st, errors.New(consts.StudentNotFound)
-		}
-		log.Error(consts.DBResultsError, err)
-		return &st, err
-	}
-
-	id, err := result.LastInsertId()
-	if err != nil {
-		log.Error(consts.DBResultIDError, err)
-	}
-
-	student.ID = int(id)
-
-	log.Debug("Student : ", *student)
-	return student, err
+type studentRepository struct {
+	getRepository[models.Student]
 }
 
-func (s *studentRepository) UpdateStudent(student *models.Student) (*models.Student, error) {
-	var st models.Student
-
-	stmt, err := s.db.Prepare("UPDATE students SET firstname = ?, lastname = ?, year = ? " +
-		"WHERE id = ?;")
-	if err != nil {
-		log.Error(consts.QueryPrepareError, err)
-		return &st, err
-	}
-	defer func(stmt *sql.Stmt) {
-		err := stmt.Close()
-		if err != nil {
-			log.Error(consts.DBStatementCloseError, err)
-		}
-	}(stmt)
-
-	_, err = stmt.Exec(student.FirstName, student.LastName, student.Year, student.ID)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return &st, errors.New(consts.StudentNotFound)
-		}
-		log.Error(consts.DBResultsError, err)
-		return &st, err
-	}
-
-	log.Debug("Student : ", *student)
-	return student, err
+// NewStudentRepository returns a repository that numbers the new students
+// with format
+func NewStudentRepository(db *sql.DB, dialect Dialect, format numbering.Format) StudentRepository {
+	return studentRepository{NewNumberedRepository(db, dialect, studentTable, format)}
 }
 
-func (s *studentRepository) SearchStudent(searchString string, pagination models.Pagination,
-	sortBy models.SortBy) (*models.StudentSearchData, error) {
-
-	query := fmt.Sprintf("SELECT *, Count(*) Over () AS TotalCount FROM "+
-		"students WHERE firstname LIKE '%%%s%%' || lastname LIKE '%%%s%%' ORDER BY %s %s "+
-		"LIMIT %v,%v;", searchString, searchString,
-		sortBy.Column, sortBy.Direction, pagination.Page, pagination.PageSize)
-
-	stmt, err := s.db.Prepare(query)
-
-	if err != nil {
-		log.Error(consts.QueryPrepareError, err)
-		return nil, err
-	}
-	defer func(stmt *sql.Stmt) {
-		err := stmt.Close()
-		if err != nil {
-			log.Error(consts.DBStatementCloseError, err)
-		}
-	}(stmt)
-
-	rows, err := stmt.Query()
-	if err != nil {
-		log.Error(consts.DBResultsError, err)
-		return nil, err
-	}
-	defer func(rows *sql.Rows) {
-		err := rows.Close()
-		if err != nil {
-			log.Error(consts.DBRowCloseError, err)
-		}
-	}(rows)
-
-	var studentList []models.Student
-	var totalCount int
-	var resp models.StudentSearchData
-
-	for rows.Next() {
-		var st models.Student
-
-		err := rows.Scan(&st.ID, &st.FirstName, &st.LastName, &st.Year, &totalCount)
-		if err != nil {
-			log.Error(consts.DBScanRowError, err)
-			return nil, err
-		}
-
-		err = rows.Err()
-		if err != nil {
-			log.Error(consts.DBRowsError, err)
-			return nil, err
-		}
-
-		studentList = append(studentList, st)
-	}
-
-	resp.TotalElements = totalCount
-	resp.Data = studentList
-
-	log.Debug("getAllStudents response : ", resp)
-	return &resp, nil
+func NewMemoryStudentRepository(format numbering.Format) *memoryRepository[models.Student] {
+	return NewNumberedMemoryRepository(studentTable, format)
 }
 
-func (s *studentRepository) DeleteStudent(id int) (*models.Student, error) {
-	var student models.Student
-
-	stmt, err := s.db.Prepare("DELETE FROM students WHERE id = ?;")
-	if err != nil {
-		log.Error(consts.QueryPrepareError, err)
-		return &student, err
-	}
-	defer func(stmt *sql.Stmt) {
-		err := stmt.Close()
-		if err != nil {
-			log.Error(consts.DBStatementCloseError, err)
-		}
-	}(stmt)
-
-	_, err = stmt.Exec(id)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return &models.Student{}, errors.New(consts.StudentNotFound)
-		}
-		log.Error(consts.DBResultsError, err)
-		return &models.Student{}, err
-	}
-
-	log.Debug("Student id : ", id)
-	return &student, nil
+func (s studentRepository) GetByNumber(ctx context.Context, number string) (*models.Student, error) {
+	return s.getBy(ctx, "registration_number", number)
 }
@@ -0,0 +1,161 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+// keyPrefix starts every key so leaked keys are easy to recognize
+const keyPrefix = "sak_"
+
+type APIKeyUsecase interface {
+	GetAll(ctx context.Context) ([]models.APIKey, error)
+	Create(ctx context.Context, req *models.APIKeyRequest) (*models.NewAPIKey, error)
+	Rotate(ctx context.Context, id int) (*models.NewAPIKey, error)
+	Revoke(ctx context.Context, id int) (*models.APIKey, error)
+	// Authenticate returns the key matching the given key, a key that is
+	// unknown or revoked is an unauthorized error
+	Authenticate(ctx context.Context, key string) (*models.APIKey, error)
+}
+
+type apiKeyUsecase struct {
+	repo repository.APIKeyRepository
+	now  func() time.Time
+}
+
+func NewAPIKey(apiKeyRepo repository.APIKeyRepository) APIKeyUsecase {
+	return &apiKeyUsecase{
+		repo: apiKeyRepo,
+		now:  time.Now,
+	}
+}
+
+func (s apiKeyUsecase) GetAll(ctx context.Context) ([]models.APIKey, error) {
+	list, err := s.repo.GetAll(ctx)
+	if err != nil {
+		log.Debug(consts.GetAPIKeyError, err)
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s apiKeyUsecase) Create(ctx context.Context, req *models.APIKeyRequest) (*models.NewAPIKey, error) {
+	key, prefix, hash, err := generate()
+	if err != nil {
+		log.Error(consts.APIKeyCreateError, err)
+		return nil, apperrors.Internal(err)
+	}
+
+	created, err := s.repo.Create(ctx, &models.APIKey{
+		Name:   req.Name,
+		Prefix: prefix,
+		Hash:   hash,
+		Scopes: req.Scopes,
+		// the databases store whole seconds
+		CreatedAt: s.now().UTC().Truncate(time.Second),
+	})
+	if err != nil {
+		log.Debug(consts.APIKeyCreateError, err)
+		return nil, err
+	}
+	return &models.NewAPIKey{APIKey: *created, Key: key}, nil
+}
+
+// Rotate replaces the key, the old key stops working at once
+func (s apiKeyUsecase) Rotate(ctx context.Context, id int) (*models.NewAPIKey, error) {
+	existing, err := s.repo.Get(ctx, id)
+	if err != nil {
+		log.Debug(consts.GetAPIKeyError, err)
+		return nil, err
+	}
+	if existing.Revoked() {
+		return nil, apperrors.Conflict(consts.APIKeyRevokedError, nil)
+	}
+
+	key, prefix, hash, err := generate()
+	if err != nil {
+		log.Error(consts.APIKeyCreateError, err)
+		return nil, apperrors.Internal(err)
+	}
+
+	rotated, err := s.repo.Rotate(ctx, id, prefix, hash)
+	if err != nil {
+		log.Debug(consts.APIKeyCreateError, err)
+		return nil, err
+	}
+	return &models.NewAPIKey{APIKey: *rotated, Key: key}, nil
+}
+
+func (s apiKeyUsecase) Revoke(ctx context.Context, id int) (*models.APIKey, error) {
+	revoked, err := s.repo.Revoke(ctx, id, s.now().UTC().Truncate(time.Second))
+	if err != nil {
+		log.Debug(consts.GetAPIKeyError, err)
+		return nil, err
+	}
+	return revoked, nil
+}
+
+func (s apiKeyUsecase) Authenticate(ctx context.Context, key string) (*models.APIKey, error) {
+	prefix, ok := parse(key)
+	if !ok {
+		return nil, apperrors.Unauthorized(consts.InvalidAPIKeyError, nil)
+	}
+
+	stored, err := s.repo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		if apperrors.CodeOf(err) == apperrors.CodeNotFound {
+			return nil, apperrors.Unauthorized(consts.InvalidAPIKeyError, err)
+		}
+		log.Debug(consts.GetAPIKeyError, err)
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashKey(key)), []byte(stored.Hash)) != 1 || stored.Revoked() {
+		return nil, apperrors.Unauthorized(consts.InvalidAPIKeyError, nil)
+	}
+	return stored, nil
+}
+
+// generate returns a new key of the form sak_{prefix}.{secret} together with
+// its prefix and hash. The secret is random enough for a plain SHA-256 hash
+func generate() (string, string, string, error) {
+	b := make([]byte, 40)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	prefix := hex.EncodeToString(b[:8])
+	key := keyPrefix + prefix + "." + base64.RawURLEncoding.EncodeToString(b[8:])
+	return key, prefix, hashKey(key), nil
+}
+
+// parse returns the prefix of a key
+func parse(key string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, keyPrefix)
+	if !ok {
+		return "", false
+	}
+	prefix, secret, ok := strings.Cut(rest, ".")
+	if !ok || prefix == "" || secret == "" {
+		return "", false
+	}
+	return prefix, true
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
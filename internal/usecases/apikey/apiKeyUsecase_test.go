@@ -0,0 +1,143 @@
+package apikey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/mocks"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+var now = time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+
+func newTestUsecase(repo *mocks.MockAPIKeyRepository) *apiKeyUsecase {
+	return &apiKeyUsecase{
+		repo: repo,
+		now:  func() time.Time { return now },
+	}
+}
+
+func TestAPIKeyUsecase_CreateAndAuthenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var stored models.APIKey
+	repo := mocks.NewMockAPIKeyRepository(ctrl)
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, key *models.APIKey) (*models.APIKey, error) {
+			stored = *key
+			stored.ID = 1
+			return &stored, nil
+		})
+
+	usecase := newTestUsecase(repo)
+	created, err := usecase.Create(context.Background(),
+		&models.APIKeyRequest{Name: "batch", Scopes: []string{"student:read"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Key == "" || stored.Hash == "" || stored.Hash == created.Key || !stored.CreatedAt.Equal(now) {
+		log.Info("Expected a new key with its hash stored, Got : %v, %v ", created, stored)
+		t.Fail()
+	}
+
+	repo.EXPECT().GetByPrefix(gomock.Any(), stored.Prefix).Return(&stored, nil)
+
+	actual, err := usecase.Authenticate(context.Background(), created.Key)
+	if err != nil || actual.ID != 1 {
+		log.Info("Expected : %v, Got : %v, %v ", stored, actual, err)
+		t.Fail()
+	}
+}
+
+func TestAPIKeyUsecase_Authenticate_ErrorPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	key, prefix, hash, err := generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	revokedAt := now
+
+	type test struct {
+		name   string
+		key    string
+		stored *models.APIKey
+		err    error
+	}
+
+	tests := []test{
+		{
+			name: "Malformed Key",
+			key:  "not-a-key",
+		},
+		{
+			name: "Unknown Prefix",
+			key:  key,
+			err:  apperrors.NotFound(consts.APIKeyNotFound),
+		},
+		{
+			name:   "Wrong Secret",
+			key:    key + "x",
+			stored: &models.APIKey{ID: 1, Prefix: prefix, Hash: hash},
+		},
+		{
+			name:   "Revoked Key",
+			key:    key,
+			stored: &models.APIKey{ID: 1, Prefix: prefix, Hash: hash, RevokedAt: &revokedAt},
+		},
+	}
+
+	for _, test := range tests {
+		repo := mocks.NewMockAPIKeyRepository(ctrl)
+		if test.stored != nil || test.err != nil {
+			repo.EXPECT().GetByPrefix(gomock.Any(), prefix).Return(test.stored, test.err)
+		}
+
+		_, err := newTestUsecase(repo).Authenticate(context.Background(), test.key)
+		if apperrors.CodeOf(err) != apperrors.CodeUnauthorized {
+			log.Info("Test %s : Expected an unauthorized error, Got : %v ", test.name, err)
+			t.Fail()
+		}
+	}
+}
+
+func TestAPIKeyUsecase_Rotate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	existing := &models.APIKey{ID: 1, Name: "batch", Prefix: "old", Hash: "old"}
+	repo := mocks.NewMockAPIKeyRepository(ctrl)
+	repo.EXPECT().Get(gomock.Any(), 1).Return(existing, nil)
+	repo.EXPECT().Rotate(gomock.Any(), 1, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id int, prefix string, hash string) (*models.APIKey, error) {
+			return &models.APIKey{ID: id, Name: "batch", Prefix: prefix, Hash: hash}, nil
+		})
+
+	rotated, err := newTestUsecase(repo).Rotate(context.Background(), 1)
+	if err != nil || rotated.Prefix == "old" || hashKey(rotated.Key) != rotated.Hash {
+		log.Info("Expected a new key, Got : %v, %v ", rotated, err)
+		t.Fail()
+	}
+}
+
+func TestAPIKeyUsecase_Rotate_Revoked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	revokedAt := now
+	repo := mocks.NewMockAPIKeyRepository(ctrl)
+	repo.EXPECT().Get(gomock.Any(), 1).Return(&models.APIKey{ID: 1, RevokedAt: &revokedAt}, nil)
+
+	_, err := newTestUsecase(repo).Rotate(context.Background(), 1)
+	if apperrors.CodeOf(err) != apperrors.CodeConflict {
+		log.Info("Expected a conflict, Got : %v ", err)
+		t.Fail()
+	}
+}
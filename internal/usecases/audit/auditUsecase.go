@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+type AuditUsecase interface {
+	// List returns a page of the audit events of the entity, newest first.
+	// An empty entity lists the events of every entity and an entityID of 0
+	// the events of every record of the entity
+	List(ctx context.Context, entity string, entityID int,
+		pagination models.Pagination) (*models.SearchData[models.AuditEvent], error)
+}
+
+type auditUsecase struct {
+	repo repository.AuditRepository
+}
+
+func NewAudit(auditRepo repository.AuditRepository) AuditUsecase {
+	return &auditUsecase{
+		repo: auditRepo,
+	}
+}
+
+func (s auditUsecase) List(ctx context.Context, entity string, entityID int,
+	pagination models.Pagination) (*models.SearchData[models.AuditEvent], error) {
+
+	if entityID != 0 && entity == "" {
+		return nil, apperrors.Validation(consts.AuditEntityError, nil)
+	}
+	if pagination.PageSize == 0 {
+		pagination.PageSize = models.DefaultPageSize
+	}
+
+	events, err := s.repo.List(ctx, entity, entityID, pagination)
+	if err != nil {
+		log.Debug(consts.GetAuditError, err)
+		return nil, err
+	}
+	return events, nil
+}
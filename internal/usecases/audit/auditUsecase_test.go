@@ -0,0 +1,49 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/mocks"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/tryfix/log"
+)
+
+func TestAuditUsecase_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	events := &models.SearchData[models.AuditEvent]{TotalElements: 1,
+		Data: []models.AuditEvent{{ID: 1, Actor: "admin-1", Entity: "student", EntityID: 7}}}
+	repo := mocks.NewMockAuditRepository(ctrl)
+	repo.EXPECT().List(gomock.Any(), "student", 7, models.Pagination{Page: 0, PageSize: models.DefaultPageSize}).
+		Return(events, nil)
+	repo.EXPECT().List(gomock.Any(), "", 0, models.Pagination{Page: 2, PageSize: 5}).Return(events, nil)
+
+	usecase := NewAudit(repo)
+
+	actual, err := usecase.List(context.Background(), "student", 7, models.Pagination{})
+	if err != nil || actual != events {
+		log.Info("Expected : %v, Got : %v, %v ", events, actual, err)
+		t.Fail()
+	}
+
+	actual, err = usecase.List(context.Background(), "", 0, models.Pagination{Page: 2, PageSize: 5})
+	if err != nil || actual != events {
+		log.Info("Expected : %v, Got : %v, %v ", events, actual, err)
+		t.Fail()
+	}
+}
+
+func TestAuditUsecase_List_IDWithoutEntity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, err := NewAudit(mocks.NewMockAuditRepository(ctrl)).List(context.Background(), "", 7, models.Pagination{})
+	if apperrors.CodeOf(err) != apperrors.CodeValidation {
+		log.Info("Expected a validation error, Got : %v ", err)
+		t.Fail()
+	}
+}
@@ -0,0 +1,47 @@
+package course
+
+import (
+	"context"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/usecases/crud"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+type CourseUsecase interface {
+	crud.Usecase[models.Course]
+	// GetByLecturer returns the courses the lecturer teaches, a missing
+	// lecturer is a not found error
+	GetByLecturer(ctx context.Context, lecturerID int) ([]models.Course, error)
+}
+
+type courseUsecase struct {
+	crud.Usecase[models.Course]
+	courseRepo   repository.CourseRepository
+	lecturerRepo repository.LecturerRepository
+}
+
+func NewCourse(courseRepo repository.CourseRepository, lecturerRepo repository.LecturerRepository) CourseUsecase {
+	return &courseUsecase{
+		Usecase:      crud.NewUsecase[models.Course](courseRepo, models.CourseResource),
+		courseRepo:   courseRepo,
+		lecturerRepo: lecturerRepo,
+	}
+}
+
+func (s courseUsecase) GetByLecturer(ctx context.Context, lecturerID int) ([]models.Course, error) {
+	_, err := s.lecturerRepo.Get(ctx, lecturerID)
+	if err != nil {
+		log.Debug(consts.GetLecturersError, err)
+		return nil, err
+	}
+
+	list, err := s.courseRepo.GetByLecturer(ctx, lecturerID)
+	if err != nil {
+		log.Debug(consts.GetCoursesError, err)
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,66 @@
+package course
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/mocks"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+var (
+	lecturer1 = models.Lecturer{
+		ID:        1,
+		FirstName: "Adrian",
+		LastName:  "Newey",
+		Year:      30,
+	}
+	c1 = models.Course{
+		ID:         1,
+		Code:       "AE101",
+		Title:      "Aerodynamics",
+		Credits:    3,
+		Semester:   "2023-S1",
+		LecturerID: 1,
+	}
+)
+
+func TestCourseUsecase_GetByLecturer_HappyPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lecturerRepo := mocks.NewMockLecturerRepository(ctrl)
+	lecturerRepo.EXPECT().Get(gomock.Any(), 1).Return(&lecturer1, nil)
+	courseRepo := mocks.NewMockCourseRepository(ctrl)
+	courseRepo.EXPECT().GetByLecturer(gomock.Any(), 1).Return([]models.Course{c1}, nil)
+
+	course := NewCourse(courseRepo, lecturerRepo)
+
+	actual, err := course.GetByLecturer(context.Background(), 1)
+	if err != nil || !reflect.DeepEqual(actual, []models.Course{c1}) {
+		log.Info("Expected : %v, Got : %v, %v ", []models.Course{c1}, actual, err)
+		t.Fail()
+	}
+}
+
+func TestCourseUsecase_GetByLecturer_MissingLecturer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lecturerRepo := mocks.NewMockLecturerRepository(ctrl)
+	lecturerRepo.EXPECT().Get(gomock.Any(), 2).Return(&models.Lecturer{}, apperrors.NotFound(consts.LecturerNotFound))
+	courseRepo := mocks.NewMockCourseRepository(ctrl)
+
+	course := NewCourse(courseRepo, lecturerRepo)
+
+	_, err := course.GetByLecturer(context.Background(), 2)
+	if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+		log.Info("Expected a not found error, Got : %v ", err)
+		t.Fail()
+	}
+}
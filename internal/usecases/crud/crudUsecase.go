@@ -0,0 +1,148 @@
+package crud
+
+import (
+	"context"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/filter"
+	"github.com/tryfix/log"
+)
+
+type Usecase[T any] interface {
+	GetAll(ctx context.Context, expr filter.Expr) ([]T, error)
+	Get(ctx context.Context, id int) (*T, error)
+	Create(ctx context.Context, entity *T) (*T, error)
+	Update(ctx context.Context, entity *T) (*T, error)
+	Search(ctx context.Context, searchString string, expr filter.Expr, pagination models.Pagination,
+		sortBy models.SortBy) (*models.SearchData[T], error)
+	Delete(ctx context.Context, id int) (*T, error)
+}
+
+type crudUsecase[T any] struct {
+	repo     repository.Repository[T]
+	resource models.Resource
+}
+
+func NewUsecase[T any](repo repository.Repository[T], resource models.Resource) Usecase[T] {
+	return &crudUsecase[T]{
+		repo:     repo,
+		resource: resource,
+	}
+}
+
+func (s crudUsecase[T]) GetAll(ctx context.Context, expr filter.Expr) ([]T, error) {
+	list, err := s.repo.GetAll(ctx, expr)
+	if err != nil {
+		log.Debug(s.resource.GetError, err)
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s crudUsecase[T]) Get(ctx context.Context, id int) (*T, error) {
+	entity, err := s.repo.Get(ctx, id)
+	if err != nil {
+		log.Debug(s.resource.GetError, err)
+		return new(T), err
+	}
+	return entity, nil
+}
+
+func (s crudUsecase[T]) Create(ctx context.Context, entity *T) (*T, error) {
+	created, err := s.repo.Create(ctx, entity)
+	if err != nil {
+		log.Debug(s.resource.CreateError, err)
+		return new(T), err
+	}
+	return created, nil
+}
+
+func (s crudUsecase[T]) Update(ctx context.Context, entity *T) (*T, error) {
+	updated, err := s.repo.Update(ctx, entity)
+	if err != nil {
+		log.Debug(s.resource.UpdateError, err)
+		return new(T), err
+	}
+	return updated, nil
+}
+
+func (s crudUsecase[T]) Search(ctx context.Context, searchString string, expr filter.Expr,
+	pagination models.Pagination, sortBy models.SortBy) (*models.SearchData[T], error) {
+	if pagination.PageSize == 0 {
+		pagination.PageSize = models.DefaultPageSize
+	}
+
+	list, err := s.repo.Search(ctx, searchString, expr, pagination, sortBy)
+	if err != nil {
+		log.Debug(s.resource.GetError, err)
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s crudUsecase[T]) Delete(ctx context.Context, id int) (*T, error) {
+	entity, err := s.repo.Delete(ctx, id)
+	if err != nil {
+		log.Debug(s.resource.DeleteError, err)
+		return new(T), err
+	}
+	return entity, nil
+}
+
+// SoftDeleteUsecase is a Usecase of an entity that is soft deleted, the
+// deleted records can be restored
+type SoftDeleteUsecase[T any] interface {
+	Usecase[T]
+	Restore(ctx context.Context, id int) (*T, error)
+}
+
+type softDeleteUsecase[T any] struct {
+	Usecase[T]
+	repo     repository.SoftDeleteRepository[T]
+	resource models.Resource
+}
+
+func NewSoftDeleteUsecase[T any](repo repository.SoftDeleteRepository[T],
+	resource models.Resource) SoftDeleteUsecase[T] {
+	return &softDeleteUsecase[T]{
+		Usecase:  NewUsecase[T](repo, resource),
+		repo:     repo,
+		resource: resource,
+	}
+}
+
+func (s softDeleteUsecase[T]) Restore(ctx context.Context, id int) (*T, error) {
+	entity, err := s.repo.Restore(ctx, id)
+	if err != nil {
+		log.Debug(s.resource.RestoreError, err)
+		return new(T), err
+	}
+	return entity, nil
+}
+
+// Patcher patches the records of an entity, see repository.Patcher
+type Patcher[T any] interface {
+	Patch(ctx context.Context, id int, patch func(entity *T) error) (*T, error)
+}
+
+type patcher[T any] struct {
+	repo     repository.Patcher[T]
+	resource models.Resource
+}
+
+func NewPatcher[T any](repo repository.Patcher[T], resource models.Resource) Patcher[T] {
+	return &patcher[T]{
+		repo:     repo,
+		resource: resource,
+	}
+}
+
+func (s patcher[T]) Patch(ctx context.Context, id int, patch func(entity *T) error) (*T, error) {
+	entity, err := s.repo.Patch(ctx, id, patch)
+	if err != nil {
+		log.Debug(s.resource.PatchError, err)
+		return new(T), err
+	}
+	return entity, nil
+}
@@ -0,0 +1,76 @@
+package department
+
+import (
+	"context"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/usecases/crud"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+type DepartmentUsecase interface {
+	// Update requires the head, when there is one, to be a lecturer of the
+	// department, the repository checks it in the transaction storing the
+	// department. Create rejects a head, a new department has no lecturers
+	// yet so its head is appointed by an update once they are assigned
+	crud.Usecase[models.Department]
+	// GetLecturers returns the lecturers of the department, a missing
+	// department is a not found error
+	GetLecturers(ctx context.Context, departmentID int) ([]models.Lecturer, error)
+	// GetStaff returns the staff of the department, a missing department is
+	// a not found error
+	GetStaff(ctx context.Context, departmentID int) ([]models.Staff, error)
+}
+
+type departmentUsecase struct {
+	crud.Usecase[models.Department]
+	departmentRepo repository.DepartmentRepository
+}
+
+func NewDepartment(departmentRepo repository.DepartmentRepository) DepartmentUsecase {
+	return &departmentUsecase{
+		Usecase:        crud.NewUsecase[models.Department](departmentRepo, models.DepartmentResource),
+		departmentRepo: departmentRepo,
+	}
+}
+
+func (s departmentUsecase) Create(ctx context.Context, department *models.Department) (*models.Department, error) {
+	if department.HeadID != nil {
+		return new(models.Department), apperrors.InvalidFields(consts.InvalidRequestBody,
+			[]apperrors.FieldError{{Field: "headId", Message: consts.HeadOnCreateError}})
+	}
+	return s.Usecase.Create(ctx, department)
+}
+
+func (s departmentUsecase) GetLecturers(ctx context.Context, departmentID int) ([]models.Lecturer, error) {
+	_, err := s.departmentRepo.Get(ctx, departmentID)
+	if err != nil {
+		log.Debug(consts.GetDepartmentsError, err)
+		return nil, err
+	}
+
+	list, err := s.departmentRepo.GetLecturers(ctx, departmentID)
+	if err != nil {
+		log.Debug(consts.GetLecturersError, err)
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s departmentUsecase) GetStaff(ctx context.Context, departmentID int) ([]models.Staff, error) {
+	_, err := s.departmentRepo.Get(ctx, departmentID)
+	if err != nil {
+		log.Debug(consts.GetDepartmentsError, err)
+		return nil, err
+	}
+
+	list, err := s.departmentRepo.GetStaff(ctx, departmentID)
+	if err != nil {
+		log.Debug(consts.GetStaffError, err)
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,115 @@
+package department
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/mocks"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+var (
+	aeroID = 1
+	newey  = models.Lecturer{
+		ID:           1,
+		FirstName:    "Adrian",
+		LastName:     "Newey",
+		Year:         30,
+		DepartmentID: &aeroID,
+	}
+	headID = 1
+	aero   = models.Department{
+		ID:     1,
+		Name:   "Aerodynamics",
+		HeadID: &headID,
+	}
+)
+
+func TestDepartmentUsecase_Update_HappyPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	departmentRepo := mocks.NewMockDepartmentRepository(ctrl)
+	departmentRepo.EXPECT().Update(gomock.Any(), &aero).Return(&aero, nil)
+
+	department := NewDepartment(departmentRepo)
+
+	actual, err := department.Update(context.Background(), &aero)
+	if err != nil || actual != &aero {
+		log.Info("Expected : %v, Got : %v, %v ", aero, actual, err)
+		t.Fail()
+	}
+}
+
+func TestDepartmentUsecase_Create_WithoutHead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	created := models.Department{Name: "Chassis"}
+	departmentRepo := mocks.NewMockDepartmentRepository(ctrl)
+	departmentRepo.EXPECT().Create(gomock.Any(), &created).Return(&created, nil)
+
+	department := NewDepartment(departmentRepo)
+
+	_, err := department.Create(context.Background(), &created)
+	if err != nil {
+		log.Info("Expected no error, Got : %v ", err)
+		t.Fail()
+	}
+}
+
+func TestDepartmentUsecase_Create_WithHead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	created := models.Department{Name: "Chassis", HeadID: &headID}
+	departmentRepo := mocks.NewMockDepartmentRepository(ctrl)
+
+	department := NewDepartment(departmentRepo)
+
+	_, err := department.Create(context.Background(), &created)
+	expected := []apperrors.FieldError{{Field: "headId", Message: consts.HeadOnCreateError}}
+	if apperrors.CodeOf(err) != apperrors.CodeValidation || !reflect.DeepEqual(apperrors.FieldsOf(err), expected) {
+		log.Info("Expected : %v, Got : %v ", expected, err)
+		t.Fail()
+	}
+}
+
+func TestDepartmentUsecase_GetLecturers_HappyPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	departmentRepo := mocks.NewMockDepartmentRepository(ctrl)
+	departmentRepo.EXPECT().Get(gomock.Any(), 1).Return(&aero, nil)
+	departmentRepo.EXPECT().GetLecturers(gomock.Any(), 1).Return([]models.Lecturer{newey}, nil)
+
+	department := NewDepartment(departmentRepo)
+
+	actual, err := department.GetLecturers(context.Background(), 1)
+	if err != nil || !reflect.DeepEqual(actual, []models.Lecturer{newey}) {
+		log.Info("Expected : %v, Got : %v, %v ", []models.Lecturer{newey}, actual, err)
+		t.Fail()
+	}
+}
+
+func TestDepartmentUsecase_GetStaff_MissingDepartment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	departmentRepo := mocks.NewMockDepartmentRepository(ctrl)
+	departmentRepo.EXPECT().Get(gomock.Any(), 2).
+		Return(&models.Department{}, apperrors.NotFound(consts.DepartmentNotFound))
+
+	department := NewDepartment(departmentRepo)
+
+	_, err := department.GetStaff(context.Background(), 2)
+	if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+		log.Info("Expected a not found error, Got : %v ", err)
+		t.Fail()
+	}
+}
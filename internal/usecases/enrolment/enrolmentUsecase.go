@@ -0,0 +1,94 @@
+package enrolment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+type EnrolmentUsecase interface {
+	// GetByStudent returns the enrolments of the student, a missing student
+	// is a not found error
+	GetByStudent(ctx context.Context, studentID int) ([]models.Enrolment, error)
+	// Enrol enrols the student in the course. Enrolling twice or in a full
+	// course is a conflict and a student below the year of the course is
+	// unprocessable
+	Enrol(ctx context.Context, studentID int, courseID int) (*models.Enrolment, error)
+	Withdraw(ctx context.Context, studentID int, courseID int) (*models.Enrolment, error)
+}
+
+type enrolmentUsecase struct {
+	enrolmentRepo repository.EnrolmentRepository
+	studentRepo   repository.StudentRepository
+	now           func() time.Time
+}
+
+func NewEnrolment(enrolmentRepo repository.EnrolmentRepository,
+	studentRepo repository.StudentRepository) EnrolmentUsecase {
+	return &enrolmentUsecase{
+		enrolmentRepo: enrolmentRepo,
+		studentRepo:   studentRepo,
+		now:           time.Now,
+	}
+}
+
+func (s enrolmentUsecase) GetByStudent(ctx context.Context, studentID int) ([]models.Enrolment, error) {
+	_, err := s.studentRepo.Get(ctx, studentID)
+	if err != nil {
+		log.Debug(consts.GetStudentsError, err)
+		return nil, err
+	}
+
+	list, err := s.enrolmentRepo.GetByStudent(ctx, studentID)
+	if err != nil {
+		log.Debug(consts.GetEnrolmentsError, err)
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s enrolmentUsecase) Enrol(ctx context.Context, studentID int, courseID int) (*models.Enrolment, error) {
+	enrolment := &models.Enrolment{
+		StudentID: studentID,
+		CourseID:  courseID,
+		// the databases store whole seconds
+		EnrolledAt: s.now().UTC().Truncate(time.Second),
+	}
+
+	enrolled, err := s.enrolmentRepo.Enrol(ctx, enrolment, checkEnrolment)
+	if err != nil {
+		log.Debug(consts.EnrolError, err)
+		return nil, err
+	}
+	return enrolled, nil
+}
+
+func (s enrolmentUsecase) Withdraw(ctx context.Context, studentID int, courseID int) (*models.Enrolment, error) {
+	withdrawn, err := s.enrolmentRepo.Withdraw(ctx, studentID, courseID)
+	if err != nil {
+		log.Debug(consts.WithdrawError, err)
+		return nil, err
+	}
+	return withdrawn, nil
+}
+
+// checkEnrolment holds the enrolment rules, the repository runs it in the
+// transaction that adds the enrolment
+func checkEnrolment(student *models.Student, course *models.Course, enrolled int, alreadyEnrolled bool) error {
+	if alreadyEnrolled {
+		return apperrors.Conflict(consts.AlreadyEnrolledError, nil)
+	}
+	if student.Year < course.MinYear {
+		return apperrors.Unprocessable(fmt.Sprintf(consts.YearRequirementError, course.MinYear))
+	}
+	if course.Capacity > 0 && enrolled >= course.Capacity {
+		return apperrors.Conflict(consts.CourseFullError, nil)
+	}
+	return nil
+}
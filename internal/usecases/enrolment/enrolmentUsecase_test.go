@@ -0,0 +1,89 @@
+package enrolment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	"github.com/shashaneRanasinghe/simpleAPI/mocks"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+var now = time.Date(2023, 9, 1, 9, 0, 0, 0, time.UTC)
+
+func TestCheckEnrolment(t *testing.T) {
+	course := &models.Course{ID: 1, Capacity: 2, MinYear: 2}
+
+	testCases := []struct {
+		name     string
+		student  *models.Student
+		course   *models.Course
+		enrolled int
+		already  bool
+		expected apperrors.Code
+	}{
+		{name: "Allowed", student: &models.Student{Year: 2}, course: course, enrolled: 1},
+		{name: "Already Enrolled", student: &models.Student{Year: 2}, course: course, enrolled: 1, already: true,
+			expected: apperrors.CodeConflict},
+		{name: "Below The Year", student: &models.Student{Year: 1}, course: course,
+			expected: apperrors.CodeUnprocessable},
+		{name: "Course Full", student: &models.Student{Year: 3}, course: course, enrolled: 2,
+			expected: apperrors.CodeConflict},
+		{name: "No Capacity Limit", student: &models.Student{Year: 1}, course: &models.Course{ID: 2},
+			enrolled: 500},
+	}
+
+	for _, test := range testCases {
+		err := checkEnrolment(test.student, test.course, test.enrolled, test.already)
+		if err == nil && test.expected != "" || err != nil && apperrors.CodeOf(err) != test.expected {
+			t.Errorf("Test %s : Expected %q, but got %v", test.name, test.expected, err)
+		}
+	}
+}
+
+func TestEnrolmentUsecase_Enrol(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expected := models.Enrolment{StudentID: 1, CourseID: 2, EnrolledAt: now}
+
+	enrolmentRepo := mocks.NewMockEnrolmentRepository(ctrl)
+	enrolmentRepo.EXPECT().Enrol(gomock.Any(), &expected, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, enrolment *models.Enrolment, check repository.EnrolmentCheck) (*models.Enrolment, error) {
+			err := check(&models.Student{ID: 1, Year: 1}, &models.Course{ID: 2, MinYear: 3}, 0, false)
+			return nil, err
+		})
+
+	enrolment := &enrolmentUsecase{
+		enrolmentRepo: enrolmentRepo,
+		now:           func() time.Time { return now.Add(300 * time.Millisecond) },
+	}
+
+	_, err := enrolment.Enrol(context.Background(), 1, 2)
+	if apperrors.CodeOf(err) != apperrors.CodeUnprocessable ||
+		apperrors.MessageOf(err, "") != "The Course Is Open To Students In Year 3 Or Later" {
+		log.Info("Expected the year requirement error, Got : %v ", err)
+		t.Fail()
+	}
+}
+
+func TestEnrolmentUsecase_GetByStudent_MissingStudent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	studentRepo := mocks.NewMockStudentRepository(ctrl)
+	studentRepo.EXPECT().Get(gomock.Any(), 1).Return(&models.Student{}, apperrors.NotFound(consts.StudentNotFound))
+
+	enrolment := NewEnrolment(mocks.NewMockEnrolmentRepository(ctrl), studentRepo)
+
+	_, err := enrolment.GetByStudent(context.Background(), 1)
+	if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+		log.Info("Expected a not found error, Got : %v ", err)
+		t.Fail()
+	}
+}
@@ -0,0 +1,210 @@
+package grade
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+type GradeUsecase interface {
+	// GetByStudent returns the grades of the student, a missing student is a
+	// not found error
+	GetByStudent(ctx context.Context, studentID int) ([]models.Grade, error)
+	// Record records the grade of the student for a course. The grading
+	// lecturer, see grader, must teach the course and the student must be
+	// enrolled in it, grading a course twice is a conflict. lecturerID names
+	// the grading lecturer, only admins may give it
+	Record(ctx context.Context, studentID int, req *models.GradeRequest, lecturerID *int) (*models.Grade, error)
+	// Update replaces the recorded grade of the student for the course like
+	// Record, a missing grade is a not found error
+	Update(ctx context.Context, studentID int, courseID int, change *models.GradeChange,
+		lecturerID *int) (*models.Grade, error)
+	// Transcript returns the grades of the student grouped by semester with
+	// the GPA of every semester and the overall GPA
+	Transcript(ctx context.Context, studentID int) (*models.Transcript, error)
+}
+
+type gradeUsecase struct {
+	gradeRepo   repository.GradeRepository
+	studentRepo repository.StudentRepository
+	now         func() time.Time
+}
+
+func NewGrade(gradeRepo repository.GradeRepository, studentRepo repository.StudentRepository) GradeUsecase {
+	return &gradeUsecase{
+		gradeRepo:   gradeRepo,
+		studentRepo: studentRepo,
+		now:         time.Now,
+	}
+}
+
+func (s gradeUsecase) GetByStudent(ctx context.Context, studentID int) ([]models.Grade, error) {
+	_, err := s.studentRepo.Get(ctx, studentID)
+	if err != nil {
+		log.Debug(consts.GetStudentsError, err)
+		return nil, err
+	}
+
+	list, err := s.gradeRepo.GetByStudent(ctx, studentID)
+	if err != nil {
+		log.Debug(consts.GetGradesError, err)
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s gradeUsecase) Record(ctx context.Context, studentID int, req *models.GradeRequest,
+	lecturerID *int) (*models.Grade, error) {
+	return s.save(ctx, studentID, req.CourseID, &req.GradeChange, lecturerID, func(existing *models.Grade) error {
+		if existing != nil {
+			return apperrors.Conflict(consts.AlreadyGradedError, nil)
+		}
+		return nil
+	})
+}
+
+func (s gradeUsecase) Update(ctx context.Context, studentID int, courseID int,
+	change *models.GradeChange, lecturerID *int) (*models.Grade, error) {
+	return s.save(ctx, studentID, courseID, change, lecturerID, func(existing *models.Grade) error {
+		if existing == nil {
+			return apperrors.NotFound(consts.GradeNotFound)
+		}
+		return nil
+	})
+}
+
+// save saves the grade when the grading lecturer teaches the course, the
+// student is enrolled in it and checkExisting accepts the grade already
+// recorded
+func (s gradeUsecase) save(ctx context.Context, studentID int, courseID int, change *models.GradeChange,
+	lecturerID *int, checkExisting func(existing *models.Grade) error) (*models.Grade, error) {
+
+	lecturerID, fromToken, err := grader(ctx, lecturerID)
+	if err != nil {
+		log.Debug(consts.GradeRecordError, err)
+		return nil, err
+	}
+
+	grade := &models.Grade{
+		StudentID: studentID,
+		CourseID:  courseID,
+		Grade:     change.Grade,
+		// the databases store whole seconds
+		RecordedAt: s.now().UTC().Truncate(time.Second),
+	}
+
+	saved, err := s.gradeRepo.Save(ctx, grade, func(course *models.Course, enrolled bool,
+		existing *models.Grade) error {
+		err := checkExisting(existing)
+		if err != nil {
+			return err
+		}
+
+		grade.LecturerID = course.LecturerID
+		if lecturerID != nil {
+			grade.LecturerID = *lecturerID
+		}
+		if course.LecturerID != grade.LecturerID {
+			// a lecturer grading another lecturer's course is not
+			// allowed to, a lecturer named by an admin is only wrong
+			if fromToken {
+				return apperrors.Forbidden(consts.NotCourseLecturerError)
+			}
+			return apperrors.Unprocessable(consts.NotCourseLecturerError)
+		}
+		if !enrolled {
+			return apperrors.Unprocessable(consts.NotEnrolledError)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Debug(consts.GradeRecordError, err)
+		return nil, err
+	}
+	return saved, nil
+}
+
+// grader returns the lecturer recording a grade, nil for the lecturer of
+// the course, and whether it is the lecturer of the caller's token. A
+// caller with the lecturer role grades as the lecturer whose id is the
+// subject of its token. Admins, and every caller when authentication is
+// disabled, may name another lecturer, the other callers, eg. API keys,
+// grade as the lecturer of the course
+func grader(ctx context.Context, named *int) (*int, bool, error) {
+	claims, ok := auth.ClaimsFrom(ctx)
+	if !ok || claims.HasRole(auth.RoleAdmin) {
+		return named, false, nil
+	}
+	if named != nil {
+		return nil, false, apperrors.Forbidden(consts.GraderError)
+	}
+	if !claims.HasRole(auth.RoleLecturer) {
+		return nil, false, nil
+	}
+
+	id, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return nil, false, apperrors.Forbidden(consts.ForbiddenError)
+	}
+	return &id, true, nil
+}
+
+func (s gradeUsecase) Transcript(ctx context.Context, studentID int) (*models.Transcript, error) {
+	student, err := s.studentRepo.Get(ctx, studentID)
+	if err != nil {
+		log.Debug(consts.GetStudentsError, err)
+		return nil, err
+	}
+
+	lines, err := s.gradeRepo.GetTranscript(ctx, studentID)
+	if err != nil {
+		log.Debug(consts.GetTranscriptError, err)
+		return nil, err
+	}
+
+	transcript := &models.Transcript{Student: *student, Semesters: []models.SemesterResult{}}
+	var points float64
+	for _, line := range lines {
+		line.Points = models.GradePoints[line.Grade]
+
+		// the lines are ordered by semester
+		last := len(transcript.Semesters) - 1
+		if last < 0 || transcript.Semesters[last].Semester != line.Semester {
+			transcript.Semesters = append(transcript.Semesters, models.SemesterResult{Semester: line.Semester})
+			last++
+		}
+		transcript.Semesters[last].Courses = append(transcript.Semesters[last].Courses, line)
+
+		transcript.Credits += line.Credits
+		points += line.Points * float64(line.Credits)
+	}
+
+	for i := range transcript.Semesters {
+		semester := &transcript.Semesters[i]
+		var semesterPoints float64
+		for _, line := range semester.Courses {
+			semester.Credits += line.Credits
+			semesterPoints += line.Points * float64(line.Credits)
+		}
+		semester.GPA = gpa(semesterPoints, semester.Credits)
+	}
+	transcript.GPA = gpa(points, transcript.Credits)
+	return transcript, nil
+}
+
+// gpa returns the credit weighted grade point average rounded to two
+// decimals, no credits give a GPA of 0
+func gpa(points float64, credits int) float64 {
+	if credits == 0 {
+		return 0
+	}
+	return math.Round(points/float64(credits)*100) / 100
+}
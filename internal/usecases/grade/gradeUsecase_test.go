@@ -0,0 +1,191 @@
+package grade
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	"github.com/shashaneRanasinghe/simpleAPI/mocks"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/apperrors"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+var now = time.Date(2024, 1, 20, 9, 0, 0, 0, time.UTC)
+
+var student1 = models.Student{ID: 1, FirstName: "Charles", LastName: "Leclerc", Year: 3}
+
+func TestGradeUsecase_Record(t *testing.T) {
+	course := &models.Course{ID: 2, LecturerID: 5}
+	recorded := &models.Grade{StudentID: 1, CourseID: 2, LecturerID: 5, Grade: "B", RecordedAt: now}
+	lecturer := func(id string) *auth.Claims {
+		return &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: id}, Roles: []string{"lecturer"}}
+	}
+	admin := &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "root"}, Roles: []string{"admin"}}
+	six := 6
+
+	testCases := []struct {
+		name       string
+		claims     *auth.Claims
+		named      *int
+		enrolled   bool
+		existing   *models.Grade
+		expected   apperrors.Code
+		expectedBy int
+	}{
+		{name: "Lecturer Of The Course", claims: lecturer("5"), enrolled: true, expectedBy: 5},
+		{name: "Already Graded", claims: lecturer("5"), enrolled: true, existing: recorded,
+			expected: apperrors.CodeConflict},
+		{name: "Lecturer Of Another Course", claims: lecturer("6"), enrolled: true,
+			expected: apperrors.CodeForbidden},
+		{name: "Not Enrolled", claims: lecturer("5"), expected: apperrors.CodeUnprocessable},
+		{name: "Admin Grades As The Course Lecturer", claims: admin, enrolled: true, expectedBy: 5},
+		{name: "Admin Names A Lecturer Of Another Course", claims: admin, named: &six, enrolled: true,
+			expected: apperrors.CodeUnprocessable},
+		{name: "API Key Grades As The Course Lecturer", claims: &auth.Claims{Scopes: []string{"grade:write"}},
+			enrolled: true, expectedBy: 5},
+		{name: "Authentication Disabled", enrolled: true, expectedBy: 5},
+	}
+
+	for _, test := range testCases {
+		ctrl := gomock.NewController(t)
+
+		gradeRepo := mocks.NewMockGradeRepository(ctrl)
+		gradeRepo.EXPECT().Save(gomock.Any(), &models.Grade{StudentID: 1, CourseID: 2, Grade: "A", RecordedAt: now},
+			gomock.Any()).DoAndReturn(
+			func(ctx context.Context, grade *models.Grade, check repository.GradeCheck) (*models.Grade, error) {
+				err := check(course, test.enrolled, test.existing)
+				if err != nil {
+					return nil, err
+				}
+				return grade, nil
+			})
+
+		grade := &gradeUsecase{
+			gradeRepo: gradeRepo,
+			now:       func() time.Time { return now.Add(300 * time.Millisecond) },
+		}
+
+		ctx := context.Background()
+		if test.claims != nil {
+			ctx = auth.WithClaims(ctx, test.claims)
+		}
+		saved, err := grade.Record(ctx, 1, &models.GradeRequest{CourseID: 2,
+			GradeChange: models.GradeChange{Grade: "A"}}, test.named)
+		if err == nil && test.expected != "" || err != nil && apperrors.CodeOf(err) != test.expected {
+			t.Errorf("Test %s : Expected %q, but got %v", test.name, test.expected, err)
+		}
+		if err == nil && saved.LecturerID != test.expectedBy {
+			t.Errorf("Test %s : Expected the grade of lecturer %d, but got %d", test.name, test.expectedBy,
+				saved.LecturerID)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestGradeUsecase_Record_NamedByLecturer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	five := 5
+	ctx := auth.WithClaims(context.Background(), &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "6"},
+		Roles: []string{"lecturer"}})
+
+	_, err := NewGrade(mocks.NewMockGradeRepository(ctrl), mocks.NewMockStudentRepository(ctrl)).Record(ctx, 1,
+		&models.GradeRequest{CourseID: 2, GradeChange: models.GradeChange{Grade: "A"}}, &five)
+	if apperrors.CodeOf(err) != apperrors.CodeForbidden || apperrors.MessageOf(err, "") != consts.GraderError {
+		t.Errorf("Expected only admins to name the lecturer, but got %v", err)
+	}
+}
+
+func TestGradeUsecase_Update_NotGraded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gradeRepo := mocks.NewMockGradeRepository(ctrl)
+	gradeRepo.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, grade *models.Grade, check repository.GradeCheck) (*models.Grade, error) {
+			return nil, check(&models.Course{ID: 2, LecturerID: 5}, true, nil)
+		})
+
+	grade := NewGrade(gradeRepo, mocks.NewMockStudentRepository(ctrl))
+
+	_, err := grade.Update(context.Background(), 1, 2, &models.GradeChange{Grade: "A"}, nil)
+	if apperrors.CodeOf(err) != apperrors.CodeNotFound || apperrors.MessageOf(err, "") != consts.GradeNotFound {
+		log.Info("Expected the grade not found error, Got : %v ", err)
+		t.Fail()
+	}
+}
+
+func TestGradeUsecase_Transcript(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pu201 := models.TranscriptLine{CourseID: 3, Code: "PU201", Title: "Power Units", Semester: "2022-S2",
+		Credits: 4, Grade: "C+"}
+	ae101 := models.TranscriptLine{CourseID: 1, Code: "AE101", Title: "Aerodynamics", Semester: "2023-S1",
+		Credits: 3, Grade: "A-"}
+	ch101 := models.TranscriptLine{CourseID: 2, Code: "CH101", Title: "Chassis Design", Semester: "2023-S1",
+		Credits: 2, Grade: "B"}
+
+	studentRepo := mocks.NewMockStudentRepository(ctrl)
+	studentRepo.EXPECT().Get(gomock.Any(), 1).Return(&student1, nil)
+
+	gradeRepo := mocks.NewMockGradeRepository(ctrl)
+	gradeRepo.EXPECT().GetTranscript(gomock.Any(), 1).Return([]models.TranscriptLine{pu201, ae101, ch101}, nil)
+
+	pu201.Points, ae101.Points, ch101.Points = 2.3, 3.7, 3.0
+	expected := &models.Transcript{
+		Student: student1,
+		Semesters: []models.SemesterResult{
+			{Semester: "2022-S2", Courses: []models.TranscriptLine{pu201}, Credits: 4, GPA: 2.3},
+			{Semester: "2023-S1", Courses: []models.TranscriptLine{ae101, ch101}, Credits: 5, GPA: 3.42},
+		},
+		Credits: 9,
+		GPA:     2.92,
+	}
+
+	transcript, err := NewGrade(gradeRepo, studentRepo).Transcript(context.Background(), 1)
+	if err != nil || !reflect.DeepEqual(transcript, expected) {
+		log.Info("Expected %v, Got : %v, %v ", expected, transcript, err)
+		t.Fail()
+	}
+}
+
+func TestGradeUsecase_Transcript_NoGrades(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	studentRepo := mocks.NewMockStudentRepository(ctrl)
+	studentRepo.EXPECT().Get(gomock.Any(), 1).Return(&student1, nil)
+
+	gradeRepo := mocks.NewMockGradeRepository(ctrl)
+	gradeRepo.EXPECT().GetTranscript(gomock.Any(), 1).Return(nil, nil)
+
+	transcript, err := NewGrade(gradeRepo, studentRepo).Transcript(context.Background(), 1)
+	if err != nil || len(transcript.Semesters) != 0 || transcript.Semesters == nil || transcript.GPA != 0 {
+		log.Info("Expected an empty transcript, Got : %v, %v ", transcript, err)
+		t.Fail()
+	}
+}
+
+func TestGradeUsecase_Transcript_MissingStudent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	studentRepo := mocks.NewMockStudentRepository(ctrl)
+	studentRepo.EXPECT().Get(gomock.Any(), 1).Return(&models.Student{}, apperrors.NotFound(consts.StudentNotFound))
+
+	_, err := NewGrade(mocks.NewMockGradeRepository(ctrl), studentRepo).Transcript(context.Background(), 1)
+	if apperrors.CodeOf(err) != apperrors.CodeNotFound {
+		log.Info("Expected a not found error, Got : %v ", err)
+		t.Fail()
+	}
+}
@@ -1,6 +1,7 @@
 package lecturer
 
 import (
+	"context"
 	"errors"
 	"github.com/golang/mock/gomock"
 	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
@@ -42,12 +43,12 @@ func TestLecturerUsecase_GetAllLecturers_HappyPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockLecturerRepository(ctrl)
-	repo.EXPECT().GetAllLecturers().Return(lecturerList, nil)
+	repo.EXPECT().GetAll(gomock.Any(), nil).Return(lecturerList, nil)
 
 	lecturer := NewLecturer(repo)
 
 	for _, test := range tests {
-		actual, err := lecturer.GetAllLecturers()
+		actual, err := lecturer.GetAll(context.Background(), nil)
 		if actual[0] != test.expected[0] || err != nil {
 			log.Info("Expected : %v, Got : %v ", test.expected, actual)
 			t.Fail()
@@ -71,12 +72,12 @@ func TestLecturerUsecase_GetAllLecturers_ErrorPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockLecturerRepository(ctrl)
-	repo.EXPECT().GetAllLecturers().Return(nil, returnErr)
+	repo.EXPECT().GetAll(gomock.Any(), nil).Return(nil, returnErr)
 
 	lecturer := NewLecturer(repo)
 
 	for _, test := range tests {
-		_, err := lecturer.GetAllLecturers()
+		_, err := lecturer.GetAll(context.Background(), nil)
 		if err != test.expected {
 			log.Info("Expected : %v, Got : %v ", test.expected, err)
 			t.Fail()
@@ -87,12 +88,12 @@ func TestLecturerUsecase_GetAllLecturers_ErrorPath(t *testing.T) {
 func BenchmarkLecturerUsecase_GetAllLecturers(b *testing.B) {
 	ctrl := gomock.NewController(b)
 	repo := mocks.NewMockLecturerRepository(ctrl)
-	repo.EXPECT().GetAllLecturers().Return(lecturerList, nil).AnyTimes()
+	repo.EXPECT().GetAll(gomock.Any(), nil).Return(lecturerList, nil).AnyTimes()
 
 	lecturer := NewLecturer(repo)
 
 	for i := 0; i < b.N; i++ {
-		_, err := lecturer.GetAllLecturers()
+		_, err := lecturer.GetAll(context.Background(), nil)
 		if err != nil {
 			return
 		}
@@ -114,12 +115,12 @@ func TestLecturerUsecase_GetLecturer_HappyPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockLecturerRepository(ctrl)
-	repo.EXPECT().GetLecturer(1).Return(&s1, nil)
+	repo.EXPECT().Get(gomock.Any(), 1).Return(&s1, nil)
 
 	lecturer := NewLecturer(repo)
 
 	for _, test := range tests {
-		actual, err := lecturer.GetLecturer(1)
+		actual, err := lecturer.Get(context.Background(), 1)
 		if actual != test.expected || err != nil {
 			log.Info("Expected : %v, Got : %v ", test.expected, actual)
 			t.Fail()
@@ -142,12 +143,12 @@ func TestLecturerUsecase_GetLecturer_ErrorPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockLecturerRepository(ctrl)
-	repo.EXPECT().GetLecturer(1).Return(nil, returnErr)
+	repo.EXPECT().Get(gomock.Any(), 1).Return(nil, returnErr)
 
 	lecturer := NewLecturer(repo)
 
 	for _, test := range tests {
-		_, err := lecturer.GetLecturer(1)
+		_, err := lecturer.Get(context.Background(), 1)
 		if test.expected != err {
 			log.Info("Expected : %v, Got : %v ", test.expected, err)
 			t.Fail()
@@ -158,12 +159,12 @@ func TestLecturerUsecase_GetLecturer_ErrorPath(t *testing.T) {
 func BenchmarkLecturerUsecase_GetLecturer(b *testing.B) {
 	ctrl := gomock.NewController(b)
 	repo := mocks.NewMockLecturerRepository(ctrl)
-	repo.EXPECT().GetLecturer(1).Return(&s1, nil).AnyTimes()
+	repo.EXPECT().Get(gomock.Any(), 1).Return(&s1, nil).AnyTimes()
 
 	lecturer := NewLecturer(repo)
 
 	for i := 0; i < b.N; i++ {
-		_, err := lecturer.GetLecturer(1)
+		_, err := lecturer.Get(context.Background(), 1)
 		if err != nil {
 			return
 		}
@@ -185,12 +186,12 @@ func TestLecturerUsecase_CreateLecturer_HappyPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockLecturerRepository(ctrl)
-	repo.EXPECT().CreateLecturer(&s1).Return(&s1, nil)
+	repo.EXPECT().Create(gomock.Any(), &s1).Return(&s1, nil)
 
 	lecturer := NewLecturer(repo)
 
 	for _, test := range tests {
-		actual, err := lecturer.CreateLecturer(&s1)
+		actual, err := lecturer.Create(context.Background(), &s1)
 		if actual != test.expected || err != nil {
 			log.Info("Expected : %v, Got : %v ", test.expected, actual)
 			t.Fail()
@@ -213,12 +214,12 @@ func TestLecturerUsecase_CreateLecturer_ErrorPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockLecturerRepository(ctrl)
-	repo.EXPECT().CreateLecturer(&s1).Return(nil, returnErr)
+	repo.EXPECT().Create(gomock.Any(), &s1).Return(nil, returnErr)
 
 	lecturer := NewLecturer(repo)
 
 	for _, test := range tests {
-		_, err := lecturer.CreateLecturer(&s1)
+		_, err := lecturer.Create(context.Background(), &s1)
 		if err != test.expected {
 			log.Info("Expected : %v, Got : %v ", test.expected, err)
 			t.Fail()
@@ -229,12 +230,12 @@ func TestLecturerUsecase_CreateLecturer_ErrorPath(t *testing.T) {
 func BenchmarkLecturerUsecase_CreateLecturer(b *testing.B) {
 	ctrl := gomock.NewController(b)
 	repo := mocks.NewMockLecturerRepository(ctrl)
-	repo.EXPECT().CreateLecturer(&s1).Return(&s1, nil).AnyTimes()
+	repo.EXPECT().Create(gomock.Any(), &s1).Return(&s1, nil).AnyTimes()
 
 	lecturer := NewLecturer(repo)
 
 	for i := 0; i < b.N; i++ {
-		_, err := lecturer.CreateLecturer(&s1)
+		_, err := lecturer.Create(context.Background(), &s1)
 		if err != nil {
 			return
 		}
@@ -256,12 +257,12 @@ func TestLecturerUsecase_UpdateLecturer_HappyPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockLecturerRepository(ctrl)
-	repo.EXPECT().UpdateLecturer(&s1).Return(&s2, nil)
+	repo.EXPECT().Update(gomock.Any(), &s1).Return(&s2, nil)
 
 	lecturer := NewLecturer(repo)
 
 	for _, test := range tests {
-		actual, err := lecturer.UpdateLecturer(&s1)
+		actual, err := lecturer.Update(context.Background(), &s1)
 		if actual != test.expected || err != nil {
 			log.Info("Expected : %v, Got : %v ", test.expected, actual)
 			t.Fail()
@@ -284,12 +285,12 @@ func TestLecturerUsecase_UpdateLecturer_ErrorPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockLecturerRepository(ctrl)
-	repo.EXPECT().UpdateLecturer(&s1).Return(nil, returnErr)
+	repo.EXPECT().Update(gomock.Any(), &s1).Return(nil, returnErr)
 
 	lecturer := NewLecturer(repo)
 
 	for _, test := range tests {
-		_, err := lecturer.UpdateLecturer(&s1)
+		_, err := lecturer.Update(context.Background(), &s1)
 		if err != test.expected {
 			log.Info("Expected : %v, Got : %v ", test.expected, err)
 			t.Fail()
@@ -297,15 +298,42 @@ func TestLecturerUsecase_UpdateLecturer_ErrorPath(t *testing.T) {
 	}
 }
 
+func TestLecturerUsecase_PatchLecturer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockLecturerRepository(ctrl)
+	repo.EXPECT().Patch(gomock.Any(), 1, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, id int, patch func(*models.Lecturer) error) (*models.Lecturer, error) {
+			patched := s1
+			err := patch(&patched)
+			if err != nil {
+				return new(models.Lecturer), err
+			}
+			return &patched, nil
+		})
+
+	lecturer := NewLecturer(repo)
+
+	actual, err := lecturer.Patch(context.Background(), 1, func(l *models.Lecturer) error {
+		l.Year = 12
+		return nil
+	})
+	if err != nil || actual.Year != 12 {
+		log.Info("Expected : %v, Got : %v, %v ", 12, actual, err)
+		t.Fail()
+	}
+}
+
 func BenchmarkLecturerUsecase_UpdateLecturer(b *testing.B) {
 	ctrl := gomock.NewController(b)
 	repo := mocks.NewMockLecturerRepository(ctrl)
-	repo.EXPECT().UpdateLecturer(&s1).Return(&s2, nil).AnyTimes()
+	repo.EXPECT().Update(gomock.Any(), &s1).Return(&s2, nil).AnyTimes()
 
 	lecturer := NewLecturer(repo)
 
 	for i := 0; i < b.N; i++ {
-		_, err := lecturer.UpdateLecturer(&s1)
+		_, err := lecturer.Update(context.Background(), &s1)
 		if err != nil {
 			return
 		}
@@ -327,12 +355,12 @@ func TestLecturerUsecase_DeleteLecturer_HappyPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockLecturerRepository(ctrl)
-	repo.EXPECT().DeleteLecturer(1).Return(&s1, nil)
+	repo.EXPECT().Delete(gomock.Any(), 1).Return(&s1, nil)
 
 	lecturer := NewLecturer(repo)
 
 	for _, test := range tests {
-		actual, err := lecturer.DeleteLecturer(1)
+		actual, err := lecturer.Delete(context.Background(), 1)
 		if actual != test.expected || err != nil {
 			log.Info("Expected : %v, Got : %v ", test.expected, actual)
 			t.Fail()
@@ -355,12 +383,12 @@ func TestLecturerUsecase_DeleteLecturer_ErrorPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockLecturerRepository(ctrl)
-	repo.EXPECT().DeleteLecturer(1).Return(nil, returnErr)
+	repo.EXPECT().Delete(gomock.Any(), 1).Return(nil, returnErr)
 
 	lecturer := NewLecturer(repo)
 
 	for _, test := range tests {
-		_, err := lecturer.DeleteLecturer(1)
+		_, err := lecturer.Delete(context.Background(), 1)
 		if err != test.expected {
 			log.Info("Expected : %v, Got : %v ", test.expected, err)
 			t.Fail()
@@ -368,15 +396,47 @@ func TestLecturerUsecase_DeleteLecturer_ErrorPath(t *testing.T) {
 	}
 }
 
+func TestLecturerUsecase_RestoreLecturer_HappyPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockLecturerRepository(ctrl)
+	repo.EXPECT().Restore(gomock.Any(), 1).Return(&s1, nil)
+
+	lecturer := NewLecturer(repo)
+
+	actual, err := lecturer.Restore(context.Background(), 1)
+	if actual != &s1 || err != nil {
+		log.Info("Expected : %v, Got : %v ", s1, actual)
+		t.Fail()
+	}
+}
+
+func TestLecturerUsecase_RestoreLecturer_ErrorPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockLecturerRepository(ctrl)
+	repo.EXPECT().Restore(gomock.Any(), 1).Return(nil, returnErr)
+
+	lecturer := NewLecturer(repo)
+
+	_, err := lecturer.Restore(context.Background(), 1)
+	if err != returnErr {
+		log.Info("Expected : %v, Got : %v ", returnErr, err)
+		t.Fail()
+	}
+}
+
 func BenchmarkLecturerUsecase_DeleteLecturer(b *testing.B) {
 	ctrl := gomock.NewController(b)
 	repo := mocks.NewMockLecturerRepository(ctrl)
-	repo.EXPECT().DeleteLecturer(1).Return(&s1, nil).AnyTimes()
+	repo.EXPECT().Delete(gomock.Any(), 1).Return(&s1, nil).AnyTimes()
 
 	lecturer := NewLecturer(repo)
 
 	for i := 0; i < b.N; i++ {
-		_, err := lecturer.DeleteLecturer(1)
+		_, err := lecturer.Delete(context.Background(), 1)
 		if err != nil {
 			return
 		}
@@ -415,13 +475,13 @@ func TestLecturerUsecase_SearchLecturers_HappyPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockLecturerRepository(ctrl)
-	repo.EXPECT().SearchLecturer(tests[0].searchString, tests[0].pagination,
+	repo.EXPECT().Search(gomock.Any(), tests[0].searchString, nil, tests[0].pagination,
 		tests[0].sortBy).Return(&data, nil)
 
 	lecturer := NewLecturer(repo)
 
 	for _, test := range tests {
-		actual, err := lecturer.SearchLecturer(test.searchString, test.pagination, test.sortBy)
+		actual, err := lecturer.Search(context.Background(), test.searchString, nil, test.pagination, test.sortBy)
 		if actual != test.expected || err != nil {
 			log.Info("Expected : %v, Got : %v ", test.expected, actual)
 			t.Fail()
@@ -456,13 +516,13 @@ func TestLecturerUsecase_SearchLecturers_ErrorPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockLecturerRepository(ctrl)
-	repo.EXPECT().SearchLecturer(tests[0].searchString, tests[0].pagination,
+	repo.EXPECT().Search(gomock.Any(), tests[0].searchString, nil, tests[0].pagination,
 		tests[0].sortBy).Return(nil, returnErr)
 
 	lecturer := NewLecturer(repo)
 
 	for _, test := range tests {
-		actual, err := lecturer.SearchLecturer(test.searchString, test.pagination, test.sortBy)
+		actual, err := lecturer.Search(context.Background(), test.searchString, nil, test.pagination, test.sortBy)
 		if err != test.expected {
 			log.Info("Expected : %v, Got : %v ", test.expected, actual)
 			t.Fail()
@@ -501,13 +561,13 @@ func BenchmarkLecturerUsecase_SearchLecturers(b *testing.B) {
 	}
 
 	repo := mocks.NewMockLecturerRepository(ctrl)
-	repo.EXPECT().SearchLecturer(tests[0].searchString, tests[0].pagination,
+	repo.EXPECT().Search(gomock.Any(), tests[0].searchString, nil, tests[0].pagination,
 		tests[0].sortBy).Return(&data, nil).AnyTimes()
 
 	lecturer := NewLecturer(repo)
 
 	for i := 0; i < b.N; i++ {
-		_, err := lecturer.SearchLecturer(tests[0].searchString, tests[0].pagination,
+		_, err := lecturer.Search(context.Background(), tests[0].searchString, nil, tests[0].pagination,
 			tests[0].sortBy)
 		if err != nil {
 			return
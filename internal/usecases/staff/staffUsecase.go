@@ -0,0 +1,39 @@
+package staff
+
+import (
+	"context"
+
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/usecases/crud"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
+	"github.com/tryfix/log"
+)
+
+type StaffUsecase interface {
+	crud.Usecase[models.Staff]
+	// GetByNumber returns the staff member with the staff number, a missing
+	// staff member is a not found error
+	GetByNumber(ctx context.Context, number string) (*models.Staff, error)
+}
+
+type staffUsecase struct {
+	crud.Usecase[models.Staff]
+	staffRepo repository.StaffRepository
+}
+
+func NewStaff(staffRepo repository.StaffRepository) StaffUsecase {
+	return &staffUsecase{
+		Usecase:   crud.NewUsecase[models.Staff](staffRepo, models.StaffResource),
+		staffRepo: staffRepo,
+	}
+}
+
+func (s staffUsecase) GetByNumber(ctx context.Context, number string) (*models.Staff, error) {
+	staff, err := s.staffRepo.GetByNumber(ctx, number)
+	if err != nil {
+		log.Debug(consts.GetStaffError, err)
+		return new(models.Staff), err
+	}
+	return staff, nil
+}
@@ -0,0 +1,549 @@
+package staff
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/mocks"
+	"github.com/tryfix/log"
+	"testing"
+)
+
+var (
+	s1 = models.Staff{
+		ID:        1,
+		FirstName: "test1",
+		LastName:  "test1",
+		Position:  "Clerk",
+	}
+	s2 = models.Staff{
+		ID:        2,
+		FirstName: "test2",
+		LastName:  "test2",
+		Position:  "Manager",
+	}
+	staffList = []models.Staff{s1, s2}
+
+	returnErr = errors.New("error")
+)
+
+func TestStaffUsecase_GetAllStaff_HappyPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type test struct {
+		expected []models.Staff
+	}
+
+	tests := []test{
+		{
+			expected: staffList,
+		},
+	}
+
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().GetAll(gomock.Any(), nil).Return(staffList, nil)
+
+	staff := NewStaff(repo)
+
+	for _, test := range tests {
+		actual, err := staff.GetAll(context.Background(), nil)
+		if actual[0] != test.expected[0] || err != nil {
+			log.Info("Expected : %v, Got : %v ", test.expected, actual)
+			t.Fail()
+		}
+	}
+
+}
+
+func TestStaffUsecase_GetAllStaff_ErrorPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type test struct {
+		expected error
+	}
+
+	tests := []test{
+		{
+			expected: returnErr,
+		},
+	}
+
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().GetAll(gomock.Any(), nil).Return(nil, returnErr)
+
+	staff := NewStaff(repo)
+
+	for _, test := range tests {
+		_, err := staff.GetAll(context.Background(), nil)
+		if err != test.expected {
+			log.Info("Expected : %v, Got : %v ", test.expected, err)
+			t.Fail()
+		}
+	}
+}
+
+func BenchmarkStaffUsecase_GetAllStaff(b *testing.B) {
+	ctrl := gomock.NewController(b)
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().GetAll(gomock.Any(), nil).Return(staffList, nil).AnyTimes()
+
+	staff := NewStaff(repo)
+
+	for i := 0; i < b.N; i++ {
+		_, err := staff.GetAll(context.Background(), nil)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func TestStaffUsecase_GetStaff_HappyPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type test struct {
+		expected *models.Staff
+	}
+
+	tests := []test{
+		{
+			expected: &s1,
+		},
+	}
+
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().Get(gomock.Any(), 1).Return(&s1, nil)
+
+	staff := NewStaff(repo)
+
+	for _, test := range tests {
+		actual, err := staff.Get(context.Background(), 1)
+		if actual != test.expected || err != nil {
+			log.Info("Expected : %v, Got : %v ", test.expected, actual)
+			t.Fail()
+		}
+	}
+}
+
+func TestStaffUsecase_GetStaff_ErrorPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type test struct {
+		expected error
+	}
+
+	tests := []test{
+		{
+			expected: returnErr,
+		},
+	}
+
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().Get(gomock.Any(), 1).Return(nil, returnErr)
+
+	staff := NewStaff(repo)
+
+	for _, test := range tests {
+		_, err := staff.Get(context.Background(), 1)
+		if test.expected != err {
+			log.Info("Expected : %v, Got : %v ", test.expected, err)
+			t.Fail()
+		}
+	}
+}
+
+func BenchmarkStaffUsecase_GetStaff(b *testing.B) {
+	ctrl := gomock.NewController(b)
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().Get(gomock.Any(), 1).Return(&s1, nil).AnyTimes()
+
+	staff := NewStaff(repo)
+
+	for i := 0; i < b.N; i++ {
+		_, err := staff.Get(context.Background(), 1)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func TestStaffUsecase_CreateStaff_HappyPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type test struct {
+		expected *models.Staff
+	}
+
+	tests := []test{
+		{
+			expected: &s1,
+		},
+	}
+
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().Create(gomock.Any(), &s1).Return(&s1, nil)
+
+	staff := NewStaff(repo)
+
+	for _, test := range tests {
+		actual, err := staff.Create(context.Background(), &s1)
+		if actual != test.expected || err != nil {
+			log.Info("Expected : %v, Got : %v ", test.expected, actual)
+			t.Fail()
+		}
+	}
+}
+
+func TestStaffUsecase_CreateStaff_ErrorPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type test struct {
+		expected error
+	}
+
+	tests := []test{
+		{
+			expected: returnErr,
+		},
+	}
+
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().Create(gomock.Any(), &s1).Return(nil, returnErr)
+
+	staff := NewStaff(repo)
+
+	for _, test := range tests {
+		_, err := staff.Create(context.Background(), &s1)
+		if err != test.expected {
+			log.Info("Expected : %v, Got : %v ", test.expected, err)
+			t.Fail()
+		}
+	}
+}
+
+func BenchmarkStaffUsecase_CreateStaff(b *testing.B) {
+	ctrl := gomock.NewController(b)
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().Create(gomock.Any(), &s1).Return(&s1, nil).AnyTimes()
+
+	staff := NewStaff(repo)
+
+	for i := 0; i < b.N; i++ {
+		_, err := staff.Create(context.Background(), &s1)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func TestStaffUsecase_UpdateStaff_HappyPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type test struct {
+		expected *models.Staff
+	}
+
+	tests := []test{
+		{
+			expected: &s2,
+		},
+	}
+
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().Update(gomock.Any(), &s1).Return(&s2, nil)
+
+	staff := NewStaff(repo)
+
+	for _, test := range tests {
+		actual, err := staff.Update(context.Background(), &s1)
+		if actual != test.expected || err != nil {
+			log.Info("Expected : %v, Got : %v ", test.expected, actual)
+			t.Fail()
+		}
+	}
+}
+
+func TestStaffUsecase_UpdateStaff_ErrorPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type test struct {
+		expected error
+	}
+
+	tests := []test{
+		{
+			expected: returnErr,
+		},
+	}
+
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().Update(gomock.Any(), &s1).Return(nil, returnErr)
+
+	staff := NewStaff(repo)
+
+	for _, test := range tests {
+		_, err := staff.Update(context.Background(), &s1)
+		if err != test.expected {
+			log.Info("Expected : %v, Got : %v ", test.expected, err)
+			t.Fail()
+		}
+	}
+}
+
+func BenchmarkStaffUsecase_UpdateStaff(b *testing.B) {
+	ctrl := gomock.NewController(b)
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().Update(gomock.Any(), &s1).Return(&s2, nil).AnyTimes()
+
+	staff := NewStaff(repo)
+
+	for i := 0; i < b.N; i++ {
+		_, err := staff.Update(context.Background(), &s1)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func TestStaffUsecase_DeleteStaff_HappyPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type test struct {
+		expected *models.Staff
+	}
+
+	tests := []test{
+		{
+			expected: &s1,
+		},
+	}
+
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().Delete(gomock.Any(), 1).Return(&s1, nil)
+
+	staff := NewStaff(repo)
+
+	for _, test := range tests {
+		actual, err := staff.Delete(context.Background(), 1)
+		if actual != test.expected || err != nil {
+			log.Info("Expected : %v, Got : %v ", test.expected, actual)
+			t.Fail()
+		}
+	}
+}
+
+func TestStaffUsecase_DeleteStaff_ErrorPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type test struct {
+		expected error
+	}
+
+	tests := []test{
+		{
+			expected: returnErr,
+		},
+	}
+
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().Delete(gomock.Any(), 1).Return(nil, returnErr)
+
+	staff := NewStaff(repo)
+
+	for _, test := range tests {
+		_, err := staff.Delete(context.Background(), 1)
+		if err != test.expected {
+			log.Info("Expected : %v, Got : %v ", test.expected, err)
+			t.Fail()
+		}
+	}
+}
+
+func BenchmarkStaffUsecase_DeleteStaff(b *testing.B) {
+	ctrl := gomock.NewController(b)
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().Delete(gomock.Any(), 1).Return(&s1, nil).AnyTimes()
+
+	staff := NewStaff(repo)
+
+	for i := 0; i < b.N; i++ {
+		_, err := staff.Delete(context.Background(), 1)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func TestStaffUsecase_SearchStaff_HappyPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	data := models.StaffSearchData{
+		TotalElements: 2,
+		Data:          staffList,
+	}
+
+	type test struct {
+		searchString string
+		pagination   models.Pagination
+		sortBy       models.SortBy
+		expected     *models.StaffSearchData
+	}
+
+	tests := []test{
+		{
+			searchString: "a",
+			pagination: models.Pagination{
+				Page:     0,
+				PageSize: 2,
+			},
+			sortBy: models.SortBy{
+				Column:    "firstname",
+				Direction: "ASC",
+			},
+			expected: &data,
+		},
+	}
+
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().Search(gomock.Any(), tests[0].searchString, nil, tests[0].pagination,
+		tests[0].sortBy).Return(&data, nil)
+
+	staff := NewStaff(repo)
+
+	for _, test := range tests {
+		actual, err := staff.Search(context.Background(), test.searchString, nil, test.pagination, test.sortBy)
+		if actual != test.expected || err != nil {
+			log.Info("Expected : %v, Got : %v ", test.expected, actual)
+			t.Fail()
+		}
+	}
+}
+
+func TestStaffUsecase_SearchStaff_ErrorPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	type test struct {
+		searchString string
+		pagination   models.Pagination
+		sortBy       models.SortBy
+		expected     error
+	}
+
+	tests := []test{
+		{
+			searchString: "a",
+			pagination: models.Pagination{
+				Page:     0,
+				PageSize: 2,
+			},
+			sortBy: models.SortBy{
+				Column:    "firstname",
+				Direction: "ASC",
+			},
+			expected: returnErr,
+		},
+	}
+
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().Search(gomock.Any(), tests[0].searchString, nil, tests[0].pagination,
+		tests[0].sortBy).Return(nil, returnErr)
+
+	staff := NewStaff(repo)
+
+	for _, test := range tests {
+		actual, err := staff.Search(context.Background(), test.searchString, nil, test.pagination, test.sortBy)
+		if err != test.expected {
+			log.Info("Expected : %v, Got : %v ", test.expected, actual)
+			t.Fail()
+		}
+	}
+}
+
+func BenchmarkStaffUsecase_SearchStaff(b *testing.B) {
+	ctrl := gomock.NewController(b)
+
+	data := models.StaffSearchData{
+		TotalElements: 2,
+		Data:          staffList,
+	}
+
+	type test struct {
+		searchString string
+		pagination   models.Pagination
+		sortBy       models.SortBy
+		expected     *models.StaffSearchData
+	}
+
+	tests := []test{
+		{
+			searchString: "a",
+			pagination: models.Pagination{
+				Page:     0,
+				PageSize: 2,
+			},
+			sortBy: models.SortBy{
+				Column:    "firstname",
+				Direction: "ASC",
+			},
+			expected: &data,
+		},
+	}
+
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().Search(gomock.Any(), tests[0].searchString, nil, tests[0].pagination,
+		tests[0].sortBy).Return(&data, nil).AnyTimes()
+
+	staff := NewStaff(repo)
+
+	for i := 0; i < b.N; i++ {
+		_, err := staff.Search(context.Background(), tests[0].searchString, nil, tests[0].pagination,
+			tests[0].sortBy)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func TestStaffUsecase_GetByNumber_HappyPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().GetByNumber(gomock.Any(), "STF-2026-000001").Return(&s1, nil)
+
+	staff := NewStaff(repo)
+
+	actual, err := staff.GetByNumber(context.Background(), "STF-2026-000001")
+	if *actual != s1 || err != nil {
+		log.Info("Expected : %v, Got : %v ", s1, actual)
+		t.Fail()
+	}
+}
+
+func TestStaffUsecase_GetByNumber_ErrorPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockStaffRepository(ctrl)
+	repo.EXPECT().GetByNumber(gomock.Any(), "STF-2026-000001").Return(nil, returnErr)
+
+	staff := NewStaff(repo)
+
+	_, err := staff.GetByNumber(context.Background(), "STF-2026-000001")
+	if err != returnErr {
+		log.Info("Expected : %v, Got : %v ", returnErr, err)
+		t.Fail()
+	}
+}
@@ -1,74 +1,42 @@
 package student
 
 import (
+	"context"
+
 	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
 	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/usecases/crud"
 	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
 	"github.com/tryfix/log"
 )
 
+type StudentUsecase interface {
+	crud.SoftDeleteUsecase[models.Student]
+	crud.Patcher[models.Student]
+	// GetByNumber returns the student with the registration number, a
+	// missing student is a not found error
+	GetByNumber(ctx context.Context, number string) (*models.Student, error)
+}
+
 type studentUsecase struct {
+	crud.SoftDeleteUsecase[models.Student]
+	crud.Patcher[models.Student]
 	studentRepo repository.StudentRepository
 }
 
 func NewStudent(studentRepo repository.StudentRepository) StudentUsecase {
 	return &studentUsecase{
-		studentRepo: studentRepo,
-	}
-}
-
-func (s studentUsecase) GetAllStudents() ([]models.Student, error) {
-	studentList, err := s.studentRepo.GetAllStudents()
-	if err != nil {
-		log.Debug(consts.GetStudentsError, err)
-		return nil, err
+		SoftDeleteUsecase: crud.NewSoftDeleteUsecase[models.Student](studentRepo, models.StudentResource),
+		Patcher:           crud.NewPatcher[models.Student](studentRepo, models.StudentResource),
+		studentRepo:       studentRepo,
 	}
-	return studentList, nil
 }
 
-func (s studentUsecase) GetStudent(id int) (*models.Student, error) {
-	student, err := s.studentRepo.GetStudent(id)
-	if err != nil {
-		log.Debug(consts.GetStudentsError, err)
-		return &models.Student{}, err
-	}
-	return student, nil
-}
-
-func (s studentUsecase) CreateStudent(student *models.Student) (*models.Student, error) {
-
-	st, err := s.studentRepo.CreateStudent(student)
+func (s studentUsecase) GetByNumber(ctx context.Context, number string) (*models.Student, error) {
+	student, err := s.studentRepo.GetByNumber(ctx, number)
 	if err != nil {
 		log.Debug(consts.GetStudentsError, err)
-		return &models.Student{}, err
-	}
-	return st, nil
-}
-
-func (s studentUsecase) UpdateStudent(student *models.Student) (*models.Student, error) {
-	st, err := s.studentRepo.UpdateStudent(student)
-	if err != nil {
-		log.Debug(consts.GetStudentsError, err)
-		return &models.Student{}, err
-	}
-	return st, nil
-}
-
-func (s studentUsecase) SearchStudent(searchString string, pagination models.Pagination,
-	sortBy models.SortBy) (*models.StudentSearchData, error) {
-	studentList, err := s.studentRepo.SearchStudent(searchString, pagination, sortBy)
-	if err != nil {
-		log.Debug(consts.GetStudentsError, err)
-		return nil, err
-	}
-	return studentList, nil
-}
-
-func (s studentUsecase) DeleteStudent(id int) (*models.Student, error) {
-	student, err := s.studentRepo.DeleteStudent(id)
-	if err != nil {
-		log.Debug(consts.StudentDeleteError, err)
-		return &models.Student{}, err
+		return new(models.Student), err
 	}
 	return student, nil
 }
@@ -1,10 +1,13 @@
 package student
 
 import (
+	"context"
 	"errors"
 	"github.com/golang/mock/gomock"
 	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
 	"github.com/shashaneRanasinghe/simpleAPI/mocks"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/numbering"
 	"github.com/tryfix/log"
 	"testing"
 )
@@ -42,12 +45,12 @@ func TestStudentUsecase_GetAllStudents_HappyPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockStudentRepository(ctrl)
-	repo.EXPECT().GetAllStudents().Return(studentList, nil)
+	repo.EXPECT().GetAll(gomock.Any(), nil).Return(studentList, nil)
 
 	student := NewStudent(repo)
 
 	for _, test := range tests {
-		actual, err := student.GetAllStudents()
+		actual, err := student.GetAll(context.Background(), nil)
 		if actual[0] != test.expected[0] || err != nil {
 			log.Info("Expected : %v, Got : %v ", test.expected, actual)
 			t.Fail()
@@ -71,12 +74,12 @@ func TestStudentUsecase_GetAllStudents_ErrorPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockStudentRepository(ctrl)
-	repo.EXPECT().GetAllStudents().Return(nil, returnErr)
+	repo.EXPECT().GetAll(gomock.Any(), nil).Return(nil, returnErr)
 
 	student := NewStudent(repo)
 
 	for _, test := range tests {
-		_, err := student.GetAllStudents()
+		_, err := student.GetAll(context.Background(), nil)
 		if err != test.expected {
 			log.Info("Expected : %v, Got : %v ", test.expected, err)
 			t.Fail()
@@ -87,12 +90,12 @@ func TestStudentUsecase_GetAllStudents_ErrorPath(t *testing.T) {
 func BenchmarkStudentUsecase_GetAllStudents(b *testing.B) {
 	ctrl := gomock.NewController(b)
 	repo := mocks.NewMockStudentRepository(ctrl)
-	repo.EXPECT().GetAllStudents().Return(studentList, nil).AnyTimes()
+	repo.EXPECT().GetAll(gomock.Any(), nil).Return(studentList, nil).AnyTimes()
 
 	student := NewStudent(repo)
 
 	for i := 0; i < b.N; i++ {
-		_, err := student.GetAllStudents()
+		_, err := student.GetAll(context.Background(), nil)
 		if err != nil {
 			return
 		}
@@ -114,12 +117,12 @@ func TestStudentUsecase_GetStudent_HappyPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockStudentRepository(ctrl)
-	repo.EXPECT().GetStudent(1).Return(&s1, nil)
+	repo.EXPECT().Get(gomock.Any(), 1).Return(&s1, nil)
 
 	student := NewStudent(repo)
 
 	for _, test := range tests {
-		actual, err := student.GetStudent(1)
+		actual, err := student.Get(context.Background(), 1)
 		if actual != test.expected || err != nil {
 			log.Info("Expected : %v, Got : %v ", test.expected, actual)
 			t.Fail()
@@ -142,12 +145,12 @@ func TestStudentUsecase_GetStudent_ErrorPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockStudentRepository(ctrl)
-	repo.EXPECT().GetStudent(1).Return(nil, returnErr)
+	repo.EXPECT().Get(gomock.Any(), 1).Return(nil, returnErr)
 
 	student := NewStudent(repo)
 
 	for _, test := range tests {
-		_, err := student.GetStudent(1)
+		_, err := student.Get(context.Background(), 1)
 		if test.expected != err {
 			log.Info("Expected : %v, Got : %v ", test.expected, err)
 			t.Fail()
@@ -158,12 +161,12 @@ func TestStudentUsecase_GetStudent_ErrorPath(t *testing.T) {
 func BenchmarkStudentUsecase_GetStudent(b *testing.B) {
 	ctrl := gomock.NewController(b)
 	repo := mocks.NewMockStudentRepository(ctrl)
-	repo.EXPECT().GetStudent(1).Return(&s1, nil).AnyTimes()
+	repo.EXPECT().Get(gomock.Any(), 1).Return(&s1, nil).AnyTimes()
 
 	student := NewStudent(repo)
 
 	for i := 0; i < b.N; i++ {
-		_, err := student.GetStudent(1)
+		_, err := student.Get(context.Background(), 1)
 		if err != nil {
 			return
 		}
@@ -185,12 +188,12 @@ func TestStudentUsecase_CreateStudent_HappyPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockStudentRepository(ctrl)
-	repo.EXPECT().CreateStudent(&s1).Return(&s1, nil)
+	repo.EXPECT().Create(gomock.Any(), &s1).Return(&s1, nil)
 
 	student := NewStudent(repo)
 
 	for _, test := range tests {
-		actual, err := student.CreateStudent(&s1)
+		actual, err := student.Create(context.Background(), &s1)
 		if actual != test.expected || err != nil {
 			log.Info("Expected : %v, Got : %v ", test.expected, actual)
 			t.Fail()
@@ -213,12 +216,12 @@ func TestStudentUsecase_CreateStudent_ErrorPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockStudentRepository(ctrl)
-	repo.EXPECT().CreateStudent(&s1).Return(nil, returnErr)
+	repo.EXPECT().Create(gomock.Any(), &s1).Return(nil, returnErr)
 
 	student := NewStudent(repo)
 
 	for _, test := range tests {
-		_, err := student.CreateStudent(&s1)
+		_, err := student.Create(context.Background(), &s1)
 		if err != test.expected {
 			log.Info("Expected : %v, Got : %v ", test.expected, err)
 			t.Fail()
@@ -229,12 +232,12 @@ func TestStudentUsecase_CreateStudent_ErrorPath(t *testing.T) {
 func BenchmarkStudentUsecase_CreateStudent(b *testing.B) {
 	ctrl := gomock.NewController(b)
 	repo := mocks.NewMockStudentRepository(ctrl)
-	repo.EXPECT().CreateStudent(&s1).Return(&s1, nil).AnyTimes()
+	repo.EXPECT().Create(gomock.Any(), &s1).Return(&s1, nil).AnyTimes()
 
 	student := NewStudent(repo)
 
 	for i := 0; i < b.N; i++ {
-		_, err := student.CreateStudent(&s1)
+		_, err := student.Create(context.Background(), &s1)
 		if err != nil {
 			return
 		}
@@ -256,12 +259,12 @@ func TestStudentUsecase_UpdateStudent_HappyPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockStudentRepository(ctrl)
-	repo.EXPECT().UpdateStudent(&s1).Return(&s2, nil)
+	repo.EXPECT().Update(gomock.Any(), &s1).Return(&s2, nil)
 
 	student := NewStudent(repo)
 
 	for _, test := range tests {
-		actual, err := student.UpdateStudent(&s1)
+		actual, err := student.Update(context.Background(), &s1)
 		if actual != test.expected || err != nil {
 			log.Info("Expected : %v, Got : %v ", test.expected, actual)
 			t.Fail()
@@ -284,12 +287,12 @@ func TestStudentUsecase_UpdateStudent_ErrorPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockStudentRepository(ctrl)
-	repo.EXPECT().UpdateStudent(&s1).Return(nil, returnErr)
+	repo.EXPECT().Update(gomock.Any(), &s1).Return(nil, returnErr)
 
 	student := NewStudent(repo)
 
 	for _, test := range tests {
-		_, err := student.UpdateStudent(&s1)
+		_, err := student.Update(context.Background(), &s1)
 		if err != test.expected {
 			log.Info("Expected : %v, Got : %v ", test.expected, err)
 			t.Fail()
@@ -300,12 +303,12 @@ func TestStudentUsecase_UpdateStudent_ErrorPath(t *testing.T) {
 func BenchmarkStudentUsecase_UpdateStudent(b *testing.B) {
 	ctrl := gomock.NewController(b)
 	repo := mocks.NewMockStudentRepository(ctrl)
-	repo.EXPECT().UpdateStudent(&s1).Return(&s2, nil).AnyTimes()
+	repo.EXPECT().Update(gomock.Any(), &s1).Return(&s2, nil).AnyTimes()
 
 	student := NewStudent(repo)
 
 	for i := 0; i < b.N; i++ {
-		_, err := student.UpdateStudent(&s1)
+		_, err := student.Update(context.Background(), &s1)
 		if err != nil {
 			return
 		}
@@ -327,12 +330,12 @@ func TestStudentUsecase_DeleteStudent_HappyPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockStudentRepository(ctrl)
-	repo.EXPECT().DeleteStudent(1).Return(&s1, nil)
+	repo.EXPECT().Delete(gomock.Any(), 1).Return(&s1, nil)
 
 	student := NewStudent(repo)
 
 	for _, test := range tests {
-		actual, err := student.DeleteStudent(1)
+		actual, err := student.Delete(context.Background(), 1)
 		if actual != test.expected || err != nil {
 			log.Info("Expected : %v, Got : %v ", test.expected, actual)
 			t.Fail()
@@ -355,12 +358,12 @@ func TestStudentUsecase_DeleteStudent_ErrorPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockStudentRepository(ctrl)
-	repo.EXPECT().DeleteStudent(1).Return(nil, returnErr)
+	repo.EXPECT().Delete(gomock.Any(), 1).Return(nil, returnErr)
 
 	student := NewStudent(repo)
 
 	for _, test := range tests {
-		_, err := student.DeleteStudent(1)
+		_, err := student.Delete(context.Background(), 1)
 		if err != test.expected {
 			log.Info("Expected : %v, Got : %v ", test.expected, err)
 			t.Fail()
@@ -371,12 +374,12 @@ func TestStudentUsecase_DeleteStudent_ErrorPath(t *testing.T) {
 func BenchmarkStudentUsecase_DeleteStudent(b *testing.B) {
 	ctrl := gomock.NewController(b)
 	repo := mocks.NewMockStudentRepository(ctrl)
-	repo.EXPECT().DeleteStudent(1).Return(&s1, nil).AnyTimes()
+	repo.EXPECT().Delete(gomock.Any(), 1).Return(&s1, nil).AnyTimes()
 
 	student := NewStudent(repo)
 
 	for i := 0; i < b.N; i++ {
-		_, err := student.DeleteStudent(1)
+		_, err := student.Delete(context.Background(), 1)
 		if err != nil {
 			return
 		}
@@ -415,13 +418,13 @@ func TestStudentUsecase_SearchStudents_HappyPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockStudentRepository(ctrl)
-	repo.EXPECT().SearchStudent(tests[0].searchString, tests[0].pagination,
+	repo.EXPECT().Search(gomock.Any(), tests[0].searchString, nil, tests[0].pagination,
 		tests[0].sortBy).Return(&data, nil)
 
 	student := NewStudent(repo)
 
 	for _, test := range tests {
-		actual, err := student.SearchStudent(test.searchString, test.pagination, test.sortBy)
+		actual, err := student.Search(context.Background(), test.searchString, nil, test.pagination, test.sortBy)
 		if actual != test.expected || err != nil {
 			log.Info("Expected : %v, Got : %v ", test.expected, actual)
 			t.Fail()
@@ -456,13 +459,13 @@ func TestStudentUsecase_SearchStudents_ErrorPath(t *testing.T) {
 	}
 
 	repo := mocks.NewMockStudentRepository(ctrl)
-	repo.EXPECT().SearchStudent(tests[0].searchString, tests[0].pagination,
+	repo.EXPECT().Search(gomock.Any(), tests[0].searchString, nil, tests[0].pagination,
 		tests[0].sortBy).Return(nil, returnErr)
 
 	student := NewStudent(repo)
 
 	for _, test := range tests {
-		actual, err := student.SearchStudent(test.searchString, test.pagination, test.sortBy)
+		actual, err := student.Search(context.Background(), test.searchString, nil, test.pagination, test.sortBy)
 		if err != test.expected {
 			log.Info("Expected : %v, Got : %v ", test.expected, actual)
 			t.Fail()
@@ -470,6 +473,25 @@ func TestStudentUsecase_SearchStudents_ErrorPath(t *testing.T) {
 	}
 }
 
+func TestStudentUsecase_SearchStudents_DefaultPageSize(t *testing.T) {
+	repos := repository.NewMemoryRepositories(repository.Numbers{Students: numbering.MustParse("STU-{seq:3}")})
+	student := NewStudent(repos.Students)
+
+	for _, s := range studentList {
+		s.ID = 0
+		_, err := student.Create(context.Background(), &s)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	actual, err := student.Search(context.Background(), "test", nil, models.Pagination{}, models.SortBy{})
+	if err != nil || actual.TotalElements != 2 || len(actual.Data) != 2 {
+		log.Info("Expected : %v, Got : %v ", len(studentList), actual)
+		t.Fail()
+	}
+}
+
 func BenchmarkStudentUsecase_SearchStudents(b *testing.B) {
 	ctrl := gomock.NewController(b)
 
@@ -501,16 +523,80 @@ func BenchmarkStudentUsecase_SearchStudents(b *testing.B) {
 	}
 
 	repo := mocks.NewMockStudentRepository(ctrl)
-	repo.EXPECT().SearchStudent(tests[0].searchString, tests[0].pagination,
+	repo.EXPECT().Search(gomock.Any(), tests[0].searchString, nil, tests[0].pagination,
 		tests[0].sortBy).Return(&data, nil).AnyTimes()
 
 	student := NewStudent(repo)
 
 	for i := 0; i < b.N; i++ {
-		_, err := student.SearchStudent(tests[0].searchString, tests[0].pagination,
+		_, err := student.Search(context.Background(), tests[0].searchString, nil, tests[0].pagination,
 			tests[0].sortBy)
 		if err != nil {
 			return
 		}
 	}
 }
+
+func TestStudentUsecase_GetByNumber_HappyPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockStudentRepository(ctrl)
+	repo.EXPECT().GetByNumber(gomock.Any(), "STU-2026-000001").Return(&s1, nil)
+
+	student := NewStudent(repo)
+
+	actual, err := student.GetByNumber(context.Background(), "STU-2026-000001")
+	if *actual != s1 || err != nil {
+		log.Info("Expected : %v, Got : %v ", s1, actual)
+		t.Fail()
+	}
+}
+
+func TestStudentUsecase_GetByNumber_ErrorPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockStudentRepository(ctrl)
+	repo.EXPECT().GetByNumber(gomock.Any(), "STU-2026-000001").Return(nil, returnErr)
+
+	student := NewStudent(repo)
+
+	_, err := student.GetByNumber(context.Background(), "STU-2026-000001")
+	if err != returnErr {
+		log.Info("Expected : %v, Got : %v ", returnErr, err)
+		t.Fail()
+	}
+}
+
+func TestStudentUsecase_RestoreStudent_HappyPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockStudentRepository(ctrl)
+	repo.EXPECT().Restore(gomock.Any(), 1).Return(&s1, nil)
+
+	student := NewStudent(repo)
+
+	actual, err := student.Restore(context.Background(), 1)
+	if *actual != s1 || err != nil {
+		log.Info("Expected : %v, Got : %v ", s1, actual)
+		t.Fail()
+	}
+}
+
+func TestStudentUsecase_RestoreStudent_ErrorPath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockStudentRepository(ctrl)
+	repo.EXPECT().Restore(gomock.Any(), 1).Return(nil, returnErr)
+
+	student := NewStudent(repo)
+
+	_, err := student.Restore(context.Background(), 1)
+	if err != returnErr {
+		log.Info("Expected : %v, Got : %v ", returnErr, err)
+		t.Fail()
+	}
+}
@@ -0,0 +1,135 @@
+package apperrors
+
+import (
+	"errors"
+)
+
+// Code is the machine readable code of an error, it is sent to the client
+// in the response envelope
+type Code string
+
+const (
+	CodeNotFound      Code = "NOT_FOUND"
+	CodeValidation    Code = "VALIDATION_ERROR"
+	CodeConflict      Code = "CONFLICT"
+	CodePrecondition  Code = "PRECONDITION_FAILED"
+	CodeUnprocessable Code = "UNPROCESSABLE"
+	CodeTimeout       Code = "TIMEOUT"
+	CodeUnauthorized  Code = "UNAUTHORIZED"
+	CodeForbidden     Code = "FORBIDDEN"
+	CodeMediaType     Code = "UNSUPPORTED_MEDIA_TYPE"
+	CodeTooLarge      Code = "REQUEST_TOO_LARGE"
+	CodeInternal      Code = "INTERNAL_ERROR"
+)
+
+// FieldError describes why a field of a request was rejected
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is the error type returned by the repositories and usecases.
+// Message and Fields are safe to show to the client, Err holds the
+// underlying cause
+type Error struct {
+	Code    Code
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + " : " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func NotFound(message string) error {
+	return &Error{Code: CodeNotFound, Message: message}
+}
+
+func Validation(message string, err error) error {
+	return &Error{Code: CodeValidation, Message: message, Err: err}
+}
+
+// InvalidFields returns a validation error listing the rejected fields
+func InvalidFields(message string, fields []FieldError) error {
+	return &Error{Code: CodeValidation, Message: message, Fields: fields}
+}
+
+func Conflict(message string, err error) error {
+	return &Error{Code: CodeConflict, Message: message, Err: err}
+}
+
+// PreconditionFailed is returned when a conditional request does not match
+// the current version of the record
+func PreconditionFailed(message string) error {
+	return &Error{Code: CodePrecondition, Message: message}
+}
+
+// Unprocessable is returned when a well formed request breaks a business rule
+func Unprocessable(message string) error {
+	return &Error{Code: CodeUnprocessable, Message: message}
+}
+
+func Timeout(err error) error {
+	return &Error{Code: CodeTimeout, Message: "The Request Took Too Long", Err: err}
+}
+
+// Unauthorized is returned when the request does not carry valid credentials
+func Unauthorized(message string, err error) error {
+	return &Error{Code: CodeUnauthorized, Message: message, Err: err}
+}
+
+// Forbidden is returned when the caller may not perform the request
+func Forbidden(message string) error {
+	return &Error{Code: CodeForbidden, Message: message}
+}
+
+// UnsupportedMediaType is returned when the Content-Type of the request body
+// is not one the endpoint accepts
+func UnsupportedMediaType(message string, err error) error {
+	return &Error{Code: CodeMediaType, Message: message, Err: err}
+}
+
+// TooLarge is returned when the request body is larger than the server reads
+func TooLarge(message string) error {
+	return &Error{Code: CodeTooLarge, Message: message}
+}
+
+func Internal(err error) error {
+	return &Error{Code: CodeInternal, Message: "Internal Error", Err: err}
+}
+
+// CodeOf returns the code of err, errors that are not an *Error are internal
+func CodeOf(err error) Code {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return CodeInternal
+}
+
+// MessageOf returns the client facing message of err, or fallback when err
+// is internal so that database details are not leaked
+func MessageOf(err error, fallback string) string {
+	var appErr *Error
+	if errors.As(err, &appErr) && appErr.Code != CodeInternal {
+		return appErr.Message
+	}
+	return fallback
+}
+
+// FieldsOf returns the rejected fields of a validation error
+func FieldsOf(err error) []FieldError {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Fields
+	}
+	return nil
+}
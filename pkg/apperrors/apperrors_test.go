@@ -0,0 +1,68 @@
+package apperrors
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCodeOf(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected Code
+	}{
+		{name: "Not Found", err: NotFound("student Not Found"), expected: CodeNotFound},
+		{name: "Validation", err: Validation("Invalid ID", nil), expected: CodeValidation},
+		{name: "Conflict", err: Conflict("Duplicate", nil), expected: CodeConflict},
+		{name: "Precondition Failed", err: PreconditionFailed("Changed"), expected: CodePrecondition},
+		{name: "Unprocessable", err: Unprocessable("Rule Broken"), expected: CodeUnprocessable},
+		{name: "Timeout", err: Timeout(context.DeadlineExceeded), expected: CodeTimeout},
+		{name: "Unauthorized", err: Unauthorized("Missing Token", nil), expected: CodeUnauthorized},
+		{name: "Forbidden", err: Forbidden("Not Allowed"), expected: CodeForbidden},
+		{name: "Unsupported Media Type", err: UnsupportedMediaType("Wrong Type", nil), expected: CodeMediaType},
+		{name: "Too Large", err: TooLarge("Too Large"), expected: CodeTooLarge},
+		{name: "Internal", err: Internal(sql.ErrConnDone), expected: CodeInternal},
+		{name: "Wrapped", err: fmt.Errorf("wrapped : %w", NotFound("x")), expected: CodeNotFound},
+		{name: "Plain Error", err: errors.New("error"), expected: CodeInternal},
+	}
+
+	for _, test := range testCases {
+		if actual := CodeOf(test.err); actual != test.expected {
+			t.Errorf("Test %s : Expected %s, but got %s", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestMessageOf(t *testing.T) {
+	if msg := MessageOf(NotFound("student Not Found"), "fallback"); msg != "student Not Found" {
+		t.Errorf("Expected %s, but got %s", "student Not Found", msg)
+	}
+	if msg := MessageOf(Internal(sql.ErrConnDone), "fallback"); msg != "fallback" {
+		t.Errorf("Expected %s, but got %s", "fallback", msg)
+	}
+}
+
+func TestError_Unwrap(t *testing.T) {
+	err := Internal(sql.ErrConnDone)
+	if !errors.Is(err, sql.ErrConnDone) {
+		t.Errorf("Expected %v to wrap %v", err, sql.ErrConnDone)
+	}
+}
+
+func TestFieldsOf(t *testing.T) {
+	fields := []FieldError{{Field: "firstname", Message: "is required"}}
+
+	err := fmt.Errorf("wrapped : %w", InvalidFields("Invalid Request Body", fields))
+	if actual := FieldsOf(err); len(actual) != 1 || actual[0] != fields[0] {
+		t.Errorf("Expected %v, but got %v", fields, actual)
+	}
+	if CodeOf(err) != CodeValidation {
+		t.Errorf("Expected %s, but got %s", CodeValidation, CodeOf(err))
+	}
+	if actual := FieldsOf(errors.New("error")); actual != nil {
+		t.Errorf("Expected no fields, but got %v", actual)
+	}
+}
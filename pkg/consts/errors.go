@@ -6,7 +6,17 @@ const (
 	ResponseWriteError    = "Error Writing Response "
 	RequestBodyReadError  = "Error Reading The Request Body"
 	RequestBodyCloseError = "Error Closing The Request Body"
+	RequestBodyTooLarge   = "The Request Body Is Too Large"
 	IDError               = "Error Getting The ID"
+	InvalidRequestBody    = "Invalid Request Body"
+	IncludeDeletedError   = "includeDeleted Must Be true Or false"
+	VersionMismatchError  = "The Record Was Changed Since It Was Read"
+	RecordMissingError    = "The Record Does Not Exist"
+	PatchTypeError        = "Content-Type Must Be application/merge-patch+json Or application/json-patch+json"
+	InvalidPatch          = "Invalid Patch"
+	PatchTestError        = "The Patch Test Failed"
+	InvalidSearchQuery    = "Invalid Search Query"
+	InvalidFilterError    = "Invalid Filter"
 )
 
 // DB ERRORS
@@ -18,16 +28,107 @@ const (
 	DBRowsError           = "Error In DB Rows"
 	DBResultIDError       = "Error Getting Insert ID "
 	DBStatementCloseError = "Error Closing Prepared Statement"
+	DBRowsAffectedError   = "Error Getting Affected Rows "
+	DBTransactionError    = "Error In DB Transaction "
 )
 
 const (
-	StudentNotFound    = "student Not Found"
-	StudentDeleteError = "Error Deleting Student"
-	GetStudentsError   = "Error Getting Students "
+	StudentNotFound     = "student Not Found"
+	StudentCreateError  = "Error Creating Student"
+	StudentUpdateError  = "Error Updating Student"
+	StudentPatchError   = "Error Patching Student"
+	StudentDeleteError  = "Error Deleting Student"
+	StudentRestoreError = "Error Restoring Student"
+	GetStudentsError    = "Error Getting Students "
 )
 
 const (
-	LecturerNotFound    = "lecturer Not Found"
-	LecturerDeleteError = "Error Deleting Lecturer"
-	GetLecturersError   = "Error Getting Lecturers "
+	LecturerNotFound     = "lecturer Not Found"
+	LecturerCreateError  = "Error Creating Lecturer"
+	LecturerUpdateError  = "Error Updating Lecturer"
+	LecturerPatchError   = "Error Patching Lecturer"
+	LecturerDeleteError  = "Error Deleting Lecturer"
+	LecturerRestoreError = "Error Restoring Lecturer"
+	GetLecturersError    = "Error Getting Lecturers "
+)
+
+const (
+	StaffNotFound    = "staff Not Found"
+	StaffCreateError = "Error Creating Staff"
+	StaffUpdateError = "Error Updating Staff"
+	StaffPatchError  = "Error Patching Staff"
+	StaffDeleteError = "Error Deleting Staff"
+	GetStaffError    = "Error Getting Staff "
+)
+
+const (
+	DepartmentNotFound       = "department Not Found"
+	DepartmentCreateError    = "Error Creating Department"
+	DepartmentUpdateError    = "Error Updating Department"
+	DepartmentPatchError     = "Error Patching Department"
+	DepartmentDeleteError    = "Error Deleting Department"
+	GetDepartmentsError      = "Error Getting Departments "
+	HeadNotInDepartmentError = "The Head Must Be A Lecturer Of The Department"
+	DepartmentHeadMoveError  = "The Lecturer Heads The Department, Appoint Another Head First"
+	HeadOnCreateError        = "can not be set on create, appoint the head once the lecturers are assigned"
+)
+
+const (
+	CourseNotFound    = "course Not Found"
+	CourseCreateError = "Error Creating Course"
+	CourseUpdateError = "Error Updating Course"
+	CoursePatchError  = "Error Patching Course"
+	CourseDeleteError = "Error Deleting Course"
+	GetCoursesError   = "Error Getting Courses "
+)
+
+const (
+	EnrolmentNotFound    = "enrolment Not Found"
+	GetEnrolmentsError   = "Error Getting Enrolments "
+	EnrolError           = "Error Enrolling The Student "
+	WithdrawError        = "Error Removing The Enrolment "
+	AlreadyEnrolledError = "The Student Is Already Enrolled In The Course"
+	CourseFullError      = "The Course Is Full"
+	YearRequirementError = "The Course Is Open To Students In Year %d Or Later"
+	GradedWithdrawError  = "The Student Is Graded For The Course And Can Not Withdraw"
+)
+
+const (
+	GradeNotFound          = "grade Not Found"
+	GetGradesError         = "Error Getting Grades "
+	GradeRecordError       = "Error Recording The Grade "
+	NotEnrolledError       = "The Student Is Not Enrolled In The Course"
+	NotCourseLecturerError = "The Lecturer Does Not Teach The Course"
+	AlreadyGradedError     = "A Grade Is Already Recorded For The Course"
+	GraderError            = "Only An Admin Can Grade For Another Lecturer"
+	LecturerLookupError    = "lecturerId Must Be A Number"
+	GetTranscriptError     = "Error Getting The Transcript "
+	TranscriptFormatError  = "Unknown Transcript Format, It Must Be One Of json, text or pdf"
+)
+
+const (
+	InvalidSearchError  = "Invalid Search Request"
+	DuplicateEntryError = "A Record With The Same Values Already Exists"
+	ForeignKeyError     = "The Record Is Referenced By Or References A Missing Record"
+)
+
+const (
+	MissingTokenError = "A Bearer Token Is Required"
+	InvalidTokenError = "The Bearer Token Is Invalid Or Expired"
+	ForbiddenError    = "You Are Not Allowed To Perform This Action"
+)
+
+const (
+	APIKeyNotFound     = "API Key Not Found"
+	APIKeyRevokedError = "The API Key Is Revoked"
+	InvalidAPIKeyError = "The API Key Is Invalid Or Revoked"
+	GetAPIKeyError     = "Error Getting API Keys "
+	APIKeyCreateError  = "Error Creating The API Key "
+)
+
+const (
+	AuditError       = "Error Recording The Audit Event "
+	GetAuditError    = "Error Getting Audit Events "
+	AuditQueryError  = "id, page And pageSize Must Be Numbers"
+	AuditEntityError = "An id Must Be Given With Its entity"
 )
@@ -3,23 +3,78 @@ package consts
 const (
 	Success = "Success"
 	Error   = "Error"
+	Healthy = "Service Is Healthy"
 )
 
 const (
 	ContentType     = "Content-Type"
 	ApplicationJSON = "application/json"
+	TextPlain       = "text/plain"
+	ApplicationPDF  = "application/pdf"
+	ETag            = "ETag"
+	IfMatch         = "If-Match"
+	IfNoneMatch     = "If-None-Match"
+	AcceptPatch     = "Accept-Patch"
 )
 
 const (
-	StudentCreated = "Student Created Successfully"
-	GetStudent     = "Student Queried Successfully"
-	StudentDeleted = "Student Deleted Successfully"
-	StudentUpdated = "Student Updated Successfully"
+	StudentCreated  = "Student Created Successfully"
+	GetStudent      = "Student Queried Successfully"
+	StudentDeleted  = "Student Deleted Successfully"
+	StudentUpdated  = "Student Updated Successfully"
+	StudentRestored = "Student Restored Successfully"
 )
 
 const (
-	GetLecturer     = "Lecturer Queried Successfully"
-	LecturerCreated = "Lecturer Created Successfully"
-	LecturerDeleted = "Lecturer Deleted Successfully"
-	LecturerUpdated = "Lecturer Updated Successfully"
+	GetLecturer      = "Lecturer Queried Successfully"
+	LecturerCreated  = "Lecturer Created Successfully"
+	LecturerDeleted  = "Lecturer Deleted Successfully"
+	LecturerUpdated  = "Lecturer Updated Successfully"
+	LecturerRestored = "Lecturer Restored Successfully"
+)
+
+const (
+	GetStaff     = "Staff Queried Successfully"
+	StaffCreated = "Staff Created Successfully"
+	StaffDeleted = "Staff Deleted Successfully"
+	StaffUpdated = "Staff Updated Successfully"
+)
+
+const (
+	GetDepartment     = "Department Queried Successfully"
+	DepartmentCreated = "Department Created Successfully"
+	DepartmentDeleted = "Department Deleted Successfully"
+	DepartmentUpdated = "Department Updated Successfully"
+)
+
+const (
+	GetCourse     = "Course Queried Successfully"
+	CourseCreated = "Course Created Successfully"
+	CourseDeleted = "Course Deleted Successfully"
+	CourseUpdated = "Course Updated Successfully"
+)
+
+const (
+	GetEnrolments    = "Enrolments Queried Successfully"
+	StudentEnrolled  = "Student Enrolled Successfully"
+	EnrolmentRemoved = "Enrolment Removed Successfully"
+)
+
+const (
+	GetGrades     = "Grades Queried Successfully"
+	GradeRecorded = "Grade Recorded Successfully"
+	GradeUpdated  = "Grade Updated Successfully"
+	GetTranscript = "Transcript Queried Successfully"
+)
+
+const (
+	GetAuditEvents = "Audit Events Queried Successfully"
+)
+
+const (
+	GetAPIKeys    = "API Keys Queried Successfully"
+	APIKeyCreated = "API Key Created Successfully, It Is Shown Only Once"
+	APIKeyRotated = "API Key Rotated Successfully, It Is Shown Only Once"
+	APIKeyRevoked = "API Key Revoked Successfully"
+	APIKeyHeader  = "X-API-Key"
 )
@@ -6,7 +6,7 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/shashaneRanasinghe/simpleAPI/internal/config"
 	"github.com/tryfix/log"
-	"os"
+	_ "modernc.org/sqlite"
 )
 
 type database struct {
@@ -17,21 +17,28 @@ func NewDatabase() *database {
 	return &database{}
 }
 
-func (d *database) InitDatabase() {
-
-	cfg := config.DBConnection{
-		DBHost:    os.Getenv("DB_HOST"),
-		DBPort:    os.Getenv("DB_PORT"),
-		Username:  os.Getenv("DB_USERNAME"),
-		Password:  os.Getenv("DB_PASSWORD"),
-		DBName:    os.Getenv("DB_NAME"),
-		DBNetwork: os.Getenv("DB_NETWORK"),
+// InitDatabase connects to the database of the mysql or sqlite driver
+func (d *database) InitDatabase(cfg config.DBConnection) {
+
+	var db *sql.DB
+	var err error
+
+	switch cfg.Driver {
+	case config.DriverSQLite:
+		// foreign keys are off by default in SQLite. A single connection
+		// serializes the writers, which SQLite allows only one of at a time
+		db, err = sql.Open("sqlite", "file:"+cfg.Path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+		if err == nil {
+			db.SetMaxOpenConns(1)
+		}
+	default:
+		// Get a database handle. clientFoundRows makes updates report the matched
+		// rows, the repositories rely on it to detect missing records. parseTime
+		// scans DATETIME columns into time.Time
+		dsn := fmt.Sprintf("%s:%s@%s(%s:%s)/%s?clientFoundRows=true&parseTime=true", cfg.Username,
+			cfg.Password, cfg.DBNetwork, cfg.DBHost, cfg.DBPort, cfg.DBName)
+		db, err = sql.Open("mysql", dsn)
 	}
-	// Get a database handle.
-	dsn := fmt.Sprintf("%s:%s@%s(%s:%s)/%s", cfg.Username, cfg.Password, cfg.DBNetwork, cfg.DBHost,
-		cfg.DBPort, cfg.DBName)
-
-	db, err := sql.Open("mysql", dsn)
 	if err != nil {
 		log.Fatal("Error connecting to DB ", err)
 	}
@@ -0,0 +1,136 @@
+// Package filter parses the filter expressions of the list and search
+// queries, eg. year:eq:3,lastname:prefix:L, a comma separated list of
+// conditions that must all hold. A condition is a field, an operator and a
+// value separated by colons, the values of in are separated by bars, eg.
+// status:in:active|on_leave. A backslash escapes a comma, colon, bar or
+// backslash of a value
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Op is the operator of a condition
+type Op string
+
+// The operators, prefix and contains match a part of a text
+const (
+	Eq       Op = "eq"
+	Ne       Op = "ne"
+	Lt       Op = "lt"
+	Lte      Op = "lte"
+	Gt       Op = "gt"
+	Gte      Op = "gte"
+	Prefix   Op = "prefix"
+	Contains Op = "contains"
+	In       Op = "in"
+)
+
+var ops = map[Op]bool{Eq: true, Ne: true, Lt: true, Lte: true, Gt: true, Gte: true, Prefix: true,
+	Contains: true, In: true}
+
+// The limits of an expression, so a query can not grow without bounds
+const (
+	MaxConditions = 20
+	MaxValues     = 50
+)
+
+var fieldName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+
+// Condition compares the field with the values, only in has more than one
+type Condition struct {
+	Field  string
+	Op     Op
+	Values []string
+}
+
+// Expr is a filter expression, the records it keeps match all its conditions
+type Expr []Condition
+
+// Parse parses the filter expression, an empty expression keeps every record.
+// The field names and operators are checked here, whether the entity can be
+// filtered by the field is left to the caller
+func Parse(s string) (Expr, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	conditions := split(s, ',', -1)
+	if len(conditions) > MaxConditions {
+		return nil, fmt.Errorf("at most %d conditions are allowed", MaxConditions)
+	}
+
+	var expr Expr
+	for _, c := range conditions {
+		parts := split(c, ':', 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("%q is not a field:operator:value condition", c)
+		}
+		if !fieldName.MatchString(parts[0]) {
+			return nil, fmt.Errorf("%q is not a field name", parts[0])
+		}
+		op := Op(parts[1])
+		if !ops[op] {
+			return nil, fmt.Errorf("%q is not an operator", parts[1])
+		}
+
+		raw := []string{parts[2]}
+		if op == In {
+			raw = split(parts[2], '|', -1)
+			if len(raw) > MaxValues {
+				return nil, fmt.Errorf("in takes at most %d values", MaxValues)
+			}
+		}
+		values := make([]string, len(raw))
+		for i, v := range raw {
+			value, err := unescape(v)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+
+		expr = append(expr, Condition{Field: parts[0], Op: op, Values: values})
+	}
+	return expr, nil
+}
+
+// split splits s around the unescaped separators into at most n parts, all
+// of them when n is negative. The escapes are kept
+func split(s string, sep byte, n int) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s) && len(parts) != n-1; i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unescape removes the backslashes escaping the special characters of value
+func unescape(value string) (string, error) {
+	if !strings.Contains(value, `\`) {
+		return value, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		if i == len(value) || !strings.ContainsRune(`\,:|`, rune(value[i])) {
+			return "", fmt.Errorf("%q has an invalid escape", value)
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,66 @@
+package filter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		filter   string
+		expected Expr
+	}{
+		{name: "Empty", filter: "", expected: nil},
+		{name: "One Condition", filter: "year:eq:3",
+			expected: Expr{{Field: "year", Op: Eq, Values: []string{"3"}}}},
+		{name: "Conjunction", filter: "year:gte:2,lastname:prefix:L",
+			expected: Expr{
+				{Field: "year", Op: Gte, Values: []string{"2"}},
+				{Field: "lastname", Op: Prefix, Values: []string{"L"}},
+			}},
+		{name: "In", filter: "status:in:active|on_leave",
+			expected: Expr{{Field: "status", Op: In, Values: []string{"active", "on_leave"}}}},
+		{name: "Colon In The Value", filter: "phone:eq:+1:555",
+			expected: Expr{{Field: "phone", Op: Eq, Values: []string{"+1:555"}}}},
+		{name: "Escapes", filter: `lastname:contains:a\,b\|c\\d,status:in:x\|y|z`,
+			expected: Expr{
+				{Field: "lastname", Op: Contains, Values: []string{`a,b|c\d`}},
+				{Field: "status", Op: In, Values: []string{"x|y", "z"}},
+			}},
+		{name: "Empty Value", filter: "email:eq:",
+			expected: Expr{{Field: "email", Op: Eq, Values: []string{""}}}},
+	}
+
+	for _, test := range testCases {
+		actual, err := Parse(test.filter)
+		if err != nil || !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Test %s : Expected %v, but got %v, %v", test.name, test.expected, actual, err)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	testCases := []struct {
+		name   string
+		filter string
+	}{
+		{name: "Missing Value", filter: "year:eq"},
+		{name: "Missing Condition", filter: "year:eq:3,"},
+		{name: "Unknown Operator", filter: "year:like:3"},
+		{name: "Field Is Not A Name", filter: "year;drop:eq:3"},
+		{name: "Empty Field", filter: ":eq:3"},
+		{name: "Unknown Escape", filter: `lastname:eq:a\b`},
+		{name: "Trailing Backslash", filter: `lastname:eq:a\`},
+		{name: "Too Many Conditions", filter: strings.Repeat("year:eq:3,", MaxConditions) + "year:eq:3"},
+		{name: "Too Many Values", filter: "year:in:" + strings.Repeat("3|", MaxValues) + "3"},
+	}
+
+	for _, test := range testCases {
+		expr, err := Parse(test.filter)
+		if err == nil {
+			t.Errorf("Test %s : Expected %q to be rejected, but got %v", test.name, test.filter, expr)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Package numbering formats human facing record numbers, eg. STU-2026-000123,
+// from a pattern and a sequence number
+package numbering
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxLength is the longest number a pattern may produce, the columns holding
+// the numbers are this wide
+const MaxLength = 50
+
+const (
+	defaultWidth = 6
+	maxWidth     = 12
+)
+
+var placeholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// Format is a parsed pattern. The pattern is literal text with the
+// placeholders
+//
+//	{year}   the four digit year the record is created in
+//	{seq}    the sequence number, zero padded to 6 digits
+//	{seq:N}  the sequence number, zero padded to N digits
+//
+// and it must hold exactly one sequence. The sequence restarts whenever the
+// rest of the number changes, eg. every year with STU-{year}-{seq:6}
+type Format struct {
+	pattern string
+	width   int
+}
+
+// Parse parses a pattern, eg. STU-{year}-{seq:6}
+func Parse(pattern string) (Format, error) {
+	format := Format{pattern: pattern}
+	sequences := 0
+	for _, p := range placeholder.FindAllString(pattern, -1) {
+		name, width, hasWidth := strings.Cut(strings.Trim(p, "{}"), ":")
+		switch {
+		case name == "year" && !hasWidth:
+		case name == "seq" && !hasWidth:
+			sequences++
+			format.width = defaultWidth
+		case name == "seq":
+			n, err := strconv.Atoi(width)
+			if err != nil || n < 1 || n > maxWidth {
+				return Format{}, fmt.Errorf("the width of %s must be between 1 and %d", p, maxWidth)
+			}
+			sequences++
+			format.width = n
+		default:
+			return Format{}, fmt.Errorf("%s is not a placeholder, use {year}, {seq} or {seq:N}", p)
+		}
+	}
+	if sequences != 1 {
+		return Format{}, fmt.Errorf("the pattern %q must hold one {seq}", pattern)
+	}
+	if strings.ContainsAny(placeholder.ReplaceAllString(pattern, ""), "{}/ ") {
+		return Format{}, fmt.Errorf("the pattern %q can not hold braces, slashes or spaces", pattern)
+	}
+	if len(format.Number(time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC), 1)) > MaxLength {
+		return Format{}, fmt.Errorf("the pattern %q makes numbers longer than %d characters", pattern, MaxLength)
+	}
+	return format, nil
+}
+
+// MustParse is like Parse but panics when the pattern is invalid, it is
+// meant for the default patterns
+func MustParse(pattern string) Format {
+	format, err := Parse(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return format
+}
+
+// Series is the pattern with every placeholder but the sequence filled in,
+// the numbers of a series share one sequence
+func (f Format) Series(at time.Time) string {
+	return placeholder.ReplaceAllStringFunc(f.pattern, func(p string) string {
+		if p == "{year}" {
+			return fmt.Sprintf("%04d", at.Year())
+		}
+		return p
+	})
+}
+
+// Number is the number with the sequence seq in the series of at
+func (f Format) Number(at time.Time, seq int) string {
+	return placeholder.ReplaceAllStringFunc(f.Series(at), func(string) string {
+		return fmt.Sprintf("%0*d", f.width, seq)
+	})
+}
+
+func (f Format) String() string {
+	return f.pattern
+}
+
+// IsZero reports whether the format is unset, a repository without a
+// format generates no numbers
+func (f Format) IsZero() bool {
+	return f.pattern == ""
+}
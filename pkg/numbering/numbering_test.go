@@ -0,0 +1,60 @@
+package numbering
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormat_Number(t *testing.T) {
+	at := time.Date(2026, time.March, 4, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		pattern        string
+		seq            int
+		expectedSeries string
+		expected       string
+	}{
+		{name: "Year And Sequence", pattern: "STU-{year}-{seq:6}", seq: 123,
+			expectedSeries: "STU-2026-{seq:6}", expected: "STU-2026-000123"},
+		{name: "Default Width", pattern: "S{seq}", seq: 7, expectedSeries: "S{seq}", expected: "S000007"},
+		{name: "Sequence Wider Than The Width", pattern: "{year}{seq:2}", seq: 123,
+			expectedSeries: "2026{seq:2}", expected: "2026123"},
+	}
+
+	for _, test := range testCases {
+		format, err := Parse(test.pattern)
+		if err != nil {
+			t.Fatalf("Test %s : Error parsing the pattern %v", test.name, err)
+		}
+		if series := format.Series(at); series != test.expectedSeries {
+			t.Errorf("Test %s : Expected series %s, but got %s", test.name, test.expectedSeries, series)
+		}
+		if number := format.Number(at, test.seq); number != test.expected {
+			t.Errorf("Test %s : Expected %s, but got %s", test.name, test.expected, number)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		pattern string
+	}{
+		{name: "Empty", pattern: ""},
+		{name: "No Sequence", pattern: "STU-{year}"},
+		{name: "Two Sequences", pattern: "{seq}-{seq}"},
+		{name: "Unknown Placeholder", pattern: "STU-{month}-{seq}"},
+		{name: "Bad Width", pattern: "STU-{seq:0}"},
+		{name: "Unbalanced Brace", pattern: "STU-{seq}}"},
+		{name: "Slash", pattern: "STU/{seq}"},
+		{name: "Too Long", pattern: "STUDENT-OF-THE-UNIVERSITY-OF-SOMEWHERE-{year}-{seq:12}"},
+	}
+
+	for _, test := range testCases {
+		_, err := Parse(test.pattern)
+		if err == nil {
+			t.Errorf("Test %s : Expected %q to be rejected", test.name, test.pattern)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+// Package patch applies patches to JSON documents, the merge patches of
+// RFC 7396 and the JSON patches of RFC 6902
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The media types of the patches, Accepted lists them as sent in the
+// Accept-Patch header
+const (
+	MergePatchType = "application/merge-patch+json"
+	JSONPatchType  = "application/json-patch+json"
+	Accepted       = MergePatchType + ", " + JSONPatchType
+)
+
+// ErrTestFailed is returned when a test operation of a JSON patch does not
+// hold, the document is not the one the patch was written for
+var ErrTestFailed = errors.New("the test operation failed")
+
+// Merge applies the merge patch to the document. The members of a patch
+// object replace those of the document, recursively for objects, and a null
+// member removes the member of the document
+func Merge(doc []byte, patch []byte) ([]byte, error) {
+	var target interface{}
+	err := json.Unmarshal(doc, &target)
+	if err != nil {
+		return nil, err
+	}
+	var p interface{}
+	err = json.Unmarshal(patch, &p)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(merge(target, p))
+}
+
+func merge(target interface{}, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for name, value := range p {
+		if value == nil {
+			delete(t, name)
+			continue
+		}
+		t[name] = merge(t[name], value)
+	}
+	return t
+}
+
+// operation is an operation of a JSON patch, From is only used by move and
+// copy and Value by add, replace and test
+type operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Apply applies the JSON patch, an array of operations, to the document. The
+// operations are applied in order and the patch fails as a whole when any of
+// them fails
+func Apply(doc []byte, patch []byte) ([]byte, error) {
+	var target interface{}
+	err := json.Unmarshal(doc, &target)
+	if err != nil {
+		return nil, err
+	}
+	var operations []operation
+	err = json.Unmarshal(patch, &operations)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, op := range operations {
+		target, err = op.apply(target)
+		if err != nil {
+			if errors.Is(err, ErrTestFailed) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+	return json.Marshal(target)
+}
+
+func (op operation) apply(doc interface{}) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("%s needs a value", op.Op)
+		}
+		var value interface{}
+		err := json.Unmarshal(op.Value, &value)
+		if err != nil {
+			return nil, err
+		}
+		switch op.Op {
+		case "add":
+			return add(doc, path, value)
+		case "replace":
+			doc, _, err = remove(doc, path)
+			if err != nil {
+				return nil, err
+			}
+			return add(doc, path, value)
+		}
+		current, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(current, value) {
+			return nil, ErrTestFailed
+		}
+		return doc, nil
+	case "remove":
+		doc, _, err = remove(doc, path)
+		return doc, err
+	case "move", "copy":
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		var value interface{}
+		if op.Op == "move" {
+			doc, value, err = remove(doc, from)
+		} else {
+			value, err = get(doc, from)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, deepCopy(value))
+	}
+	return nil, fmt.Errorf("%q is not an operation", op.Op)
+}
+
+// parsePointer splits a JSON pointer, eg. /a/b~1c, into its unescaped tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%q is not a JSON pointer", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// get returns the value at the path
+func get(doc interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch parent := doc.(type) {
+		case map[string]interface{}:
+			value, ok := parent[token]
+			if !ok {
+				return nil, fmt.Errorf("%q does not exist", token)
+			}
+			doc = value
+		case []interface{}:
+			i, err := index(token, len(parent)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = parent[i]
+		default:
+			return nil, fmt.Errorf("%q does not exist", token)
+		}
+	}
+	return doc, nil
+}
+
+// add returns the document with the value added at the path, replacing the
+// member of an object and inserting into an array
+func add(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	last := path[len(path)-1]
+	switch parent := parent.(type) {
+	case map[string]interface{}:
+		parent[last] = value
+		return doc, nil
+	case []interface{}:
+		i := len(parent)
+		if last != "-" {
+			i, err = index(last, len(parent))
+			if err != nil {
+				return nil, err
+			}
+		}
+		list := append(parent[:i:i], append([]interface{}{value}, parent[i:]...)...)
+		return set(doc, path[:len(path)-1], list)
+	}
+	return nil, fmt.Errorf("%q can not hold %q", strings.Join(path[:len(path)-1], "/"), last)
+}
+
+// set returns the document with the value at the existing path replaced
+func set(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	last := path[len(path)-1]
+	switch parent := parent.(type) {
+	case map[string]interface{}:
+		parent[last] = value
+	case []interface{}:
+		i, err := index(last, len(parent)-1)
+		if err != nil {
+			return nil, err
+		}
+		parent[i] = value
+	}
+	return doc, nil
+}
+
+// remove returns the document without the value at the path and the value
+// removed
+func remove(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	last := path[len(path)-1]
+	switch parent := parent.(type) {
+	case map[string]interface{}:
+		value, ok := parent[last]
+		if !ok {
+			return nil, nil, fmt.Errorf("%q does not exist", last)
+		}
+		delete(parent, last)
+		return doc, value, nil
+	case []interface{}:
+		i, err := index(last, len(parent)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := parent[i]
+		list := append(parent[:i:i], parent[i+1:]...)
+		doc, err = set(doc, path[:len(path)-1], list)
+		return doc, value, err
+	}
+	return nil, nil, fmt.Errorf("%q does not exist", last)
+}
+
+// index parses an array index, which must be at most max
+func index(token string, max int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > max || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%q is not an index of the array", token)
+	}
+	return i, nil
+}
+
+// deepCopy copies a decoded JSON value, so a copied value is not shared
+func deepCopy(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(value))
+		for name, v := range value {
+			c[name] = deepCopy(v)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(value))
+		for i, v := range value {
+			c[i] = deepCopy(v)
+		}
+		return c
+	}
+	return value
+}
@@ -0,0 +1,103 @@
+package patch
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	// the examples of RFC 7396, appendix A
+	testCases := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{name: "Replace", doc: `{"a":"b"}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		{name: "Add", doc: `{"a":"b"}`, patch: `{"b":"c"}`, expected: `{"a":"b","b":"c"}`},
+		{name: "Remove", doc: `{"a":"b"}`, patch: `{"a":null}`, expected: `{}`},
+		{name: "Remove One", doc: `{"a":"b","b":"c"}`, patch: `{"a":null}`, expected: `{"b":"c"}`},
+		{name: "Array Is Replaced", doc: `{"a":["b"]}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		{name: "Value Is Replaced", doc: `{"a":"c"}`, patch: `{"a":["b"]}`, expected: `{"a":["b"]}`},
+		{name: "Nested", doc: `{"a":{"b":"c"}}`, patch: `{"a":{"b":"d","c":null}}`, expected: `{"a":{"b":"d"}}`},
+		{name: "Array Of Objects", doc: `{"a":[{"b":"c"}]}`, patch: `{"a":[1]}`, expected: `{"a":[1]}`},
+		{name: "Not An Object", doc: `["a","b"]`, patch: `["c","d"]`, expected: `["c","d"]`},
+		{name: "Object Replaces Array", doc: `["a"]`, patch: `{"a":"b"}`, expected: `{"a":"b"}`},
+		{name: "Null Patch", doc: `{"a":"foo"}`, patch: `null`, expected: `null`},
+		{name: "Null Member Of New Object", doc: `{}`, patch: `{"a":{"bb":{"ccc":null}}}`,
+			expected: `{"a":{"bb":{}}}`},
+	}
+
+	for _, test := range testCases {
+		actual, err := Merge([]byte(test.doc), []byte(test.patch))
+		if err != nil || string(actual) != test.expected {
+			t.Errorf("Test %s : Expected %s, but got %s, %v", test.name, test.expected, actual, err)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	testCases := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{name: "Add Member", doc: `{"a":1}`, patch: `[{"op":"add","path":"/b","value":2}]`,
+			expected: `{"a":1,"b":2}`},
+		{name: "Insert Into Array", doc: `{"a":[1,3]}`, patch: `[{"op":"add","path":"/a/1","value":2}]`,
+			expected: `{"a":[1,2,3]}`},
+		{name: "Append To Array", doc: `{"a":[1]}`, patch: `[{"op":"add","path":"/a/-","value":2}]`,
+			expected: `{"a":[1,2]}`},
+		{name: "Remove", doc: `{"a":1,"b":2}`, patch: `[{"op":"remove","path":"/a"}]`, expected: `{"b":2}`},
+		{name: "Remove From Array", doc: `{"a":[1,2,3]}`, patch: `[{"op":"remove","path":"/a/1"}]`,
+			expected: `{"a":[1,3]}`},
+		{name: "Replace", doc: `{"a":1}`, patch: `[{"op":"replace","path":"/a","value":null}]`,
+			expected: `{"a":null}`},
+		{name: "Move", doc: `{"a":{"b":1}}`, patch: `[{"op":"move","from":"/a/b","path":"/c"}]`,
+			expected: `{"a":{},"c":1}`},
+		{name: "Copy", doc: `{"a":[1]}`, patch: `[{"op":"copy","from":"/a","path":"/b"}]`,
+			expected: `{"a":[1],"b":[1]}`},
+		{name: "Escaped Pointer", doc: `{"a/b":1,"c~d":2}`,
+			patch:    `[{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/c~0d"}]`,
+			expected: `{"a/b":3}`},
+		{name: "Test Then Replace", doc: `{"a":[1,{"b":"c"}]}`,
+			patch:    `[{"op":"test","path":"/a","value":[1,{"b":"c"}]},{"op":"replace","path":"/a/0","value":2}]`,
+			expected: `{"a":[2,{"b":"c"}]}`},
+	}
+
+	for _, test := range testCases {
+		actual, err := Apply([]byte(test.doc), []byte(test.patch))
+		if err != nil || string(actual) != test.expected {
+			t.Errorf("Test %s : Expected %s, but got %s, %v", test.name, test.expected, actual, err)
+		}
+	}
+}
+
+func TestApply_Invalid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		patch string
+	}{
+		{name: "Not An Array", patch: `{"op":"add","path":"/b","value":2}`},
+		{name: "Unknown Operation", patch: `[{"op":"merge","path":"/a","value":2}]`},
+		{name: "Missing Value", patch: `[{"op":"add","path":"/b"}]`},
+		{name: "Missing Member", patch: `[{"op":"replace","path":"/b","value":2}]`},
+		{name: "Missing Parent", patch: `[{"op":"add","path":"/b/c","value":2}]`},
+		{name: "Bad Pointer", patch: `[{"op":"remove","path":"a"}]`},
+		{name: "Index Out Of Range", patch: `[{"op":"add","path":"/c/3","value":2}]`},
+		{name: "Leading Zero", patch: `[{"op":"remove","path":"/c/01"}]`},
+	}
+
+	for _, test := range testCases {
+		_, err := Apply([]byte(`{"a":1,"c":[1,2]}`), []byte(test.patch))
+		if err == nil || errors.Is(err, ErrTestFailed) {
+			t.Errorf("Test %s : Expected %s to be rejected, but got %v", test.name, test.patch, err)
+		}
+	}
+
+	_, err := Apply([]byte(`{"a":1}`), []byte(`[{"op":"test","path":"/a","value":2}]`))
+	if !errors.Is(err, ErrTestFailed) {
+		t.Errorf("Expected the test to fail, but got %v", err)
+	}
+}
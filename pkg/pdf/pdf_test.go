@@ -0,0 +1,83 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDocument_WriteTo(t *testing.T) {
+	testCases := []struct {
+		name          string
+		lines         int
+		expectedPages int
+	}{
+		{name: "Empty", lines: 0, expectedPages: 1},
+		{name: "One Page", lines: linesPerPage, expectedPages: 1},
+		{name: "Two Pages", lines: linesPerPage + 1, expectedPages: 2},
+	}
+
+	for _, test := range testCases {
+		doc := New()
+		for i := 0; i < test.lines; i++ {
+			doc.Line(fmt.Sprintf("line %d", i))
+		}
+
+		var b bytes.Buffer
+		_, err := doc.WriteTo(&b)
+		if err != nil {
+			t.Fatalf("Test %s : Error writing the document %v", test.name, err)
+		}
+		out := b.String()
+
+		count := regexp.MustCompile(`/Count (\d+)`).FindStringSubmatch(out)
+		if count == nil || count[1] != strconv.Itoa(test.expectedPages) {
+			t.Errorf("Test %s : Expected %d pages, but got %v", test.name, test.expectedPages, count)
+		}
+
+		// every xref entry must point at the start of its object
+		xref := out[strings.Index(out, "xref\n"):]
+		entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(xref, -1)
+		if len(entries) != 3+2*test.expectedPages {
+			t.Errorf("Test %s : Expected %d xref entries, but got %d", test.name, 3+2*test.expectedPages,
+				len(entries))
+		}
+		for i, entry := range entries {
+			offset, _ := strconv.Atoi(entry[1])
+			if !strings.HasPrefix(out[offset:], fmt.Sprintf("%d 0 obj\n", i+1)) {
+				t.Errorf("Test %s : Expected object %d at offset %d", test.name, i+1, offset)
+			}
+		}
+
+		startxref := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindStringSubmatch(out)
+		if startxref == nil || !strings.HasPrefix(out[atoi(startxref[1]):], "xref\n") {
+			t.Errorf("Test %s : Expected startxref to point at the xref table", test.name)
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected string
+	}{
+		{text: "GPA 3.70", expected: "GPA 3.70"},
+		{text: `Aerodynamics (Advanced) \ Lab`, expected: `Aerodynamics \(Advanced\) \\ Lab`},
+		{text: "Zoë\tKravitz", expected: "Zo??Kravitz"},
+	}
+
+	for _, test := range testCases {
+		actual := escape(test.text)
+		if actual != test.expected {
+			t.Errorf("Test %q : Expected %q, but got %q", test.text, test.expected, actual)
+		}
+	}
+}
+
+func atoi(s string) int {
+	i, _ := strconv.Atoi(s)
+	return i
+}
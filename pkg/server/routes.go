@@ -0,0 +1,104 @@
+package server
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/config"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/apikey"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/audit"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/course"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/department"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/lecturer"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/staff"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/student"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/middleware"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+)
+
+// legacySuccessors are the path prefixes of the routes of before /api/v1
+// and the /api/v1 collections replacing them
+var legacySuccessors = map[string]string{
+	"/student":    "/api/v1/students",
+	"/lecturer":   "/api/v1/lecturers",
+	"/staff":      "/api/v1/staff",
+	"/course":     "/api/v1/courses",
+	"/department": "/api/v1/departments",
+	"/apikey":     "/api/v1/api-keys",
+	"/audit":      "/api/v1/audit",
+}
+
+// handlers are the handlers of every resource, shared by the legacy routes
+// and every version of the API
+type handlers struct {
+	student    *student.StudentHandler
+	lecturer   *lecturer.LecturerHandler
+	staff      *staff.StaffHandler
+	course     *course.CourseHandler
+	department *department.DepartmentHandler
+	apiKey     *apikey.APIKeyHandler
+	audit      *audit.AuditHandler
+}
+
+func newHandlers(repos *repository.Repositories) *handlers {
+	return &handlers{
+		student:    student.NewStudentHandler(repos.Students, repos.Enrolments, repos.Grades),
+		lecturer:   lecturer.NewLecturerHandler(repos.Lecturers, repos.Courses),
+		staff:      staff.NewStaffHandler(repos.Staff),
+		course:     course.NewCourseHandler(repos.Courses, repos.Lecturers),
+		department: department.NewDepartmentHandler(repos.Departments),
+		apiKey:     apikey.NewAPIKeyHandler(repos.APIKeys),
+		audit:      audit.NewAuditHandler(repos.Audit),
+	}
+}
+
+// apiVersion is a version of the API, served under /api/{name}. Each version
+// registers its own routes on its own subrouter, so a new version is added
+// to apiVersions with the routes it changes and leaves the routes and
+// handlers of the older versions alone. A version that only changes some of
+// the resources registers the routes of the previous version for the others
+type apiVersion struct {
+	name   string
+	routes func(r *mux.Router, h *handlers, policy *auth.Policy)
+}
+
+var apiVersions = []apiVersion{
+	{name: "v1", routes: v1Routes},
+}
+
+// apiRoutes registers the routes of every version of the API
+func apiRoutes(router *mux.Router, h *handlers, policy *auth.Policy) {
+	for _, version := range apiVersions {
+		version.routes(router.PathPrefix("/api/"+version.name).Subrouter(), h, policy)
+	}
+}
+
+// v1Routes registers the routes of /api/v1, the records are addressed by
+// their path, eg. /api/v1/students/{id}
+func v1Routes(r *mux.Router, h *handlers, policy *auth.Policy) {
+	h.student.V1Routes(r.PathPrefix("/students").Subrouter(), policy)
+	h.lecturer.V1Routes(r.PathPrefix("/lecturers").Subrouter(), policy)
+	h.staff.V1Routes(r.PathPrefix("/staff").Subrouter(), policy)
+	h.course.V1Routes(r.PathPrefix("/courses").Subrouter(), policy)
+	h.department.V1Routes(r.PathPrefix("/departments").Subrouter(), policy)
+	h.apiKey.V1Routes(r.PathPrefix("/api-keys").Subrouter(), policy)
+	h.audit.V1Routes(r.PathPrefix("/audit").Subrouter(), policy)
+}
+
+// legacyRoutes registers the routes of before /api/v1, eg.
+// /student/getStudent/{id}
+func legacyRoutes(router *mux.Router, h *handlers, policy *auth.Policy) {
+	h.student.StudentRoutes(router.PathPrefix("/student").Subrouter(), policy)
+	h.lecturer.LecturerRoutes(router.PathPrefix("/lecturer").Subrouter(), policy)
+	h.staff.StaffRoutes(router.PathPrefix("/staff").Subrouter(), policy)
+	h.course.CourseRoutes(router.PathPrefix("/course").Subrouter(), policy)
+	h.department.DepartmentRoutes(router.PathPrefix("/department").Subrouter(), policy)
+	h.apiKey.APIKeyRoutes(router.PathPrefix("/apikey").Subrouter(), policy)
+	h.audit.AuditRoutes(router.PathPrefix("/audit").Subrouter(), policy)
+}
+
+// legacyDeprecation marks the responses of the legacy routes as deprecated,
+// going away on the sunset of cfg, and links the /api/v1 collection
+// replacing them
+func legacyDeprecation(cfg config.ServerConfig) mux.MiddlewareFunc {
+	return middleware.Deprecated(cfg.LegacyDeprecated, cfg.LegacySunset, legacySuccessors)
+}
@@ -2,62 +2,111 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/lecturer"
-	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/staff"
-	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/handlers/student"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/auth"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/config"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/middleware"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/delivery/http/response"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/jobs"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/migrations"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/models"
+	"github.com/shashaneRanasinghe/simpleAPI/internal/repository"
+	ak "github.com/shashaneRanasinghe/simpleAPI/internal/usecases/apikey"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/consts"
 	"github.com/shashaneRanasinghe/simpleAPI/pkg/database"
+	"github.com/shashaneRanasinghe/simpleAPI/pkg/numbering"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/tryfix/log"
 )
 
 // The Serve function creates the server
-func Serve() chan string {
+func Serve(cfg *config.Config) chan string {
 	router := mux.NewRouter()
 
 	server := http.Server{
-		Addr:         ":8001",
+		Addr:         cfg.Server.Address(),
 		Handler:      router,
-		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
 	}
 
-	db := database.NewDatabase()
-	db.InitDatabase()
-	conn := db.GetConnection()
+	repos := openStorage(cfg.Database, repository.Numbers{
+		// the patterns were validated with the configuration
+		Students: numbering.MustParse(cfg.Numbers.Student),
+		Staff:    numbering.MustParse(cfg.Numbers.Staff),
+	})
+
+	// the legacy routes are marked ahead of the authentication, so its
+	// rejections are marked as well
+	if cfg.Server.LegacyRoutes {
+		router.Use(legacyDeprecation(cfg.Server))
+	}
 
-	st := student.NewStudentHandler(conn)
-	studentRouter := router.PathPrefix("/student").Subrouter()
-	st.StudentRoutes(studentRouter)
+	// the deadline covers the API key lookup as well
+	router.Use(middleware.DBTimeout(cfg.Database.Timeout))
 
-	lec := lecturer.NewLecturerHandler(conn)
-	lecturerRouter := router.PathPrefix("/lecturer").Subrouter()
-	lec.LecturerRoutes(lecturerRouter)
+	var policy *auth.Policy
+	if cfg.Auth.Enabled {
+		// an API key is accepted instead of a bearer token
+		router.Use(middleware.APIKey(ak.NewAPIKey(repos.APIKeys)))
+
+		keys, err := auth.LoadKeySet(cfg.Auth.Keys)
+		if err != nil {
+			log.Fatal("Error loading the auth keys ", err)
+		}
+		verifier := auth.NewVerifier(keys, cfg.Auth.Issuer, cfg.Auth.Audience)
+		router.Use(middleware.Authenticate(verifier, cfg.Auth.AllowPaths))
+
+		policy = auth.DefaultPolicy()
+		if cfg.Auth.Policy != "" {
+			policy, err = auth.LoadPolicy(cfg.Auth.Policy)
+			if err != nil {
+				log.Fatal("Error loading the auth policy ", err)
+			}
+		}
+	} else {
+		log.Warn("Authentication is disabled, every route is public")
+	}
 
-	staffRouter := router.PathPrefix("/staff").Subrouter()
-	staff.StaffRoutes(staffRouter, conn)
+	h := newHandlers(repos)
+	apiRoutes(router, h, policy)
+	if cfg.Server.LegacyRoutes {
+		legacyRoutes(router, h, policy)
+	} else {
+		log.Info("The legacy routes are disabled, only /api is served")
+	}
 
 	router.Handle("/metrics", promhttp.Handler())
+	router.HandleFunc("/health", health).Methods("GET")
+
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	if cfg.Retention.PurgeInterval > 0 {
+		go jobs.Purge(jobsCtx, cfg.Retention.PurgeInterval, cfg.Retention.Deleted, map[string]jobs.Purger{
+			"students":  repos.Students,
+			"lecturers": repos.Lecturers,
+		})
+	}
 
 	closeChannel := make(chan string)
 
 	//This goroutine will make sure that the service is stopped gracefully
 	go func() {
-		sig := make(chan os.Signal)
+		sig := make(chan os.Signal, 1)
 		signal.Notify(sig, os.Interrupt)
 		signal.Notify(sig, syscall.SIGTERM)
 		signal.Notify(sig, syscall.SIGQUIT)
 		<-sig
 
 		log.Info("service interruption received")
+		stopJobs()
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 		defer cancel()
 
 		err := server.Shutdown(ctx)
@@ -79,3 +128,52 @@ func Serve() chan string {
 
 	return closeChannel
 }
+
+// health reports that the service is up, it is served without a token
+func health(w http.ResponseWriter, r *http.Request) {
+	response.Write(w, http.StatusOK, models.Response[interface{}]{
+		Status:  consts.Success,
+		Message: consts.Healthy,
+	})
+}
+
+// openStorage returns the repositories of the configured storage driver,
+// applying the pending migrations first when auto migrate is on. The new
+// students and staff are numbered with numbers, and so are the stored ones
+// without a number
+func openStorage(cfg config.DBConnection, numbers repository.Numbers) *repository.Repositories {
+	if cfg.Driver == config.DriverMemory {
+		log.Info("Using the in memory storage, nothing will be persisted")
+		return repository.NewMemoryRepositories(numbers)
+	}
+
+	db := database.NewDatabase()
+	db.InitDatabase(cfg)
+	conn := db.GetConnection()
+
+	if cfg.AutoMigrate {
+		migrator, err := migrations.New(conn, cfg.Driver)
+		if err != nil {
+			log.Fatal("Error loading migrations ", err)
+		}
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			log.Fatal("Error applying migrations ", err)
+		}
+		log.Info(fmt.Sprintf("Applied %d migrations", applied))
+	}
+
+	dialect := repository.MySQL
+	if cfg.Driver == config.DriverSQLite {
+		dialect = repository.SQLite
+	}
+
+	numbered, err := repository.NumberExisting(context.Background(), conn, dialect, numbers)
+	if err != nil {
+		log.Fatal("Error numbering the existing students and staff ", err)
+	}
+	if numbered != 0 {
+		log.Info(fmt.Sprintf("Numbered %d existing students and staff", numbered))
+	}
+	return repository.NewSQLRepositories(conn, dialect, numbers)
+}